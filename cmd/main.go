@@ -2,14 +2,20 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/dormitory-life/core/internal/announcements"
 	"github.com/dormitory-life/core/internal/auth"
 	"github.com/dormitory-life/core/internal/broker"
 	"github.com/dormitory-life/core/internal/cache"
 	"github.com/dormitory-life/core/internal/config"
 	"github.com/dormitory-life/core/internal/database"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
 	"github.com/dormitory-life/core/internal/emailer"
 	"github.com/dormitory-life/core/internal/logger"
 	"github.com/dormitory-life/core/internal/server"
@@ -26,7 +32,17 @@ import (
 // @BasePath /
 // @schemes http https
 
+const recomputeGradesCommand = "recompute-grades"
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == recomputeGradesCommand {
+		if err := recomputeGrades(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
 	configPath := os.Args[1]
 	cfg, err := config.ParseConfig(configPath)
 	if err != nil {
@@ -110,6 +126,22 @@ func main() {
 		panic(err)
 	}
 
+	announcementClient := announcements.New(&announcements.AnnouncementClientConfig{
+		Broker:  brokerClient,
+		Emailer: emailer,
+		Logger:  *logger,
+	})
+
+	announcementConsumer := announcements.NewAnnouncementConsumer(&announcements.AnnouncementConsumerConfig{
+		Broker:             brokerClient,
+		AnnouncementClient: announcementClient,
+		Logger:             *logger,
+	})
+
+	if err := announcementConsumer.Start(context.Background()); err != nil {
+		panic(err)
+	}
+
 	cacheClient, err := cache.NewCacheClient(&cache.Config{
 		Addr:        cfg.Cache.Addr,
 		Password:    cfg.Cache.Password,
@@ -122,13 +154,16 @@ func main() {
 	}
 
 	coreService := core.New(core.CoreServiceConfig{
-		Repository:    repository,
-		AuthClient:    authClient,
-		Logger:        *logger,
-		S3Client:      s3Client,
-		BrokerClient:  &brokerClient,
-		SupportClient: supportClient,
-		CacheClient:   cacheClient,
+		Repository:         repository,
+		AuthClient:         authClient,
+		Logger:             *logger,
+		S3Client:           s3Client,
+		BrokerClient:       &brokerClient,
+		SupportClient:      supportClient,
+		CacheClient:        cacheClient,
+		Emailer:            emailer,
+		Ratings:            core.RatingsConfig(cfg.Ratings),
+		AnnouncementClient: announcementClient,
 	})
 
 	s := server.New(server.ServerConfig{
@@ -139,3 +174,96 @@ func main() {
 
 	panic(s.Start())
 }
+
+// recomputeGrades пересобирает помесячные средние из сырых оценок:
+//
+//	core recompute-grades --config configs/config.yaml --dormitory 9 --from 2024-01 --dry-run
+func recomputeGrades(args []string) error {
+	flags := flag.NewFlagSet(recomputeGradesCommand, flag.ContinueOnError)
+
+	var (
+		configPath  = flags.String("config", "configs/config.yaml", "path to config file")
+		dormitoryId = flags.String("dormitory", "", "dormitory id, all dormitories if empty")
+		from        = flags.String("from", "", "first month to recompute, YYYY-MM")
+		to          = flags.String("to", "", "last month to recompute, YYYY-MM")
+		dryRun      = flags.Bool("dry-run", false, "print the diff and roll back")
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	request := &dbtypes.RecomputeAvgGradesRequest{
+		DormitoryId: *dormitoryId,
+		DryRun:      *dryRun,
+	}
+
+	for _, bound := range []struct {
+		name  string
+		value string
+		dst   **time.Time
+	}{
+		{"from", *from, &request.From},
+		{"to", *to, &request.To},
+	} {
+		if bound.value == "" {
+			continue
+		}
+
+		period, err := time.Parse("2006-01", bound.value)
+		if err != nil {
+			return fmt.Errorf("invalid --%s, expected YYYY-MM: %w", bound.name, err)
+		}
+
+		*bound.dst = &period
+	}
+
+	if request.From != nil && request.To != nil && request.From.After(*request.To) {
+		return fmt.Errorf("--from must not be after --to")
+	}
+
+	cfg, err := config.ParseConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	db, err := database.InitDb(cfg.Db)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	resp, err := database.New(db).RecomputeAvgGrades(context.Background(), request)
+	if err != nil {
+		return fmt.Errorf("error recomputing grades: %w", err)
+	}
+
+	for _, change := range resp.Changes {
+		fmt.Printf(
+			"%s\t%s\t%s\t%s -> %s\n",
+			change.DormitoryId,
+			change.PeriodDate.Format("2006-01"),
+			change.Field,
+			formatAvgGradeValue(change.Before),
+			formatAvgGradeValue(change.After),
+		)
+	}
+
+	status := "committed"
+	if request.DryRun {
+		status = "dry run, rolled back"
+	}
+
+	fmt.Printf("recomputed %d periods, %d values changed (%s)\n", resp.Periods, len(resp.Changes), status)
+
+	return nil
+}
+
+func formatAvgGradeValue(val *float64) string {
+	if val == nil {
+		return "-"
+	}
+
+	return strconv.FormatFloat(*val, 'f', -1, 64)
+}
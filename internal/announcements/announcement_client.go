@@ -0,0 +1,95 @@
+package announcements
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dormitory-life/core/internal/broker"
+	"github.com/dormitory-life/core/internal/emailer"
+)
+
+type AnnouncementClient interface {
+	PublishAnnouncementMessage(ctx context.Context, msg *AnnouncementMessage) error
+	ProcessAnnouncementMessage(ctx context.Context, announcementJob *AnnouncementJob) error
+}
+
+type AnnouncementClientConfig struct {
+	Broker  broker.BrokerClient
+	Emailer *emailer.Emailer
+	Logger  slog.Logger
+}
+
+type AnnouncementSvc struct {
+	producer AnnouncementProducer
+	emailer  *emailer.Emailer
+	logger   slog.Logger
+}
+
+func New(cfg *AnnouncementClientConfig) AnnouncementClient {
+	producer := NewAnnouncementProducer(AnnouncementProducerConfig{
+		Broker: cfg.Broker,
+		Logger: cfg.Logger,
+	})
+
+	return &AnnouncementSvc{
+		producer: producer,
+		emailer:  cfg.Emailer,
+		logger:   cfg.Logger,
+	}
+}
+
+func (s *AnnouncementSvc) PublishAnnouncementMessage(
+	ctx context.Context,
+	msg *AnnouncementMessage,
+) error {
+	return s.producer.PublishAnnouncementMessage(ctx, msg)
+}
+
+// ProcessAnnouncementMessage рассылает письмо жильцам. Сообщение не возвращается в очередь:
+// повтор разослал бы письмо тем, кто его уже получил, а через время срочное объявление не нужно
+func (s *AnnouncementSvc) ProcessAnnouncementMessage(
+	ctx context.Context,
+	announcementJob *AnnouncementJob,
+) error {
+	if announcementJob == nil {
+		return fmt.Errorf("%w: message is nil", ErrBadRequest)
+	}
+
+	var (
+		msg    = announcementJob.msg
+		failed int
+	)
+
+	for _, email := range msg.UserEmails {
+		if err := s.emailer.SendAnnouncementMessage(ctx, &emailer.SendAnnouncementRequest{
+			UserEmail:     email,
+			DormitoryName: msg.DormitoryName,
+			Title:         msg.Title,
+			Description:   msg.Description,
+		}); err != nil {
+			failed++
+
+			s.logger.Warn("error sending announcement mail", slog.String("to", email), slog.String("error", err.Error()))
+		}
+	}
+
+	if failed != 0 && failed == len(msg.UserEmails) {
+		if nackErr := announcementJob.job.Nack(false, false); nackErr != nil {
+			s.logger.Error("error nack message", slog.String("title", msg.Title), slog.String("error", nackErr.Error()))
+		}
+
+		return fmt.Errorf("%w: error sending announcement %q to all %d recipients", ErrInternal, msg.Title, failed)
+	}
+
+	if err := announcementJob.job.Ack(false); err != nil {
+		return fmt.Errorf("%w: error ack message: %v", ErrInternal, err)
+	}
+
+	s.logger.Debug("sent announcement mails",
+		slog.String("title", msg.Title),
+		slog.Int("sent", len(msg.UserEmails)-failed),
+		slog.Int("failed", failed))
+
+	return nil
+}
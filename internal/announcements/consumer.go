@@ -0,0 +1,88 @@
+package announcements
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/dormitory-life/core/internal/broker"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type AnnouncementConsumerConfig struct {
+	Broker             broker.BrokerClient
+	AnnouncementClient AnnouncementClient
+	Logger             slog.Logger
+}
+
+type AnnouncementConsumer struct {
+	broker             broker.BrokerClient
+	announcementClient AnnouncementClient
+	logger             slog.Logger
+}
+
+func NewAnnouncementConsumer(cfg *AnnouncementConsumerConfig) AnnouncementConsumer {
+	return AnnouncementConsumer{
+		broker:             cfg.Broker,
+		announcementClient: cfg.AnnouncementClient,
+		logger:             cfg.Logger,
+	}
+}
+
+func (c *AnnouncementConsumer) Start(ctx context.Context) error {
+	msgStream, err := c.broker.ConsumeAnnouncementMessages(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting announcement consumer: %w", err)
+	}
+
+	c.logger.Debug("announcement consumer started")
+
+	go c.ProcessAnnouncementMessages(ctx, msgStream)
+
+	return nil
+}
+
+func (c *AnnouncementConsumer) ProcessAnnouncementMessages(ctx context.Context, msgStream <-chan amqp.Delivery) {
+	c.logger.Debug("processing announcement messages")
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("Context cancelled - stopping announcement message processing", slog.String("error", ctx.Err().Error()))
+			return
+		case delivery, ok := <-msgStream:
+			if !ok {
+				c.logger.Error("Message stream channel closed - broker connection lost")
+				return
+			}
+
+			c.handleDelivery(ctx, delivery)
+		}
+	}
+}
+
+func (c *AnnouncementConsumer) handleDelivery(ctx context.Context, delivery amqp.Delivery) {
+	var announcementMessage AnnouncementMessage
+	if err := json.Unmarshal(delivery.Body, &announcementMessage); err != nil {
+		c.logger.Error("failed to unmarshal announcement message - dropping",
+			slog.Any("message", delivery.Body),
+			"error", err)
+
+		// a malformed message will never parse, requeueing it would loop forever
+		if nackErr := delivery.Nack(false, false); nackErr != nil {
+			c.logger.Error("failed to send NACK", slog.String("error", nackErr.Error()))
+		}
+
+		return
+	}
+
+	announcementJob := &AnnouncementJob{
+		msg: announcementMessage,
+		job: delivery,
+	}
+
+	if err := c.announcementClient.ProcessAnnouncementMessage(ctx, announcementJob); err != nil {
+		c.logger.Error("error processing announcement message", slog.String("error", err.Error()))
+	}
+}
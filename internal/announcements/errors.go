@@ -0,0 +1,8 @@
+package announcements
+
+import "errors"
+
+var (
+	ErrBadRequest = errors.New("bad request")
+	ErrInternal   = errors.New("internal server error")
+)
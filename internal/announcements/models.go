@@ -0,0 +1,16 @@
+package announcements
+
+import amqp "github.com/rabbitmq/amqp091-go"
+
+// AnnouncementMessage - срочный пост общежития, consumer рассылает его письмом каждому жильцу
+type AnnouncementMessage struct {
+	UserEmails    []string `json:"user_emails"`
+	DormitoryName string   `json:"dormitory_name"`
+	Title         string   `json:"title"`
+	Description   string   `json:"description"`
+}
+
+type AnnouncementJob struct {
+	msg AnnouncementMessage
+	job amqp.Delivery
+}
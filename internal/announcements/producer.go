@@ -0,0 +1,41 @@
+package announcements
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/dormitory-life/core/internal/broker"
+)
+
+type AnnouncementProducer struct {
+	broker broker.BrokerClient
+	logger slog.Logger
+}
+
+type AnnouncementProducerConfig struct {
+	Broker broker.BrokerClient
+	Logger slog.Logger
+}
+
+func NewAnnouncementProducer(cfg AnnouncementProducerConfig) AnnouncementProducer {
+	return AnnouncementProducer{
+		broker: cfg.Broker,
+		logger: cfg.Logger,
+	}
+}
+
+func (p *AnnouncementProducer) PublishAnnouncementMessage(
+	ctx context.Context,
+	msg *AnnouncementMessage,
+) error {
+	jsonMessage, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshalling announcement message: %w", err)
+	}
+
+	p.logger.Debug("publishing announcement message", slog.String("title", msg.Title), slog.Int("recipients", len(msg.UserEmails)))
+
+	return p.broker.PublishAnnouncementMessage(ctx, jsonMessage)
+}
@@ -11,6 +11,7 @@ type BrokerClient interface {
 	Disconnect() error
 
 	SupportClient
+	AnnouncementClient
 }
 
 type SupportClient interface {
@@ -18,14 +19,21 @@ type SupportClient interface {
 	ConsumeSupportMessages(ctx context.Context) (<-chan amqp.Delivery, error)
 }
 
+type AnnouncementClient interface {
+	PublishAnnouncementMessage(ctx context.Context, message []byte) error
+	ConsumeAnnouncementMessages(ctx context.Context) (<-chan amqp.Delivery, error)
+}
+
 func New(cfg RabbitMQBrokerConfig) BrokerClient {
 	return newRabbitMQBroker(cfg)
 }
 
 type QueueConfig struct {
-	SupportQueueName string
+	SupportQueueName      string
+	AnnouncementQueueName string
 }
 
 func ConfigureQueues(cfg QueueConfig) {
 	supportQueueName = cfg.SupportQueueName
+	announcementQueueName = cfg.AnnouncementQueueName
 }
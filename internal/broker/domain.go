@@ -1,5 +1,6 @@
 package broker
 
 var (
-	supportQueueName = "support_queue_default"
+	supportQueueName      = "support_queue_default"
+	announcementQueueName = "announcement_queue_default"
 )
@@ -51,22 +51,44 @@ func (r *RabbitMQBroker) Disconnect() error {
 func (r *RabbitMQBroker) PublishSupportMessage(
 	ctx context.Context,
 	message []byte,
+) error {
+	return r.publish(ctx, supportQueueName, message)
+}
+
+func (r *RabbitMQBroker) ConsumeSupportMessages(
+	ctx context.Context,
+) (<-chan amqp.Delivery, error) {
+	return r.consume(ctx, supportQueueName)
+}
+
+func (r *RabbitMQBroker) PublishAnnouncementMessage(
+	ctx context.Context,
+	message []byte,
+) error {
+	return r.publish(ctx, announcementQueueName, message)
+}
+
+func (r *RabbitMQBroker) ConsumeAnnouncementMessages(
+	ctx context.Context,
+) (<-chan amqp.Delivery, error) {
+	return r.consume(ctx, announcementQueueName)
+}
+
+func (r *RabbitMQBroker) publish(
+	ctx context.Context,
+	queueName string,
+	message []byte,
 ) error {
 	ch, err := r.conn.Channel()
 	if err != nil {
 		return fmt.Errorf("error open rmq.Connection.Channel: %w", err)
 	}
+	// каналы ограничены channel_max соединения, незакрытые копятся до его разрыва
+	defer ch.Close()
 
-	queue, err := ch.QueueDeclare(
-		supportQueueName,
-		true,  // durable
-		false, // delete when unused
-		false, // exclusive
-		false, // no-wait
-		nil,   // arguments
-	)
+	queue, err := declareQueue(ch, queueName)
 	if err != nil {
-		return fmt.Errorf("error declaring support queue: %w", err)
+		return err
 	}
 
 	if err := ch.PublishWithContext(ctx,
@@ -79,30 +101,26 @@ func (r *RabbitMQBroker) PublishSupportMessage(
 			Body:         message,
 		},
 	); err != nil {
-		return fmt.Errorf("error publishing support message: %w", err)
+		return fmt.Errorf("error publishing message to %s: %w", queueName, err)
 	}
 
 	return nil
 }
 
-func (r *RabbitMQBroker) ConsumeSupportMessages(
+func (r *RabbitMQBroker) consume(
 	ctx context.Context,
+	queueName string,
 ) (<-chan amqp.Delivery, error) {
 	ch, err := r.conn.Channel()
 	if err != nil {
 		return nil, fmt.Errorf("error open rmq.Connection.Channel: %w", err)
 	}
 
-	queue, err := ch.QueueDeclare(
-		supportQueueName,
-		true,  // durable
-		false, // delete when unused
-		false, // exclusive
-		false, // no-wait
-		nil,   // arguments
-	)
+	queue, err := declareQueue(ch, queueName)
 	if err != nil {
-		return nil, fmt.Errorf("error declaring support queue: %w", err)
+		ch.Close()
+
+		return nil, err
 	}
 
 	messages, err := ch.Consume(
@@ -115,8 +133,26 @@ func (r *RabbitMQBroker) ConsumeSupportMessages(
 		nil,        // args
 	)
 	if err != nil {
-		return nil, fmt.Errorf("error consuming support messages: %w", err)
+		ch.Close()
+
+		return nil, fmt.Errorf("error consuming messages from %s: %w", queueName, err)
 	}
 
 	return messages, nil
 }
+
+func declareQueue(ch *amqp.Channel, queueName string) (amqp.Queue, error) {
+	queue, err := ch.QueueDeclare(
+		queueName,
+		true,  // durable
+		false, // delete when unused
+		false, // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return amqp.Queue{}, fmt.Errorf("error declaring %s queue: %w", queueName, err)
+	}
+
+	return queue, nil
+}
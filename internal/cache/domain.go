@@ -5,4 +5,6 @@ type Category string
 const (
 	CategoryDormitoryList Category = "dormitory_list"
 	CategoryDormitory     Category = "dormitory"
+
+	CategoryGradesDistribution Category = "grades_distribution"
 )
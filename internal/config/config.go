@@ -20,6 +20,7 @@ type Config struct {
 	QueueConfig QueueConfig    `yaml:"broker_queues"`
 	Emailer     EmailerConfig  `yaml:"emailer"`
 	Cache       CacheConfig    `yaml:"cache"`
+	Ratings     RatingsConfig  `yaml:"ratings"`
 }
 
 type DataBaseConfig struct {
@@ -68,7 +69,8 @@ type BrokerConfig struct {
 }
 
 type QueueConfig struct {
-	SupportQueueName string `yaml:"support_queue"`
+	SupportQueueName      string `yaml:"support_queue"`
+	AnnouncementQueueName string `yaml:"announcement_queue"`
 }
 
 type EmailerConfig struct {
@@ -87,6 +89,12 @@ type CacheConfig struct {
 	Timeout     time.Duration `yaml:"timeout"`
 }
 
+type RatingsConfig struct {
+	// Weights - веса критериев для рейтинга общежитий, пустые - веса из реестра критериев
+	Weights    map[string]float64 `yaml:"weights"`
+	MinRatings int                `yaml:"min_ratings"`
+}
+
 func ParseConfig(path string) (*Config, error) {
 	config := &Config{}
 
@@ -3,10 +3,13 @@ package constants
 import "time"
 
 const (
-	CacheDormitoriesKey = "dormitories"
+	CacheDormitoriesKey        = "dormitories"
+	CacheDormitoriesVersionKey = "dormitories_version"
 )
 
 const (
-	DefaultDormitoryListTTL = time.Minute * 30
-	DefaultDormitoryTTL     = time.Minute * 5
+	DefaultDormitoryListTTL        = time.Minute * 30
+	DefaultDormitoryListVersionTTL = time.Hour * 24
+	DefaultDormitoryTTL            = time.Minute * 5
+	DefaultGradesDistributionTTL   = time.Minute * 30
 )
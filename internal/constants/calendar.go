@@ -0,0 +1,13 @@
+package constants
+
+import "time"
+
+const (
+	// CalendarProductId - PRODID календаря в формате RFC 5545
+	CalendarProductId = "-//Dormitory Life//Core//RU"
+	// CalendarUidDomain - правая часть UID событий, левая - id события
+	CalendarUidDomain = "dormitory-life"
+	// CalendarPastWindow - насколько давно закончившиеся события еще попадают в календарь
+	CalendarPastWindow        = 90 * 24 * time.Hour
+	MaxCalendarEvents  uint64 = 500
+)
@@ -0,0 +1,13 @@
+package constants
+
+import "time"
+
+const (
+	// EventsRecurrenceHorizon - насколько вперед разворачиваем повторяющиеся события,
+	// если конец интервала не задан
+	EventsRecurrenceHorizon = 180 * 24 * time.Hour
+	// MaxRecurrenceSteps ограничивает разворачивание одной серии
+	MaxRecurrenceSteps = 5000
+	// MaxWindowSeries - сколько повторяющихся серий читаем для выборки событий по интервалу
+	MaxWindowSeries uint64 = 1000
+)
@@ -0,0 +1,19 @@
+package constants
+
+import "time"
+
+// Пороги поиска накруток оценок
+const (
+	// GradeZeroVarianceMinScores - с какого числа одинаковых баллов оценка считается подозрительной
+	GradeZeroVarianceMinScores = 5
+	// GradeNewAccountAge - аккаунты моложе считаются новыми
+	GradeNewAccountAge = 7 * 24 * time.Hour
+	// GradeActivityWindow - окно, в котором ищется всплеск оценок общежития
+	GradeActivityWindow = 24 * time.Hour
+	// GradeBaselineWindow - период перед окном, по которому считается обычная активность
+	GradeBaselineWindow = 28 * 24 * time.Hour
+	// GradeSpikeFactor - во сколько раз активность в окне должна превысить обычную
+	GradeSpikeFactor = 3
+	// GradeSpikeMinGrades - меньшее число оценок в окне всплеском не считается
+	GradeSpikeMinGrades = 5
+)
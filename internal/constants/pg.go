@@ -10,12 +10,40 @@ const (
 	GradesTable                 string = "grades"
 	DormitoryAvgGradesTableName string = "dormitory_average_grades"
 	ReviewTableName             string = "reviews"
+	ReviewEditsTableName        string = "review_edits"
+	ReviewReportsTableName      string = "review_reports"
+	ReviewRepliesTableName      string = "review_replies"
+	ReviewVotesTableName        string = "review_votes"
+	ReviewAuthorGradesViewName  string = "review_author_grades"
 	FeedTableName               string = "feed"
+	EventRsvpsTableName         string = "event_rsvps"
+	EventOccurrencesTableName   string = "event_occurrences"
 	ChatTableName               string = "chat_messages"
+	AmenitiesTableName          string = "amenities"
+	DormitoryAmenitiesTableName string = "dormitory_amenities"
+	BuildingsTableName          string = "dormitory_buildings"
+	FloorsTableName             string = "dormitory_floors"
+	RoomsTableName              string = "dormitory_rooms"
+	GradeCriteriaTableName      string = "grade_criteria"
+	GradeScoresTableName        string = "grade_scores"
+	DormitoryAvgScoresTableName string = "dormitory_average_scores"
 )
 
 const (
-	DefaultReviewsPageSize    uint64 = 10
-	DefaultPaginationPageSize uint64 = 10
-	DefaultEventsPageSize     uint64 = 15
+	DefaultReviewsPageSize     uint64 = 10
+	DefaultPaginationPageSize  uint64 = 10
+	DefaultEventsPageSize      uint64 = 15
+	DefaultDormitoriesPageSize uint64 = 10
+	MaxDormitoriesPageSize     uint64 = 50
+)
+
+const (
+	// DefaultLeaderboardMinRatings - число оценок, с которым среднее общежития весит
+	// в рейтинге столько же, сколько общее среднее по всем общежитиям
+	DefaultLeaderboardMinRatings int = 10
+)
+
+const (
+	DefaultNearbyRadiusKm float64 = 5
+	MaxNearbyRadiusKm     float64 = 100
 )
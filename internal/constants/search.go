@@ -0,0 +1,16 @@
+package constants
+
+const (
+	// SearchConfig - конфигурация полнотекстового поиска Postgres
+	SearchConfig = "russian"
+	// DefaultSearchGroupLimit - сколько результатов каждого типа отдаем по умолчанию
+	DefaultSearchGroupLimit uint64 = 10
+	MaxSearchGroupLimit     uint64 = 50
+	// MaxSearchQueryLength - ограничение длины поискового запроса в символах
+	MaxSearchQueryLength = 200
+
+	// SearchHighlightStart и SearchHighlightStop - маркеры совпадений в ts_headline.
+	// Перед отдачей клиенту текст экранируется, а маркеры заменяются на <mark>
+	SearchHighlightStart = "⟦"
+	SearchHighlightStop  = "⟧"
+)
@@ -1,6 +1,14 @@
 package constants
 
+import "time"
+
+const (
+	// PresignedURLExpiry - сколько действует подписанная ссылка на файл
+	PresignedURLExpiry = 24 * time.Hour
+)
+
 const (
+	PathDormitory       = "dormitory/%s/"
 	PathDormitoryPhotos = "dormitory/%s/photos/"
 	PathReviewPhotos    = "dormitory/%s/reviews/%s/photos/"
 	PathFeedPhotos      = "dormitory/%s/feed/%s/photos/"
@@ -9,6 +17,7 @@ const (
 type FileCategory = string
 
 const (
+	CategoryDormitoryFiles  FileCategory = "dormitory_files"
 	CategoryDormitoryPhotos FileCategory = "dormitory"
 	CategoryEventPhotos     FileCategory = "event"
 	CategoryReviewPhotos    FileCategory = "review"
@@ -0,0 +1,18 @@
+package constants
+
+import "time"
+
+const (
+	// SyndicationFormatAtom и SyndicationFormatRss - форматы ленты общежития
+	SyndicationFormatAtom = "atom"
+	SyndicationFormatRss  = "rss"
+	// SyndicationIdPrefix - tag URI (RFC 4151), к нему дописывается id общежития или поста
+	SyndicationIdPrefix  = "tag:dormitory-life,2025:"
+	SyndicationGenerator = "Dormitory Life"
+	// SyndicationMaxAge - как часто агрегатору имеет смысл опрашивать ленту
+	SyndicationMaxAge = 10 * time.Minute
+	// SyndicationLinksWindow - ссылки на фото ленты переподписываются в начале каждого окна,
+	// копия из прошлого окна остается рабочей еще не меньше PresignedURLExpiry - окно
+	SyndicationLinksWindow        = PresignedURLExpiry / 2
+	MaxSyndicationEntries  uint64 = 50
+)
@@ -0,0 +1,488 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	"github.com/lib/pq"
+)
+
+func (c *Database) GetAmenities(
+	ctx context.Context,
+	request *dbtypes.GetAmenitiesRequest,
+) (*dbtypes.GetAmenitiesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getAmenities(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) getAmenities(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetAmenitiesRequest,
+) (*dbtypes.GetAmenitiesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		amenitiesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.AmenitiesTableName)
+	)
+
+	queryBuilder := psql.
+		Select("id", "code", "name", "created_at").
+		From(amenitiesTable).
+		OrderBy("name ASC")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get amenities query: %v", dberrors.ErrInternal, err)
+	}
+
+	amenities, err := queryAmenities(ctx, driver, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbtypes.GetAmenitiesResponse{
+		Amenities: amenities,
+	}, nil
+}
+
+func (c *Database) CreateAmenity(
+	ctx context.Context,
+	request *dbtypes.CreateAmenityRequest,
+) (*dbtypes.CreateAmenityResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.createAmenity(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) createAmenity(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.CreateAmenityRequest,
+) (*dbtypes.CreateAmenityResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		amenitiesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.AmenitiesTableName)
+	)
+
+	queryBuilder := psql.Insert(amenitiesTable).
+		Columns("code", "name").
+		Values(request.Code, request.Name).
+		Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building create amenity query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.CreateAmenityResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.AmenityId); err != nil {
+		if pgErrorCode(err) == dberrors.PGErrUniqueViolation {
+			return nil, fmt.Errorf("%w: amenity with code %s already exists", dberrors.ErrConflict, request.Code)
+		}
+
+		return nil, fmt.Errorf("%w: error scanning created amenity: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) UpdateAmenity(
+	ctx context.Context,
+	request *dbtypes.UpdateAmenityRequest,
+) (*dbtypes.UpdateAmenityResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.updateAmenity(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) updateAmenity(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.UpdateAmenityRequest,
+) (*dbtypes.UpdateAmenityResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	if request.Code == nil && request.Name == nil {
+		return nil, fmt.Errorf("%w: nothing to update", dberrors.ErrBadRequest)
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		amenitiesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.AmenitiesTableName)
+	)
+
+	queryBuilder := psql.Update(amenitiesTable).Where(squirrel.Eq{"id": request.AmenityId})
+
+	if request.Code != nil {
+		queryBuilder = queryBuilder.Set("code", request.Code)
+	}
+
+	if request.Name != nil {
+		queryBuilder = queryBuilder.Set("name", request.Name)
+	}
+
+	queryBuilder = queryBuilder.Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building update amenity query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.UpdateAmenityResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.AmenityId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: amenity not found", dberrors.ErrNotFound)
+		}
+
+		if pgErrorCode(err) == dberrors.PGErrUniqueViolation {
+			return nil, fmt.Errorf("%w: amenity with this code already exists", dberrors.ErrConflict)
+		}
+
+		return nil, fmt.Errorf("%w: error scanning updated amenity: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) DeleteAmenity(
+	ctx context.Context,
+	request *dbtypes.DeleteAmenityRequest,
+) (*dbtypes.DeleteAmenityResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.deleteAmenity(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) deleteAmenity(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.DeleteAmenityRequest,
+) (*dbtypes.DeleteAmenityResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		amenitiesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.AmenitiesTableName)
+	)
+
+	// links to dormitories are removed by ON DELETE CASCADE
+	queryBuilder := psql.Delete(amenitiesTable).
+		Where(squirrel.Eq{"id": request.AmenityId}).
+		Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building delete amenity query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.DeleteAmenityResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.AmenityId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: amenity not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error executing delete amenity query: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) GetAmenityDormitories(
+	ctx context.Context,
+	request *dbtypes.GetAmenityDormitoriesRequest,
+) (*dbtypes.GetAmenityDormitoriesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getAmenityDormitories(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) getAmenityDormitories(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetAmenityDormitoriesRequest,
+) (*dbtypes.GetAmenityDormitoriesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		dormitoryAmenitiesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryAmenitiesTableName)
+	)
+
+	queryBuilder := psql.
+		Select("dormitory_id").
+		From(dormitoryAmenitiesTable).
+		Where(squirrel.Eq{"amenity_id": request.AmenityId})
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get amenity dormitories query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get amenity dormitories query: %v", dberrors.ErrInternal, err)
+	}
+
+	defer rows.Close()
+
+	var dormitoryIds []string
+
+	for rows.Next() {
+		var dormitoryId string
+
+		if err := rows.Scan(&dormitoryId); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		dormitoryIds = append(dormitoryIds, dormitoryId)
+	}
+
+	return &dbtypes.GetAmenityDormitoriesResponse{
+		DormitoryIds: dormitoryIds,
+	}, nil
+}
+
+func (c *Database) GetDormitoryAmenities(
+	ctx context.Context,
+	request *dbtypes.GetDormitoryAmenitiesRequest,
+) (*dbtypes.GetDormitoryAmenitiesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getDormitoryAmenities(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) getDormitoryAmenities(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetDormitoryAmenitiesRequest,
+) (*dbtypes.GetDormitoryAmenitiesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		amenitiesTable          = fmt.Sprintf("%s.%s a", constants.SchemaName, constants.AmenitiesTableName)
+		dormitoryAmenitiesTable = fmt.Sprintf("%s.%s da", constants.SchemaName, constants.DormitoryAmenitiesTableName)
+	)
+
+	queryBuilder := psql.
+		Select("a.id", "a.code", "a.name", "a.created_at").
+		From(amenitiesTable).
+		Join(fmt.Sprintf("%s ON da.amenity_id = a.id", dormitoryAmenitiesTable)).
+		Where(squirrel.Eq{"da.dormitory_id": request.DormitoryId}).
+		OrderBy("a.name ASC")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get dormitory amenities query: %v", dberrors.ErrInternal, err)
+	}
+
+	amenities, err := queryAmenities(ctx, driver, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbtypes.GetDormitoryAmenitiesResponse{
+		Amenities: amenities,
+	}, nil
+}
+
+func (c *Database) SetDormitoryAmenities(
+	ctx context.Context,
+	request *dbtypes.SetDormitoryAmenitiesRequest,
+) (*dbtypes.SetDormitoryAmenitiesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var resp *dbtypes.SetDormitoryAmenitiesResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.setDormitoryAmenities(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// setDormitoryAmenities заменяет набор удобств общежития на переданный
+func (c *Database) setDormitoryAmenities(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.SetDormitoryAmenitiesRequest,
+) (*dbtypes.SetDormitoryAmenitiesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		amenitiesTable          = fmt.Sprintf("%s.%s", constants.SchemaName, constants.AmenitiesTableName)
+		dormitoryAmenitiesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryAmenitiesTableName)
+	)
+
+	deleteQuery, deleteArgs, err := psql.Delete(dormitoryAmenitiesTable).
+		Where(squirrel.Eq{"dormitory_id": request.DormitoryId}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building delete dormitory amenities query: %v", dberrors.ErrInternal, err)
+	}
+
+	if _, err := driver.ExecContext(ctx, deleteQuery, deleteArgs...); err != nil {
+		return nil, fmt.Errorf("%w: error executing delete dormitory amenities query: %v", dberrors.ErrInternal, err)
+	}
+
+	if len(request.AmenityCodes) == 0 {
+		return &dbtypes.SetDormitoryAmenitiesResponse{
+			DormitoryId: request.DormitoryId,
+		}, nil
+	}
+
+	insertQuery, insertArgs, err := psql.Insert(dormitoryAmenitiesTable).
+		Columns("dormitory_id", "amenity_id").
+		Select(
+			psql.Select().
+				Column("?::varchar", request.DormitoryId).
+				Column("id").
+				From(amenitiesTable).
+				Where("code = ANY(?)", pq.Array(request.AmenityCodes)),
+		).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building insert dormitory amenities query: %v", dberrors.ErrInternal, err)
+	}
+
+	result, err := driver.ExecContext(ctx, insertQuery, insertArgs...)
+	if err != nil {
+		if pgErrorCode(err) == dberrors.PGErrForeignKeyViolation {
+			return nil, fmt.Errorf("%w: dormitory not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error executing insert dormitory amenities query: %v", dberrors.ErrInternal, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting inserted amenities count: %v", dberrors.ErrInternal, err)
+	}
+
+	if inserted != int64(len(request.AmenityCodes)) {
+		return nil, fmt.Errorf("%w: unknown amenity codes in %v", dberrors.ErrBadRequest, request.AmenityCodes)
+	}
+
+	return &dbtypes.SetDormitoryAmenitiesResponse{
+		DormitoryId: request.DormitoryId,
+	}, nil
+}
+
+func queryAmenities(
+	ctx context.Context,
+	driver Driver,
+	query string,
+	args ...any,
+) ([]dbtypes.Amenity, error) {
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get amenities query: %v", dberrors.ErrInternal, err)
+	}
+
+	defer rows.Close()
+
+	var amenities []dbtypes.Amenity
+
+	for rows.Next() {
+		var amenity dbtypes.Amenity
+
+		if err := rows.Scan(
+			&amenity.Id,
+			&amenity.Code,
+			&amenity.Name,
+			&amenity.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		amenities = append(amenities, amenity)
+	}
+
+	return amenities, nil
+}
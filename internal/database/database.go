@@ -3,12 +3,16 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log"
+	"strings"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 
 	"github.com/dormitory-life/core/internal/config"
 	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
 	dbtypes "github.com/dormitory-life/core/internal/database/types"
 	"github.com/dormitory-life/utils/migrator"
 )
@@ -40,25 +44,75 @@ type Repository interface {
 	GetDormitoryById(ctx context.Context, request *dbtypes.GetDormitoryByIdRequest) (*dbtypes.GetDormitoryByIdResponse, error)
 	CreateDormitory(ctx context.Context, request *dbtypes.CreateDormitoryRequest) (*dbtypes.CreateDormitoryResponse, error)
 	UpdateDormitory(ctx context.Context, request *dbtypes.UpdateDormitoryRequest) (*dbtypes.UpdateDormitoryResponse, error)
+	DeleteDormitory(ctx context.Context, request *dbtypes.DeleteDormitoryRequest) (*dbtypes.DeleteDormitoryResponse, error)
+	GetNearbyDormitories(ctx context.Context, request *dbtypes.GetNearbyDormitoriesRequest) (*dbtypes.GetNearbyDormitoriesResponse, error)
+
+	GetAmenities(ctx context.Context, request *dbtypes.GetAmenitiesRequest) (*dbtypes.GetAmenitiesResponse, error)
+	CreateAmenity(ctx context.Context, request *dbtypes.CreateAmenityRequest) (*dbtypes.CreateAmenityResponse, error)
+	UpdateAmenity(ctx context.Context, request *dbtypes.UpdateAmenityRequest) (*dbtypes.UpdateAmenityResponse, error)
+	DeleteAmenity(ctx context.Context, request *dbtypes.DeleteAmenityRequest) (*dbtypes.DeleteAmenityResponse, error)
+	GetAmenityDormitories(ctx context.Context, request *dbtypes.GetAmenityDormitoriesRequest) (*dbtypes.GetAmenityDormitoriesResponse, error)
+	GetDormitoryAmenities(ctx context.Context, request *dbtypes.GetDormitoryAmenitiesRequest) (*dbtypes.GetDormitoryAmenitiesResponse, error)
+	SetDormitoryAmenities(ctx context.Context, request *dbtypes.SetDormitoryAmenitiesRequest) (*dbtypes.SetDormitoryAmenitiesResponse, error)
+
+	GetDormitoryRooms(ctx context.Context, request *dbtypes.GetDormitoryRoomsRequest) (*dbtypes.GetDormitoryRoomsResponse, error)
+	GetDormitoryOccupancy(ctx context.Context, request *dbtypes.GetDormitoryOccupancyRequest) (*dbtypes.GetDormitoryOccupancyResponse, error)
+	CreateBuilding(ctx context.Context, request *dbtypes.CreateBuildingRequest) (*dbtypes.CreateBuildingResponse, error)
+	DeleteBuilding(ctx context.Context, request *dbtypes.DeleteBuildingRequest) (*dbtypes.DeleteBuildingResponse, error)
+	CreateFloor(ctx context.Context, request *dbtypes.CreateFloorRequest) (*dbtypes.CreateFloorResponse, error)
+	DeleteFloor(ctx context.Context, request *dbtypes.DeleteFloorRequest) (*dbtypes.DeleteFloorResponse, error)
+	CreateRoom(ctx context.Context, request *dbtypes.CreateRoomRequest) (*dbtypes.CreateRoomResponse, error)
+	UpdateRoom(ctx context.Context, request *dbtypes.UpdateRoomRequest) (*dbtypes.UpdateRoomResponse, error)
+	DeleteRoom(ctx context.Context, request *dbtypes.DeleteRoomRequest) (*dbtypes.DeleteRoomResponse, error)
 
 	GetDormitoriesAvgGrades(ctx context.Context, request *dbtypes.GetDormitoriesAvgGradesRequest) (*dbtypes.GetDormitoriesAvgGradesResponse, error)
 	GetDormitoryAvgGrades(ctx context.Context, request *dbtypes.GetDormitoryAvgGradesRequest) (*dbtypes.GetDormitoryAvgGradesResponse, error)
 	CreateDormitoryGrade(ctx context.Context, request *dbtypes.CreateDormitoryGradeRequest) (*dbtypes.CreateDormitoryGradeResponse, error)
+	UpdateDormitoryGrade(ctx context.Context, request *dbtypes.UpdateDormitoryGradeRequest) (*dbtypes.UpdateDormitoryGradeResponse, error)
+	DeleteDormitoryGrade(ctx context.Context, request *dbtypes.DeleteDormitoryGradeRequest) (*dbtypes.DeleteDormitoryGradeResponse, error)
+	GetGradesDistribution(ctx context.Context, request *dbtypes.GetGradesDistributionRequest) (*dbtypes.GetGradesDistributionResponse, error)
+	RecomputeAvgGrades(ctx context.Context, request *dbtypes.RecomputeAvgGradesRequest) (*dbtypes.RecomputeAvgGradesResponse, error)
+	GetDormitoriesPeriodScores(ctx context.Context, request *dbtypes.GetDormitoriesPeriodScoresRequest) (*dbtypes.GetDormitoriesPeriodScoresResponse, error)
+	GetGradeSignals(ctx context.Context, request *dbtypes.GetGradeSignalsRequest) (*dbtypes.GetGradeSignalsResponse, error)
+	GetModerationGrades(ctx context.Context, request *dbtypes.GetModerationGradesRequest) (*dbtypes.GetModerationGradesResponse, error)
+	ModerateGrade(ctx context.Context, request *dbtypes.ModerateGradeRequest) (*dbtypes.ModerateGradeResponse, error)
+	GetGradeCriteria(ctx context.Context, request *dbtypes.GetGradeCriteriaRequest) (*dbtypes.GetGradeCriteriaResponse, error)
+	CreateGradeCriterion(ctx context.Context, request *dbtypes.CreateGradeCriterionRequest) (*dbtypes.CreateGradeCriterionResponse, error)
+	UpdateGradeCriterion(ctx context.Context, request *dbtypes.UpdateGradeCriterionRequest) (*dbtypes.UpdateGradeCriterionResponse, error)
 
 	GetEmailsForSupport(ctx context.Context, request *dbtypes.GetEmailsForSupportRequest) (*dbtypes.GetEmailsForSupportResponse, error)
 
 	GetReviews(ctx context.Context, request *dbtypes.GetDormitoryReviewsRequest) (*dbtypes.GetDormitoryReviewsResponse, error)
 	CreateReview(ctx context.Context, request *dbtypes.CreateReviewRequest) (*dbtypes.CreateReviewResponse, error)
+	UpdateReview(ctx context.Context, request *dbtypes.UpdateReviewRequest) (*dbtypes.UpdateReviewResponse, error)
 	DeleteReview(ctx context.Context, request *dbtypes.DeleteReviewRequest) (*dbtypes.DeleteReviewResponse, error)
+	GetReviewEdits(ctx context.Context, request *dbtypes.GetReviewEditsRequest) (*dbtypes.GetReviewEditsResponse, error)
+	CreateReviewReport(ctx context.Context, request *dbtypes.CreateReviewReportRequest) (*dbtypes.CreateReviewReportResponse, error)
+	GetModerationReviews(ctx context.Context, request *dbtypes.GetModerationReviewsRequest) (*dbtypes.GetModerationReviewsResponse, error)
+	ModerateReview(ctx context.Context, request *dbtypes.ModerateReviewRequest) (*dbtypes.ModerateReviewResponse, error)
+	CreateReviewReply(ctx context.Context, request *dbtypes.CreateReviewReplyRequest) (*dbtypes.CreateReviewReplyResponse, error)
+	DeleteReviewReply(ctx context.Context, request *dbtypes.DeleteReviewReplyRequest) (*dbtypes.DeleteReviewReplyResponse, error)
+	VoteReview(ctx context.Context, request *dbtypes.VoteReviewRequest) (*dbtypes.VoteReviewResponse, error)
 
 	GetDormitoryEvents(ctx context.Context, request *dbtypes.GetDormitoryEventsRequest) (*dbtypes.GetDormitoryEventsResponse, error)
 	CreateDormitoryEvent(ctx context.Context, request *dbtypes.CreateDormitoryEventRequest) (*dbtypes.CreateDormitoryEventResponse, error)
+	UpdateDormitoryEvent(ctx context.Context, request *dbtypes.UpdateDormitoryEventRequest) (*dbtypes.UpdateDormitoryEventResponse, error)
 	DeleteDormitoryEvent(ctx context.Context, request *dbtypes.DeleteDormitoryEventRequest) (*dbtypes.DeleteDormitoryEventResponse, error)
+	RsvpEvent(ctx context.Context, request *dbtypes.RsvpEventRequest) (*dbtypes.RsvpEventResponse, error)
+	GetEventAttendees(ctx context.Context, request *dbtypes.GetEventAttendeesRequest) (*dbtypes.GetEventAttendeesResponse, error)
+	UpdateEventOccurrence(ctx context.Context, request *dbtypes.UpdateEventOccurrenceRequest) (*dbtypes.UpdateEventOccurrenceResponse, error)
+	GetEventsCalendar(ctx context.Context, request *dbtypes.GetEventsCalendarRequest) (*dbtypes.GetEventsCalendarResponse, error)
+	GetEventsSyndication(ctx context.Context, request *dbtypes.GetEventsSyndicationRequest) (*dbtypes.GetEventsSyndicationResponse, error)
+	UpdateEventAnnouncement(ctx context.Context, request *dbtypes.UpdateEventAnnouncementRequest) (*dbtypes.UpdateEventAnnouncementResponse, error)
+
+	SearchDormitory(ctx context.Context, request *dbtypes.SearchDormitoryRequest) (*dbtypes.SearchDormitoryResponse, error)
 
 	GetChatMessages(ctx context.Context, request *dbtypes.GetChatMessagesRequest) (*dbtypes.GetChatMessagesResponse, error)
 	CreateChatMessage(ctx context.Context, request *dbtypes.CreateChatMessageRequest) (*dbtypes.CreateChatMessageResponse, error)
 
 	GetUsersRole(ctx context.Context, request *dbtypes.GetUsersRoleRequest) (*dbtypes.GetUsersRoleResponse, error)
+	GetUserEmail(ctx context.Context, request *dbtypes.GetUserEmailRequest) (*dbtypes.GetUserEmailResponse, error)
+	GetDormitoryResidentsEmails(ctx context.Context, request *dbtypes.GetDormitoryResidentsEmailsRequest) (*dbtypes.GetDormitoryResidentsEmailsResponse, error)
 	GetReviewById(ctx context.Context, request *dbtypes.GetReviewByIdRequest) (*dbtypes.GetReviewByIdResponse, error)
 }
 
@@ -91,6 +145,36 @@ func InitDb(cfg config.DataBaseConfig) (*sql.DB, error) {
 	return db, nil
 }
 
+// withTx выполняет fn в транзакции, откатывая ее при ошибке
+func (c *Database) withTx(ctx context.Context, fn func(driver Driver) error) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w: error starting transaction: %v", dberrors.ErrInternal, err)
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%w: error committing transaction: %v", dberrors.ErrInternal, err)
+	}
+
+	return nil
+}
+
+// pgErrorCode возвращает код ошибки Postgres или пустую строку
+func pgErrorCode(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+
+	return ""
+}
+
 func countOffset(page, pageSize uint64) uint64 {
 	if page == 0 {
 		page = 1
@@ -102,3 +186,8 @@ func countOffset(page, pageSize uint64) uint64 {
 
 	return (page - 1) * pageSize
 }
+
+// escapeLike экранирует спецсимволы шаблона LIKE в пользовательском вводе
+func escapeLike(val string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(val)
+}
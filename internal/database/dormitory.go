@@ -4,11 +4,19 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/dormitory-life/core/internal/constants"
 	dberrors "github.com/dormitory-life/core/internal/database/errors"
 	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	"github.com/lib/pq"
+)
+
+const (
+	earthRadiusKm       = 6371.0
+	kmPerLatitudeDegree = 111.32
+	minLongitudeScale   = 0.01
 )
 
 func (c *Database) GetDormitories(
@@ -39,12 +47,47 @@ func (c *Database) getDormitories(
 	var (
 		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
-		dormitoryTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryTableName)
+		dormitoryTable          = fmt.Sprintf("%s.%s d", constants.SchemaName, constants.DormitoryTableName)
+		dormitoryAvgGradesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryAvgGradesTableName)
+
+		// rating of the dormitory is the overall average of its latest period
+		ratingJoin = fmt.Sprintf(
+			"LEFT JOIN LATERAL (SELECT dag.overall_average FROM %s dag WHERE dag.dormitory_id = d.id ORDER BY dag.period_date DESC LIMIT 1) g ON TRUE",
+			dormitoryAvgGradesTable,
+		)
 	)
 
-	queryBuilder := psql.
-		Select("id", "name", "address", "support_email", "description").
-		From(dormitoryTable)
+	pageSize := request.PageSize
+	if pageSize == 0 {
+		pageSize = constants.DefaultDormitoriesPageSize
+	}
+
+	countBuilder := setupDormitoriesFilters(
+		psql.Select("COUNT(*)").From(dormitoryTable).JoinClause(ratingJoin),
+		request,
+	)
+
+	countQuery, countArgs, err := countBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building count dormitories query: %v", dberrors.ErrInternal, err)
+	}
+
+	var total uint64
+
+	if err := driver.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("%w: error executing count dormitories query: %v", dberrors.ErrInternal, err)
+	}
+
+	queryBuilder := setupDormitoriesFilters(
+		psql.
+			Select("d.id", "d.name", "d.address", "d.support_email", "d.description", "d.latitude", "d.longitude", "COALESCE(g.overall_average, 0)").
+			From(dormitoryTable).
+			JoinClause(ratingJoin),
+		request,
+	).
+		OrderBy(dormitoriesOrderBy(request)...).
+		Offset(countOffset(request.Page, pageSize)).
+		Limit(pageSize)
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
@@ -69,6 +112,9 @@ func (c *Database) getDormitories(
 			&dormitory.Address,
 			&dormitory.SupportEmail,
 			&dormitory.Description,
+			&dormitory.Latitude,
+			&dormitory.Longitude,
+			&dormitory.OverallAverage,
 		); err != nil {
 			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
 		}
@@ -78,9 +124,69 @@ func (c *Database) getDormitories(
 
 	return &dbtypes.GetDormitoriesResponse{
 		Dormitories: dormitories,
+		Total:       total,
 	}, nil
 }
 
+func setupDormitoriesFilters(
+	queryBuilder squirrel.SelectBuilder,
+	request *dbtypes.GetDormitoriesRequest,
+) squirrel.SelectBuilder {
+	if request.Search != "" {
+		pattern := "%" + escapeLike(request.Search) + "%"
+
+		queryBuilder = queryBuilder.Where(squirrel.Or{
+			squirrel.ILike{"d.name": pattern},
+			squirrel.ILike{"d.address": pattern},
+		})
+	}
+
+	if request.MinRating != nil {
+		queryBuilder = queryBuilder.Where(squirrel.GtOrEq{"g.overall_average": *request.MinRating})
+	}
+
+	if len(request.Amenities) > 0 {
+		var (
+			amenitiesTable          = fmt.Sprintf("%s.%s", constants.SchemaName, constants.AmenitiesTableName)
+			dormitoryAmenitiesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryAmenitiesTableName)
+		)
+
+		// общежитие должно обладать всеми запрошенными удобствами
+		queryBuilder = queryBuilder.Where(
+			fmt.Sprintf(
+				"d.id IN (SELECT da.dormitory_id FROM %s da JOIN %s a ON a.id = da.amenity_id WHERE a.code = ANY(?) GROUP BY da.dormitory_id HAVING COUNT(DISTINCT a.code) = ?)",
+				dormitoryAmenitiesTable,
+				amenitiesTable,
+			),
+			pq.Array(request.Amenities),
+			len(request.Amenities),
+		)
+	}
+
+	return queryBuilder
+}
+
+func dormitoriesOrderBy(request *dbtypes.GetDormitoriesRequest) []string {
+	direction := "ASC"
+	if request.SortDesc {
+		direction = "DESC"
+	}
+
+	switch request.SortBy {
+	case dbtypes.DormitorySortByRating:
+		return []string{
+			fmt.Sprintf("g.overall_average %s NULLS LAST", direction),
+			"d.name ASC",
+			"d.id ASC",
+		}
+	default:
+		return []string{
+			fmt.Sprintf("d.name %s", direction),
+			"d.id ASC",
+		}
+	}
+}
+
 func (c *Database) GetDormitoryById(
 	ctx context.Context,
 	request *dbtypes.GetDormitoryByIdRequest,
@@ -113,7 +219,7 @@ func (c *Database) getDormitoryById(
 	)
 
 	queryBuilder := psql.
-		Select("id", "name", "address", "support_email", "description").
+		Select("id", "name", "address", "support_email", "description", "latitude", "longitude").
 		From(dormitoryTable).
 		Where(squirrel.Eq{"id": request.DormitoryId}).
 		Limit(1)
@@ -131,6 +237,8 @@ func (c *Database) getDormitoryById(
 		&dormitory.Address,
 		&dormitory.SupportEmail,
 		&dormitory.Description,
+		&dormitory.Latitude,
+		&dormitory.Longitude,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -178,7 +286,7 @@ func (c *Database) createDormitory(
 
 	queryBuilder := psql.Insert(dormitoryTable).
 		Columns(
-			"id", "name", "address", "support_email", "description",
+			"id", "name", "address", "support_email", "description", "latitude", "longitude",
 		).
 		Values(
 			request.DormitoryId,
@@ -186,6 +294,8 @@ func (c *Database) createDormitory(
 			request.Address,
 			request.SupportEmail,
 			request.Description,
+			request.Latitude,
+			request.Longitude,
 		).
 		Suffix("RETURNING id")
 
@@ -286,4 +396,176 @@ func setupUpdateFields(
 	if request.Description != nil {
 		*queryBuilder = queryBuilder.Set("description", request.Description)
 	}
+
+	if request.Latitude != nil {
+		*queryBuilder = queryBuilder.Set("latitude", request.Latitude)
+	}
+
+	if request.Longitude != nil {
+		*queryBuilder = queryBuilder.Set("longitude", request.Longitude)
+	}
+}
+
+func (c *Database) DeleteDormitory(
+	ctx context.Context,
+	request *dbtypes.DeleteDormitoryRequest,
+) (*dbtypes.DeleteDormitoryResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.deleteDormitory(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) deleteDormitory(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.DeleteDormitoryRequest,
+) (*dbtypes.DeleteDormitoryResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		dormitoryTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryTableName)
+	)
+
+	// grades, reviews, feed and chat rows are removed by ON DELETE CASCADE
+	queryBuilder := psql.Delete(dormitoryTable).
+		Where(squirrel.Eq{"id": request.DormitoryId}).
+		Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building delete dormitory query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.DeleteDormitoryResponse
+
+	err = driver.QueryRowContext(ctx, query, args...).Scan(
+		&resp.DormitoryId,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: dormitory not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error executing delete dormitory query: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) GetNearbyDormitories(
+	ctx context.Context,
+	request *dbtypes.GetNearbyDormitoriesRequest,
+) (*dbtypes.GetNearbyDormitoriesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getNearbyDormitories(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) getNearbyDormitories(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetNearbyDormitoriesRequest,
+) (*dbtypes.GetNearbyDormitoriesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		dormitoryTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryTableName)
+
+		// bounding box cuts off far rows before the exact great-circle distance is computed
+		latDelta = request.RadiusKm / kmPerLatitudeDegree
+		lonDelta = request.RadiusKm / (kmPerLatitudeDegree * math.Max(math.Cos(request.Latitude*math.Pi/180), minLongitudeScale))
+	)
+
+	// haversine great-circle distance in kilometers
+	distanceExpr := squirrel.Expr(
+		fmt.Sprintf(
+			"2 * %g * ASIN(LEAST(1, SQRT(POWER(SIN(RADIANS(latitude - ?) / 2), 2) + COS(RADIANS(?)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - ?) / 2), 2))))",
+			earthRadiusKm,
+		),
+		request.Latitude, request.Latitude, request.Longitude,
+	)
+
+	innerBuilder := psql.
+		Select("id", "name", "address", "support_email", "description", "latitude", "longitude").
+		Column(squirrel.Alias(distanceExpr, "distance_km")).
+		From(dormitoryTable).
+		Where(squirrel.NotEq{"latitude": nil, "longitude": nil}).
+		Where(squirrel.And{
+			squirrel.GtOrEq{"latitude": request.Latitude - latDelta},
+			squirrel.LtOrEq{"latitude": request.Latitude + latDelta},
+		})
+
+	// near the antimeridian the longitude window wraps around, so it is not applied there
+	if request.Longitude-lonDelta >= -180 && request.Longitude+lonDelta <= 180 {
+		innerBuilder = innerBuilder.Where(squirrel.And{
+			squirrel.GtOrEq{"longitude": request.Longitude - lonDelta},
+			squirrel.LtOrEq{"longitude": request.Longitude + lonDelta},
+		})
+	}
+
+	queryBuilder := psql.
+		Select("id", "name", "address", "support_email", "description", "latitude", "longitude", "distance_km").
+		FromSelect(innerBuilder, "nearby").
+		Where(squirrel.LtOrEq{"distance_km": request.RadiusKm}).
+		OrderBy("distance_km ASC", "id ASC").
+		Limit(request.Limit)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get nearby dormitories query: %v", dberrors.ErrInternal, err)
+	}
+
+	var dormitories []dbtypes.NearbyDormitory
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get nearby dormitories query: %v", dberrors.ErrInternal, err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var dormitory dbtypes.NearbyDormitory
+
+		if err := rows.Scan(
+			&dormitory.Dormitory.Id,
+			&dormitory.Dormitory.Name,
+			&dormitory.Dormitory.Address,
+			&dormitory.Dormitory.SupportEmail,
+			&dormitory.Dormitory.Description,
+			&dormitory.Dormitory.Latitude,
+			&dormitory.Dormitory.Longitude,
+			&dormitory.DistanceKm,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		dormitories = append(dormitories, dormitory)
+	}
+
+	return &dbtypes.GetNearbyDormitoriesResponse{
+		Dormitories: dormitories,
+	}, nil
 }
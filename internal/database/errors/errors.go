@@ -3,7 +3,9 @@ package dberrors
 import "errors"
 
 const (
-	PGErrUniqueViolation = "23505"
+	PGErrUniqueViolation     = "23505"
+	PGErrForeignKeyViolation = "23503"
+	PGErrCheckViolation      = "23514"
 )
 
 var (
@@ -0,0 +1,495 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	"github.com/lib/pq"
+)
+
+func (c *Database) RsvpEvent(
+	ctx context.Context,
+	request *dbtypes.RsvpEventRequest,
+) (*dbtypes.RsvpEventResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var resp *dbtypes.RsvpEventResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.rsvpEvent(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// rsvpEvent сохраняет или снимает ответ пользователя. Строка события блокируется первой,
+// поэтому подсчет свободных мест и продвижение листа ожидания не гоняются друг с другом
+func (c *Database) rsvpEvent(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.RsvpEventRequest,
+) (*dbtypes.RsvpEventResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		feedTable       = fmt.Sprintf("%s.%s", constants.SchemaName, constants.FeedTableName)
+		eventRsvpsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.EventRsvpsTableName)
+	)
+
+	lockQuery, lockArgs, err := psql.
+		Select(
+			"capacity",
+			"starts_at IS NOT NULL",
+			// ответ на серию действует на все ее повторения, закрываем его только по recurrence_until
+			"CASE WHEN recurrence_freq IS NULL THEN COALESCE(ends_at, starts_at, 'infinity') "+
+				"ELSE COALESCE(recurrence_until, 'infinity') END < CURRENT_TIMESTAMP",
+		).
+		From(feedTable).
+		Where(squirrel.Eq{
+			"id":           request.EventId,
+			"dormitory_id": request.DormitoryId,
+		}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building lock event query: %v", dberrors.ErrInternal, err)
+	}
+
+	var (
+		capacity  *int
+		scheduled bool
+		finished  bool
+	)
+
+	if err := driver.QueryRowContext(ctx, lockQuery, lockArgs...).Scan(&capacity, &scheduled, &finished); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: event not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error locking event: %v", dberrors.ErrInternal, err)
+	}
+
+	if !scheduled {
+		return nil, fmt.Errorf("%w: post has no schedule, rsvp is not available", dberrors.ErrBadRequest)
+	}
+
+	if finished {
+		return nil, fmt.Errorf("%w: event is already over", dberrors.ErrBadRequest)
+	}
+
+	currentQuery, currentArgs, err := psql.
+		Select("status").
+		From(eventRsvpsTable).
+		Where(squirrel.Eq{
+			"event_id": request.EventId,
+			"user_id":  request.UserId,
+		}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get rsvp query: %v", dberrors.ErrInternal, err)
+	}
+
+	var current dbtypes.RsvpStatus
+
+	if err := driver.QueryRowContext(ctx, currentQuery, currentArgs...).Scan(&current); err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: error getting rsvp: %v", dberrors.ErrInternal, err)
+	}
+
+	var status dbtypes.RsvpStatus
+
+	if request.Status != nil {
+		status = *request.Status
+	}
+
+	if status == dbtypes.RsvpStatusGoing {
+		switch current {
+		case dbtypes.RsvpStatusGoing, dbtypes.RsvpStatusWaitlisted:
+			// уже участвует или стоит в очереди, место в ней не теряем
+			status = current
+		default:
+			counts, err := c.getEventsRsvpCounts(ctx, driver, []string{request.EventId})
+			if err != nil {
+				return nil, err
+			}
+
+			if capacity != nil && counts[request.EventId].Going >= *capacity {
+				status = dbtypes.RsvpStatusWaitlisted
+			}
+		}
+	}
+
+	if status != current {
+		var (
+			rsvpQuery string
+			rsvpArgs  []any
+		)
+
+		if status != "" {
+			rsvpQuery, rsvpArgs, err = psql.Insert(eventRsvpsTable).
+				Columns("event_id", "user_id", "status").
+				Values(request.EventId, request.UserId, status).
+				Suffix("ON CONFLICT (event_id, user_id) DO UPDATE SET status = EXCLUDED.status, updated_at = CURRENT_TIMESTAMP").
+				ToSql()
+		} else {
+			rsvpQuery, rsvpArgs, err = psql.Delete(eventRsvpsTable).
+				Where(squirrel.Eq{
+					"event_id": request.EventId,
+					"user_id":  request.UserId,
+				}).
+				ToSql()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: error building rsvp query: %v", dberrors.ErrInternal, err)
+		}
+
+		if _, err := driver.ExecContext(ctx, rsvpQuery, rsvpArgs...); err != nil {
+			if pgErrorCode(err) == dberrors.PGErrForeignKeyViolation {
+				return nil, fmt.Errorf("%w: user not found", dberrors.ErrNotFound)
+			}
+
+			return nil, fmt.Errorf("%w: error saving rsvp: %v", dberrors.ErrInternal, err)
+		}
+	}
+
+	resp := dbtypes.RsvpEventResponse{
+		EventId: request.EventId,
+		Status:  status,
+	}
+
+	if current == dbtypes.RsvpStatusGoing && status != dbtypes.RsvpStatusGoing {
+		resp.PromotedUserIds, err = c.promoteEventWaitlist(ctx, driver, request.EventId, capacity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	counts, err := c.getEventsRsvpCounts(ctx, driver, []string{request.EventId})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Counts = counts[request.EventId]
+
+	return &resp, nil
+}
+
+// promoteEventWaitlist переводит первых из листа ожидания в участники, пока есть свободные места.
+// Вызывать под блокировкой строки события
+func (c *Database) promoteEventWaitlist(
+	ctx context.Context,
+	driver Driver,
+	eventId string,
+	capacity *int,
+) ([]string, error) {
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		eventRsvpsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.EventRsvpsTableName)
+	)
+
+	waitlist := squirrel.
+		Select("user_id").
+		From(eventRsvpsTable).
+		Where(squirrel.Eq{
+			"event_id": eventId,
+			"status":   dbtypes.RsvpStatusWaitlisted,
+		}).
+		OrderBy("updated_at ASC", "created_at ASC")
+
+	if capacity != nil {
+		counts, err := c.getEventsRsvpCounts(ctx, driver, []string{eventId})
+		if err != nil {
+			return nil, err
+		}
+
+		free := *capacity - counts[eventId].Going
+		if free <= 0 {
+			return nil, nil
+		}
+
+		waitlist = waitlist.Limit(uint64(free))
+	}
+
+	query, args, err := psql.Update(eventRsvpsTable).
+		Set("status", dbtypes.RsvpStatusGoing).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{"event_id": eventId}).
+		Where(squirrel.Expr("user_id IN (?)", waitlist)).
+		Suffix("RETURNING user_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building promote waitlist query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing promote waitlist query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	var promoted []string
+
+	for rows.Next() {
+		var userId string
+
+		if err := rows.Scan(&userId); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		promoted = append(promoted, userId)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return promoted, nil
+}
+
+// getEventsRsvpCounts считает ответы по статусам для каждого события из списка
+func (c *Database) getEventsRsvpCounts(
+	ctx context.Context,
+	driver Driver,
+	eventIds []string,
+) (map[string]dbtypes.EventRsvpCounts, error) {
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		eventRsvpsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.EventRsvpsTableName)
+	)
+
+	query, args, err := psql.
+		Select("event_id", "status", "COUNT(*)").
+		From(eventRsvpsTable).
+		Where("event_id = ANY(?::uuid[])", pq.Array(eventIds)).
+		GroupBy("event_id", "status").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get rsvp counts query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get rsvp counts query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]dbtypes.EventRsvpCounts, len(eventIds))
+
+	for rows.Next() {
+		var (
+			eventId string
+			status  dbtypes.RsvpStatus
+			count   int
+		)
+
+		if err := rows.Scan(&eventId, &status, &count); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		eventCounts := counts[eventId]
+
+		switch status {
+		case dbtypes.RsvpStatusGoing:
+			eventCounts.Going = count
+		case dbtypes.RsvpStatusMaybe:
+			eventCounts.Maybe = count
+		case dbtypes.RsvpStatusNotGoing:
+			eventCounts.NotGoing = count
+		case dbtypes.RsvpStatusWaitlisted:
+			eventCounts.Waitlisted = count
+		}
+
+		counts[eventId] = eventCounts
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return counts, nil
+}
+
+// getUserEventsRsvp возвращает ответы пользователя на события из списка
+func (c *Database) getUserEventsRsvp(
+	ctx context.Context,
+	driver Driver,
+	userId string,
+	eventIds []string,
+) (map[string]dbtypes.RsvpStatus, error) {
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		eventRsvpsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.EventRsvpsTableName)
+	)
+
+	query, args, err := psql.
+		Select("event_id", "status").
+		From(eventRsvpsTable).
+		Where(squirrel.Eq{"user_id": userId}).
+		Where("event_id = ANY(?::uuid[])", pq.Array(eventIds)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get user rsvp query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get user rsvp query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	statuses := make(map[string]dbtypes.RsvpStatus)
+
+	for rows.Next() {
+		var eventId, status string
+
+		if err := rows.Scan(&eventId, &status); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		statuses[eventId] = status
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return statuses, nil
+}
+
+func (c *Database) GetEventAttendees(
+	ctx context.Context,
+	request *dbtypes.GetEventAttendeesRequest,
+) (*dbtypes.GetEventAttendeesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getEventAttendees(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// getEventAttendees возвращает ответы на событие: участники по времени записи,
+// лист ожидания - в порядке очереди
+func (c *Database) getEventAttendees(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetEventAttendeesRequest,
+) (*dbtypes.GetEventAttendeesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		feedTable       = fmt.Sprintf("%s.%s", constants.SchemaName, constants.FeedTableName)
+		eventRsvpsTable = fmt.Sprintf("%s.%s r", constants.SchemaName, constants.EventRsvpsTableName)
+		userTable       = fmt.Sprintf("%s.%s u", constants.SchemaName, constants.UsersTableName)
+	)
+
+	eventQuery, eventArgs, err := psql.
+		Select("capacity").
+		From(feedTable).
+		Where(squirrel.Eq{
+			"id":           request.EventId,
+			"dormitory_id": request.DormitoryId,
+		}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get event query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.GetEventAttendeesResponse
+
+	if err := driver.QueryRowContext(ctx, eventQuery, eventArgs...).Scan(&resp.Capacity); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: event not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error getting event: %v", dberrors.ErrInternal, err)
+	}
+
+	queryBuilder := psql.
+		Select("r.user_id", "u.email", "r.status", "r.created_at", "r.updated_at").
+		From(eventRsvpsTable).
+		Join(fmt.Sprintf("%s ON u.id = r.user_id", userTable)).
+		Where(squirrel.Eq{"r.event_id": request.EventId}).
+		OrderBy(
+			fmt.Sprintf(
+				"CASE r.status WHEN '%s' THEN 0 WHEN '%s' THEN 1 WHEN '%s' THEN 2 ELSE 3 END",
+				dbtypes.RsvpStatusGoing, dbtypes.RsvpStatusWaitlisted, dbtypes.RsvpStatusMaybe,
+			),
+			"r.updated_at ASC",
+			"r.created_at ASC",
+		)
+
+	if request.Status != "" {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"r.status": request.Status})
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get event attendees query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get event attendees query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	resp.Attendees = make([]dbtypes.EventAttendee, 0)
+
+	for rows.Next() {
+		var attendee dbtypes.EventAttendee
+
+		if err := rows.Scan(
+			&attendee.UserId,
+			&attendee.Email,
+			&attendee.Status,
+			&attendee.CreatedAt,
+			&attendee.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		resp.Attendees = append(resp.Attendees, attendee)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	counts, err := c.getEventsRsvpCounts(ctx, driver, []string{request.EventId})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Counts = counts[request.EventId]
+
+	return &resp, nil
+}
@@ -2,7 +2,9 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/dormitory-life/core/internal/constants"
@@ -42,15 +44,50 @@ func (c *Database) getDormitoryEvents(
 	)
 
 	queryBuilder := psql.
-		Select(
-			"id", "dormitory_id", "title", "description", "created_at",
-		).
+		Select(eventColumns...).
 		From(feedTable).
-		Where(squirrel.Eq{"dormitory_id": request.DormitoryId}).
-		Offset(countOffset(request.Page, constants.DefaultEventsPageSize)).
-		Limit(constants.DefaultEventsPageSize).
-		OrderBy("created_at DESC")
+		Where(squirrel.Eq{"dormitory_id": request.DormitoryId})
+
+	if !request.IncludeExpired {
+		queryBuilder = queryBuilder.Where(squirrel.Or{
+			squirrel.Eq{"expires_at": nil},
+			squirrel.Expr("expires_at > CURRENT_TIMESTAMP"),
+		})
+	}
+
+	var (
+		events []dbtypes.Event
+		err    error
+	)
 
+	if eventsWindowed(request) {
+		events, err = c.getEventsWindow(ctx, driver, request, queryBuilder)
+	} else {
+		events, err = c.selectEvents(ctx, driver, queryBuilder.
+			Offset(countOffset(request.Page, constants.DefaultEventsPageSize)).
+			Limit(constants.DefaultEventsPageSize).
+			// закрепленные посты идут первыми, последний закрепленный - выше
+			OrderBy("pinned_at DESC NULLS LAST", "created_at DESC"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.setEventsRsvp(ctx, driver, request.ViewerId, events); err != nil {
+		return nil, err
+	}
+
+	return &dbtypes.GetDormitoryEventsResponse{
+		Events: events,
+	}, nil
+}
+
+// selectEvents выполняет выборку событий по eventColumns
+func (c *Database) selectEvents(
+	ctx context.Context,
+	driver Driver,
+	queryBuilder squirrel.SelectBuilder,
+) ([]dbtypes.Event, error) {
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%w: error building get dormitory events query: %v", dberrors.ErrInternal, err)
@@ -66,24 +103,150 @@ func (c *Database) getDormitoryEvents(
 	defer rows.Close()
 
 	for rows.Next() {
-		var event dbtypes.Event
-
-		if err := rows.Scan(
-			&event.EventId,
-			&event.DormitoryId,
-			&event.Title,
-			&event.Description,
-			&event.CreatedAt,
-		); err != nil {
+		event, err := scanEvent(rows)
+		if err != nil {
 			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
 		}
 
-		events = append(events, event)
+		events = append(events, *event)
 	}
 
-	return &dbtypes.GetDormitoryEventsResponse{
-		Events: events,
-	}, nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return events, nil
+}
+
+// eventColumns - колонки feed в порядке, в котором их читает scanEvent
+var eventColumns = []string{
+	"id", "dormitory_id", "title", "description", "created_at",
+	"starts_at", "ends_at", "room_id", "location", "category", "capacity",
+	"recurrence_freq", "recurrence_interval", "recurrence_until", "recurrence_count",
+	"array_to_json(recurrence_exdates)::text",
+	"pinned_at", "expires_at", "urgent", "updated_at",
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanEvent читает событие, выбранное по eventColumns. Ошибка Scan возвращается как есть,
+// чтобы вызывающий мог отличить sql.ErrNoRows и ошибки ограничений
+func scanEvent(row rowScanner) (*dbtypes.Event, error) {
+	var (
+		event      dbtypes.Event
+		recurrence recurrenceRow
+		err        error
+	)
+
+	if err := row.Scan(
+		&event.EventId,
+		&event.DormitoryId,
+		&event.Title,
+		&event.Description,
+		&event.CreatedAt,
+		&event.StartsAt,
+		&event.EndsAt,
+		&event.RoomId,
+		&event.Location,
+		&event.Category,
+		&event.Capacity,
+		&recurrence.freq,
+		&recurrence.interval,
+		&recurrence.until,
+		&recurrence.count,
+		&recurrence.exdates,
+		&event.PinnedAt,
+		&event.ExpiresAt,
+		&event.Urgent,
+		&event.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if event.Recurrence, err = recurrence.toRecurrence(); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// setEventsRsvp дополняет события счетчиками ответов и ответом текущего пользователя
+func (c *Database) setEventsRsvp(
+	ctx context.Context,
+	driver Driver,
+	viewerId string,
+	events []dbtypes.Event,
+) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	eventIds := make([]string, 0, len(events))
+	for _, event := range events {
+		eventIds = append(eventIds, event.EventId)
+	}
+
+	counts, err := c.getEventsRsvpCounts(ctx, driver, eventIds)
+	if err != nil {
+		return err
+	}
+
+	var viewerRsvp map[string]dbtypes.RsvpStatus
+
+	if viewerId != "" {
+		viewerRsvp, err = c.getUserEventsRsvp(ctx, driver, viewerId, eventIds)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range events {
+		events[i].RsvpCounts = counts[events[i].EventId]
+		events[i].ViewerRsvp = viewerRsvp[events[i].EventId]
+	}
+
+	return nil
+}
+
+// eventsWindowed - запрошены события по времени проведения, а не вся лента
+func eventsWindowed(request *dbtypes.GetDormitoryEventsRequest) bool {
+	return request.Period != dbtypes.EventsPeriodAll || request.From != nil || request.To != nil
+}
+
+// eventsWindowCondition возвращает условия для разовых событий, которые идут в запрошенном
+// интервале, и для серий, которые могут дать в нем повторения. Точный отбор повторений -
+// в expandEventsSeries. Событие без ends_at считается закончившимся в момент начала,
+// посты без starts_at в выборку по времени не попадают
+func eventsWindowCondition(request *dbtypes.GetDormitoryEventsRequest) (single, series squirrel.And) {
+	const eventEnd = "COALESCE(ends_at, starts_at)"
+
+	single = squirrel.And{squirrel.Eq{"recurrence_freq": nil}}
+	series = squirrel.And{squirrel.NotEq{"recurrence_freq": nil}}
+
+	switch request.Period {
+	case dbtypes.EventsPeriodUpcoming:
+		single = append(single, squirrel.Expr(eventEnd+" >= CURRENT_TIMESTAMP"))
+	case dbtypes.EventsPeriodPast:
+		single = append(single, squirrel.Expr(eventEnd+" < CURRENT_TIMESTAMP"))
+		series = append(series, squirrel.Expr("starts_at < CURRENT_TIMESTAMP"))
+	}
+
+	if request.From != nil {
+		single = append(single, squirrel.Expr(eventEnd+" >= ?", *request.From))
+		series = append(series, squirrel.Or{
+			squirrel.Eq{"recurrence_until": nil},
+			squirrel.GtOrEq{"recurrence_until": *request.From},
+		})
+	}
+
+	if request.To != nil {
+		single = append(single, squirrel.Lt{"starts_at": *request.To})
+		series = append(series, squirrel.Lt{"starts_at": *request.To})
+	}
+
+	return single, series
 }
 
 func (c *Database) CreateDormitoryEvent(
@@ -94,7 +257,15 @@ func (c *Database) CreateDormitoryEvent(
 		return nil, dberrors.ErrBadRequest
 	}
 
-	resp, err := c.createDormitoryEvent(ctx, c.db, request)
+	var resp *dbtypes.CreateDormitoryEventResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.createDormitoryEvent(ctx, driver, request)
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -117,14 +288,53 @@ func (c *Database) createDormitoryEvent(
 		feedTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.FeedTableName)
 	)
 
+	if request.RoomId != nil {
+		if err := c.lockEventRoom(ctx, driver, request.DormitoryId, *request.RoomId); err != nil {
+			return nil, err
+		}
+	}
+
+	// разовое событие хранит пустое правило: recurrence_freq NULL и шаг по умолчанию
+	recurrence := dbtypes.Recurrence{Interval: 1}
+	if request.Recurrence != nil {
+		recurrence = *request.Recurrence
+	}
+
+	var freq *string
+	if len(recurrence.Freq) != 0 {
+		freq = &recurrence.Freq
+	}
+
+	var pinnedAt any
+	if request.Pinned {
+		pinnedAt = squirrel.Expr("CURRENT_TIMESTAMP")
+	}
+
 	queryBuilder := psql.Insert(feedTable).
 		Columns(
 			"dormitory_id", "title", "description",
+			"starts_at", "ends_at", "room_id", "location", "category", "capacity",
+			"recurrence_freq", "recurrence_interval", "recurrence_until", "recurrence_count", "recurrence_exdates",
+			"pinned_at", "expires_at", "urgent",
 		).
 		Values(
 			request.DormitoryId,
 			request.Title,
 			request.Description,
+			request.StartsAt,
+			request.EndsAt,
+			request.RoomId,
+			request.Location,
+			request.Category,
+			request.Capacity,
+			freq,
+			recurrence.Interval,
+			recurrence.Until,
+			recurrence.Count,
+			recurrenceExDates(request.Recurrence),
+			pinnedAt,
+			request.ExpiresAt,
+			request.Urgent,
 		).
 		Suffix("RETURNING id")
 
@@ -141,12 +351,212 @@ func (c *Database) createDormitoryEvent(
 	)
 
 	if err != nil {
+		if pgErrorCode(err) == dberrors.PGErrCheckViolation {
+			return nil, fmt.Errorf("%w: invalid event schedule, recurrence, category or capacity", dberrors.ErrBadRequest)
+		}
+
 		return nil, fmt.Errorf("%w: error scanning created event: %v", dberrors.ErrInternal, err)
 	}
 
 	return &resp, nil
 }
 
+func (c *Database) UpdateDormitoryEvent(
+	ctx context.Context,
+	request *dbtypes.UpdateDormitoryEventRequest,
+) (*dbtypes.UpdateDormitoryEventResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var resp *dbtypes.UpdateDormitoryEventResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.updateDormitoryEvent(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) updateDormitoryEvent(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.UpdateDormitoryEventRequest,
+) (*dbtypes.UpdateDormitoryEventResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		feedTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.FeedTableName)
+	)
+
+	// the event row lock serializes the capacity change with concurrent RSVPs
+	lockQuery, lockArgs, err := psql.Select(eventColumns...).
+		From(feedTable).
+		Where(squirrel.Eq{
+			"id":           request.EventId,
+			"dormitory_id": request.DormitoryId,
+		}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building lock event query: %v", dberrors.ErrInternal, err)
+	}
+
+	event, err := scanEvent(driver.QueryRowContext(ctx, lockQuery, lockArgs...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: event not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error locking event: %v", dberrors.ErrInternal, err)
+	}
+
+	// изменения и отмены повторений привязаны к исходным началам, сдвиг серии их потеряет
+	if event.Recurrence != nil && request.StartsAt != nil && !request.StartsAt.Equal(*event.StartsAt) {
+		return nil, fmt.Errorf("%w: starts_at of a recurring event can not be changed", dberrors.ErrBadRequest)
+	}
+
+	if request.RoomId != nil {
+		if err := c.lockEventRoom(ctx, driver, request.DormitoryId, *request.RoomId); err != nil {
+			return nil, err
+		}
+	}
+
+	// фото тоже часть поста, поэтому время изменения сдвигается и когда меняются только они
+	queryBuilder := psql.Update(feedTable).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{"id": request.EventId})
+
+	setupEventUpdateFields(&queryBuilder, request)
+
+	query, args, err := queryBuilder.
+		Suffix("RETURNING " + strings.Join(eventColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building update event query: %v", dberrors.ErrInternal, err)
+	}
+
+	event, err = scanEvent(driver.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if pgErrorCode(err) == dberrors.PGErrCheckViolation {
+			return nil, fmt.Errorf("%w: invalid event schedule, category or capacity", dberrors.ErrBadRequest)
+		}
+
+		return nil, fmt.Errorf("%w: error scanning updated event: %v", dberrors.ErrInternal, err)
+	}
+
+	resp := dbtypes.UpdateDormitoryEventResponse{}
+
+	if request.Capacity != nil || request.ClearCapacity {
+		// уменьшение вместимости не снимает уже записанных, новые ответы попадут в лист ожидания
+		resp.PromotedUserIds, err = c.promoteEventWaitlist(ctx, driver, request.EventId, event.Capacity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	events := []dbtypes.Event{*event}
+
+	if err := c.setEventsRsvp(ctx, driver, "", events); err != nil {
+		return nil, err
+	}
+
+	resp.Event = events[0]
+
+	return &resp, nil
+}
+
+func setupEventUpdateFields(
+	queryBuilder *squirrel.UpdateBuilder,
+	request *dbtypes.UpdateDormitoryEventRequest,
+) {
+	if request.Title != nil {
+		*queryBuilder = queryBuilder.Set("title", request.Title)
+	}
+
+	if request.Description != nil {
+		*queryBuilder = queryBuilder.Set("description", request.Description)
+	}
+
+	if request.StartsAt != nil {
+		*queryBuilder = queryBuilder.Set("starts_at", request.StartsAt)
+	}
+
+	if request.ClearEndsAt {
+		*queryBuilder = queryBuilder.Set("ends_at", nil)
+	} else if request.EndsAt != nil {
+		*queryBuilder = queryBuilder.Set("ends_at", request.EndsAt)
+	}
+
+	if request.ClearRoomId {
+		*queryBuilder = queryBuilder.Set("room_id", nil)
+	} else if request.RoomId != nil {
+		*queryBuilder = queryBuilder.Set("room_id", request.RoomId)
+	}
+
+	if request.Location != nil {
+		*queryBuilder = queryBuilder.Set("location", request.Location)
+	}
+
+	if request.Category != nil {
+		*queryBuilder = queryBuilder.Set("category", request.Category)
+	}
+
+	if request.ClearCapacity {
+		*queryBuilder = queryBuilder.Set("capacity", nil)
+	} else if request.Capacity != nil {
+		*queryBuilder = queryBuilder.Set("capacity", request.Capacity)
+	}
+}
+
+// lockEventRoom проверяет, что комната принадлежит общежитию события,
+// и не дает удалить ее до конца транзакции
+func (c *Database) lockEventRoom(
+	ctx context.Context,
+	driver Driver,
+	dormitoryId string,
+	roomId string,
+) error {
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		roomsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.RoomsTableName)
+	)
+
+	query, args, err := psql.Select("id").
+		From(roomsTable).
+		Where(squirrel.Eq{"id": roomId}).
+		Where(squirrel.Expr("floor_id IN (?)", dormitoryFloorIds(dormitoryId))).
+		Suffix("FOR SHARE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: error building get event room query: %v", dberrors.ErrInternal, err)
+	}
+
+	var id string
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: room not found", dberrors.ErrNotFound)
+		}
+
+		return fmt.Errorf("%w: error getting event room: %v", dberrors.ErrInternal, err)
+	}
+
+	return nil
+}
+
 func (c *Database) DeleteDormitoryEvent(
 	ctx context.Context,
 	request *dbtypes.DeleteDormitoryEventRequest,
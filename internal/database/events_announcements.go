@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+func (c *Database) UpdateEventAnnouncement(
+	ctx context.Context,
+	request *dbtypes.UpdateEventAnnouncementRequest,
+) (*dbtypes.UpdateEventAnnouncementResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var resp *dbtypes.UpdateEventAnnouncementResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.updateEventAnnouncement(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) updateEventAnnouncement(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.UpdateEventAnnouncementRequest,
+) (*dbtypes.UpdateEventAnnouncementResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		feedTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.FeedTableName)
+	)
+
+	// the lock makes the urgent flip detection below race free, so residents are notified once
+	lockQuery, lockArgs, err := psql.Select("urgent").
+		From(feedTable).
+		Where(squirrel.Eq{
+			"id":           request.EventId,
+			"dormitory_id": request.DormitoryId,
+		}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building lock event query: %v", dberrors.ErrInternal, err)
+	}
+
+	var wasUrgent bool
+
+	if err := driver.QueryRowContext(ctx, lockQuery, lockArgs...).Scan(&wasUrgent); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: event not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error locking event: %v", dberrors.ErrInternal, err)
+	}
+
+	queryBuilder := psql.Update(feedTable).
+		Where(squirrel.Eq{"id": request.EventId})
+
+	if !setupEventAnnouncementUpdateFields(&queryBuilder, request) {
+		return nil, fmt.Errorf("%w: nothing to update", dberrors.ErrBadRequest)
+	}
+
+	queryBuilder = queryBuilder.Suffix("RETURNING id, title, description, pinned_at, expires_at, urgent")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building update event announcement query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.UpdateEventAnnouncementResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(
+		&resp.EventId,
+		&resp.Title,
+		&resp.Description,
+		&resp.PinnedAt,
+		&resp.ExpiresAt,
+		&resp.Urgent,
+	); err != nil {
+		return nil, fmt.Errorf("%w: error scanning updated event announcement: %v", dberrors.ErrInternal, err)
+	}
+
+	resp.BecameUrgent = !wasUrgent && resp.Urgent
+
+	return &resp, nil
+}
+
+func setupEventAnnouncementUpdateFields(
+	queryBuilder *squirrel.UpdateBuilder,
+	request *dbtypes.UpdateEventAnnouncementRequest,
+) bool {
+	updated := false
+
+	if request.Pinned != nil {
+		if *request.Pinned {
+			// повторное закрепление не поднимает пост выше уже закрепленных позже
+			*queryBuilder = queryBuilder.Set("pinned_at", squirrel.Expr("COALESCE(pinned_at, CURRENT_TIMESTAMP)"))
+		} else {
+			*queryBuilder = queryBuilder.Set("pinned_at", nil)
+		}
+
+		updated = true
+	}
+
+	if request.ClearExpiresAt {
+		*queryBuilder = queryBuilder.Set("expires_at", nil)
+		updated = true
+	} else if request.ExpiresAt != nil {
+		*queryBuilder = queryBuilder.Set("expires_at", request.ExpiresAt)
+		updated = true
+	}
+
+	if request.Urgent != nil {
+		*queryBuilder = queryBuilder.Set("urgent", request.Urgent)
+		updated = true
+	}
+
+	return updated
+}
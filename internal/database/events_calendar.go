@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+// calendarUpcomingCondition - событие еще не закончилось или у серии будут повторения
+const calendarUpcomingCondition = "(COALESCE(f.ends_at, f.starts_at, f.created_at) >= CURRENT_TIMESTAMP" +
+	" OR (f.recurrence_freq IS NOT NULL AND COALESCE(f.recurrence_until, 'infinity') >= CURRENT_TIMESTAMP))"
+
+func (c *Database) GetEventsCalendar(
+	ctx context.Context,
+	request *dbtypes.GetEventsCalendarRequest,
+) (*dbtypes.GetEventsCalendarResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getEventsCalendar(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// getEventsCalendar возвращает события и посты общежития в хронологическом порядке.
+// Пост без расписания считается событием в день публикации, серии отдаются целиком
+// вместе с изменениями отдельных повторений
+func (c *Database) getEventsCalendar(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetEventsCalendarRequest,
+) (*dbtypes.GetEventsCalendarResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		feedTable  = fmt.Sprintf("%s.%s f", constants.SchemaName, constants.FeedTableName)
+		roomsTable = fmt.Sprintf("%s.%s r", constants.SchemaName, constants.RoomsTableName)
+	)
+
+	query, args, err := psql.
+		Select(
+			"f.id", "f.dormitory_id", "f.title", "f.description", "f.created_at",
+			"f.starts_at", "f.ends_at", "f.room_id", "f.location", "f.category", "f.capacity",
+			"r.number",
+			"f.recurrence_freq", "f.recurrence_interval", "f.recurrence_until", "f.recurrence_count",
+			"array_to_json(f.recurrence_exdates)::text",
+		).
+		From(feedTable).
+		LeftJoin(fmt.Sprintf("%s ON r.id = f.room_id", roomsTable)).
+		Where(squirrel.Eq{"f.dormitory_id": request.DormitoryId}).
+		Where(squirrel.Or{
+			squirrel.Expr("COALESCE(f.ends_at, f.starts_at, f.created_at) >= ?", request.Since),
+			squirrel.And{
+				squirrel.NotEq{"f.recurrence_freq": nil},
+				squirrel.Expr("COALESCE(f.recurrence_until, 'infinity') >= ?", request.Since),
+			},
+		}).
+		// при упоре в лимит отбрасываются самые давние прошедшие события,
+		// предстоящие и идущие серии подписчику календаря нужнее
+		OrderBy(
+			fmt.Sprintf("CASE WHEN %s THEN 0 ELSE 1 END", calendarUpcomingCondition),
+			fmt.Sprintf("CASE WHEN %s THEN COALESCE(f.starts_at, f.created_at) END ASC", calendarUpcomingCondition),
+			"COALESCE(f.ends_at, f.starts_at, f.created_at) DESC",
+			"f.id ASC",
+		).
+		Limit(request.Limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get events calendar query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get events calendar query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	resp := dbtypes.GetEventsCalendarResponse{
+		Events: make([]dbtypes.CalendarEvent, 0),
+	}
+
+	for rows.Next() {
+		var (
+			event      dbtypes.CalendarEvent
+			recurrence recurrenceRow
+		)
+
+		if err := rows.Scan(
+			&event.EventId,
+			&event.DormitoryId,
+			&event.Title,
+			&event.Description,
+			&event.CreatedAt,
+			&event.StartsAt,
+			&event.EndsAt,
+			&event.RoomId,
+			&event.Location,
+			&event.Category,
+			&event.Capacity,
+			&event.RoomNumber,
+			&recurrence.freq,
+			&recurrence.interval,
+			&recurrence.until,
+			&recurrence.count,
+			&recurrence.exdates,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		if event.Recurrence, err = recurrence.toRecurrence(); err != nil {
+			return nil, err
+		}
+
+		resp.Events = append(resp.Events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	// в календаре события идут по времени начала, независимо от того, как их отобрал лимит
+	sort.SliceStable(resp.Events, func(a, b int) bool {
+		startA, startB := calendarEventStart(&resp.Events[a]), calendarEventStart(&resp.Events[b])
+		if !startA.Equal(startB) {
+			return startA.Before(startB)
+		}
+
+		return resp.Events[a].EventId < resp.Events[b].EventId
+	})
+
+	var seriesIds []string
+
+	for _, event := range resp.Events {
+		if event.Recurrence != nil {
+			seriesIds = append(seriesIds, event.EventId)
+		}
+	}
+
+	overrides, err := c.getEventsOccurrences(ctx, driver, seriesIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, event := range resp.Events {
+		for _, occurrence := range overrides[event.EventId] {
+			resp.Events[i].Occurrences = append(resp.Events[i].Occurrences, *occurrence)
+		}
+
+		// map не хранит порядок, а календарь должен рендериться одинаково для ETag
+		sort.Slice(resp.Events[i].Occurrences, func(a, b int) bool {
+			return resp.Events[i].Occurrences[a].OccurrenceStart.Before(resp.Events[i].Occurrences[b].OccurrenceStart)
+		})
+	}
+
+	return &resp, nil
+}
+
+// calendarEventStart - начало события, пост без расписания начинается в момент публикации
+func calendarEventStart(event *dbtypes.CalendarEvent) time.Time {
+	if event.StartsAt != nil {
+		return *event.StartsAt
+	}
+
+	return event.CreatedAt
+}
@@ -0,0 +1,556 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	"github.com/lib/pq"
+)
+
+// recurrenceRow - колонки правила повторения в том виде, в каком их отдает Postgres
+type recurrenceRow struct {
+	freq     *string
+	interval int
+	until    *time.Time
+	count    *int
+	// exdates - массив timestamptz, выбранный через array_to_json
+	exdates string
+}
+
+func (r *recurrenceRow) toRecurrence() (*dbtypes.Recurrence, error) {
+	if r.freq == nil {
+		return nil, nil
+	}
+
+	recurrence := &dbtypes.Recurrence{
+		Freq:     *r.freq,
+		Interval: r.interval,
+		Until:    r.until,
+		Count:    r.count,
+	}
+
+	if err := json.Unmarshal([]byte(r.exdates), &recurrence.ExDates); err != nil {
+		return nil, fmt.Errorf("%w: error parsing recurrence exdates: %v", dberrors.ErrInternal, err)
+	}
+
+	return recurrence, nil
+}
+
+// recurrenceExDates готовит исключенные даты к записи в колонку timestamptz[]
+func recurrenceExDates(recurrence *dbtypes.Recurrence) any {
+	var exdates []string
+
+	if recurrence != nil {
+		for _, exdate := range recurrence.ExDates {
+			exdates = append(exdates, exdate.UTC().Format(time.RFC3339Nano))
+		}
+	}
+
+	return squirrel.Expr("?::timestamptz[]", textArray(exdates))
+}
+
+// recurrenceStarts возвращает исходные начала повторений серии раньше to.
+// Как и в RFC 5545, несуществующие даты (31 число в коротком месяце) пропускаются и
+// не считаются в Count, а исключенные даты считаются, но не возвращаются
+func recurrenceStarts(start time.Time, recurrence *dbtypes.Recurrence, to time.Time) []time.Time {
+	if recurrence == nil {
+		return []time.Time{start}
+	}
+
+	excluded := make(map[int64]struct{}, len(recurrence.ExDates))
+	for _, exdate := range recurrence.ExDates {
+		excluded[exdate.Unix()] = struct{}{}
+	}
+
+	interval := max(recurrence.Interval, 1)
+
+	var (
+		starts    []time.Time
+		generated int
+	)
+
+	for step := 0; step < constants.MaxRecurrenceSteps; step++ {
+		var occurrence time.Time
+
+		switch recurrence.Freq {
+		case dbtypes.RecurrenceFreqDaily:
+			occurrence = start.AddDate(0, 0, step*interval)
+		case dbtypes.RecurrenceFreqWeekly:
+			occurrence = start.AddDate(0, 0, 7*step*interval)
+		case dbtypes.RecurrenceFreqMonthly:
+			occurrence = start.AddDate(0, step*interval, 0)
+			if occurrence.Day() != start.Day() {
+				continue
+			}
+		default:
+			return []time.Time{start}
+		}
+
+		if !occurrence.Before(to) || (recurrence.Until != nil && occurrence.After(*recurrence.Until)) {
+			break
+		}
+
+		generated++
+		if recurrence.Count != nil && generated > *recurrence.Count {
+			break
+		}
+
+		if _, ok := excluded[occurrence.Unix()]; ok {
+			continue
+		}
+
+		starts = append(starts, occurrence)
+	}
+
+	return starts
+}
+
+// isRecurrenceStart проверяет, что серия действительно дает повторение в момент occurrenceStart
+func isRecurrenceStart(start time.Time, recurrence *dbtypes.Recurrence, occurrenceStart time.Time) bool {
+	starts := recurrenceStarts(start, recurrence, occurrenceStart.Add(time.Nanosecond))
+
+	return len(starts) != 0 && starts[len(starts)-1].Equal(occurrenceStart)
+}
+
+// applyEventOccurrence превращает серию в одно ее повторение с учетом изменений
+func applyEventOccurrence(series dbtypes.Event, occurrenceStart time.Time, override *dbtypes.EventOccurrence) dbtypes.Event {
+	event := series
+
+	startsAt := occurrenceStart
+	event.StartsAt = &startsAt
+	event.OccurrenceStart = &startsAt
+
+	if series.StartsAt != nil && series.EndsAt != nil {
+		endsAt := occurrenceStart.Add(series.EndsAt.Sub(*series.StartsAt))
+		event.EndsAt = &endsAt
+	}
+
+	if override == nil {
+		return event
+	}
+
+	event.Cancelled = override.Cancelled
+
+	if override.Title != nil {
+		event.Title = *override.Title
+	}
+
+	if override.Description != nil {
+		event.Description = *override.Description
+	}
+
+	if override.Location != nil {
+		event.Location = *override.Location
+	}
+
+	if override.StartsAt != nil {
+		event.StartsAt = override.StartsAt
+	}
+
+	if override.EndsAt != nil {
+		event.EndsAt = override.EndsAt
+	}
+
+	return event
+}
+
+// getEventsWindow возвращает страницу событий, которые идут в запрошенном интервале.
+// Разовые события сортируются и ограничиваются в SQL, серии разворачиваются
+// в повторения и вливаются в эту выборку в памяти
+func (c *Database) getEventsWindow(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetDormitoryEventsRequest,
+	queryBuilder squirrel.SelectBuilder,
+) ([]dbtypes.Event, error) {
+	var (
+		single, series = eventsWindowCondition(request)
+
+		offset      = countOffset(request.Page, constants.DefaultEventsPageSize)
+		startsOrder = "starts_at ASC"
+	)
+
+	if request.Period == dbtypes.EventsPeriodPast {
+		startsOrder = "starts_at DESC"
+	}
+
+	// страница общей выборки целиком лежит среди первых offset+размер страницы
+	// разовых событий и повторений, дальше читать не нужно
+	events, err := c.selectEvents(ctx, driver, queryBuilder.
+		Where(single).
+		OrderBy(startsOrder, "created_at DESC", "id ASC").
+		Limit(offset+constants.DefaultEventsPageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	seriesEvents, err := c.selectEvents(ctx, driver, queryBuilder.
+		Where(series).
+		OrderBy("starts_at ASC", "id ASC").
+		Limit(constants.MaxWindowSeries))
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := c.expandEventsSeries(ctx, driver, request, seriesEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	events = append(events, occurrences...)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i].StartsAt, events[j].StartsAt
+		if a.Equal(*b) {
+			return events[i].CreatedAt.After(events[j].CreatedAt)
+		}
+
+		if request.Period == dbtypes.EventsPeriodPast {
+			return a.After(*b)
+		}
+
+		return a.Before(*b)
+	})
+
+	if offset >= uint64(len(events)) {
+		return nil, nil
+	}
+
+	end := min(offset+constants.DefaultEventsPageSize, uint64(len(events)))
+
+	return events[offset:end], nil
+}
+
+// expandEventsSeries разворачивает серии в повторения и оставляет попавшие в интервал
+func (c *Database) expandEventsSeries(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetDormitoryEventsRequest,
+	series []dbtypes.Event,
+) ([]dbtypes.Event, error) {
+	var (
+		now       = time.Now()
+		expandTo  = eventsExpandTo(request, now)
+		seriesIds = make([]string, 0, len(series))
+	)
+
+	for _, event := range series {
+		seriesIds = append(seriesIds, event.EventId)
+	}
+
+	overrides, err := c.getEventsOccurrences(ctx, driver, seriesIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var expanded []dbtypes.Event
+
+	for _, event := range series {
+		if event.Recurrence == nil || event.StartsAt == nil {
+			continue
+		}
+
+		for _, occurrenceStart := range recurrenceStarts(*event.StartsAt, event.Recurrence, expandTo) {
+			occurrence := applyEventOccurrence(event, occurrenceStart, overrides[event.EventId][occurrenceStart.Unix()])
+
+			if eventInWindow(request, now, &occurrence) {
+				expanded = append(expanded, occurrence)
+			}
+		}
+	}
+
+	return expanded, nil
+}
+
+// eventsExpandTo - до какого момента разворачивать серии: конец интервала,
+// текущий момент для прошедших или горизонт от начала интервала
+func eventsExpandTo(request *dbtypes.GetDormitoryEventsRequest, now time.Time) time.Time {
+	switch {
+	case request.To != nil:
+		return *request.To
+	case request.Period == dbtypes.EventsPeriodPast:
+		return now
+	case request.From != nil && request.From.After(now):
+		return request.From.Add(constants.EventsRecurrenceHorizon)
+	default:
+		return now.Add(constants.EventsRecurrenceHorizon)
+	}
+}
+
+// eventInWindow повторяет для развернутых повторений условия eventsWindowCondition
+func eventInWindow(request *dbtypes.GetDormitoryEventsRequest, now time.Time, event *dbtypes.Event) bool {
+	end := *event.StartsAt
+	if event.EndsAt != nil {
+		end = *event.EndsAt
+	}
+
+	switch request.Period {
+	case dbtypes.EventsPeriodUpcoming:
+		if end.Before(now) {
+			return false
+		}
+	case dbtypes.EventsPeriodPast:
+		if !end.Before(now) {
+			return false
+		}
+	}
+
+	if request.From != nil && end.Before(*request.From) {
+		return false
+	}
+
+	if request.To != nil && !event.StartsAt.Before(*request.To) {
+		return false
+	}
+
+	return true
+}
+
+// getEventsOccurrences возвращает изменения повторений серий: id серии -> начало повторения (unix) -> изменение
+func (c *Database) getEventsOccurrences(
+	ctx context.Context,
+	driver Driver,
+	eventIds []string,
+) (map[string]map[int64]*dbtypes.EventOccurrence, error) {
+	occurrences := make(map[string]map[int64]*dbtypes.EventOccurrence)
+
+	if len(eventIds) == 0 {
+		return occurrences, nil
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		eventOccurrencesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.EventOccurrencesTableName)
+	)
+
+	query, args, err := psql.
+		Select(
+			"event_id", "occurrence_start", "cancelled", "title", "description",
+			"starts_at", "ends_at", "location", "updated_at",
+		).
+		From(eventOccurrencesTable).
+		Where("event_id = ANY(?::uuid[])", pq.Array(eventIds)).
+		OrderBy("occurrence_start ASC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get event occurrences query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get event occurrences query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var occurrence dbtypes.EventOccurrence
+
+		if err := rows.Scan(
+			&occurrence.EventId,
+			&occurrence.OccurrenceStart,
+			&occurrence.Cancelled,
+			&occurrence.Title,
+			&occurrence.Description,
+			&occurrence.StartsAt,
+			&occurrence.EndsAt,
+			&occurrence.Location,
+			&occurrence.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		if occurrences[occurrence.EventId] == nil {
+			occurrences[occurrence.EventId] = make(map[int64]*dbtypes.EventOccurrence)
+		}
+
+		occurrences[occurrence.EventId][occurrence.OccurrenceStart.Unix()] = &occurrence
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return occurrences, nil
+}
+
+func (c *Database) UpdateEventOccurrence(
+	ctx context.Context,
+	request *dbtypes.UpdateEventOccurrenceRequest,
+) (*dbtypes.UpdateEventOccurrenceResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var resp *dbtypes.UpdateEventOccurrenceResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.updateEventOccurrence(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// updateEventOccurrence меняет или отменяет одно повторение серии, не трогая остальные.
+// Переданные поля накладываются на прошлые изменения этого повторения
+func (c *Database) updateEventOccurrence(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.UpdateEventOccurrenceRequest,
+) (*dbtypes.UpdateEventOccurrenceResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		feedTable             = fmt.Sprintf("%s.%s", constants.SchemaName, constants.FeedTableName)
+		eventOccurrencesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.EventOccurrencesTableName)
+	)
+
+	seriesQuery, seriesArgs, err := psql.
+		Select(
+			"starts_at", "ends_at",
+			"recurrence_freq", "recurrence_interval", "recurrence_until", "recurrence_count",
+			"array_to_json(recurrence_exdates)::text",
+		).
+		From(feedTable).
+		Where(squirrel.Eq{
+			"id":           request.EventId,
+			"dormitory_id": request.DormitoryId,
+		}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get event series query: %v", dberrors.ErrInternal, err)
+	}
+
+	var (
+		series     dbtypes.Event
+		recurrence recurrenceRow
+	)
+
+	if err := driver.QueryRowContext(ctx, seriesQuery, seriesArgs...).Scan(
+		&series.StartsAt,
+		&series.EndsAt,
+		&recurrence.freq,
+		&recurrence.interval,
+		&recurrence.until,
+		&recurrence.count,
+		&recurrence.exdates,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: event not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error getting event series: %v", dberrors.ErrInternal, err)
+	}
+
+	if series.Recurrence, err = recurrence.toRecurrence(); err != nil {
+		return nil, err
+	}
+
+	if series.Recurrence == nil || series.StartsAt == nil {
+		return nil, fmt.Errorf("%w: event is not recurring", dberrors.ErrBadRequest)
+	}
+
+	if !isRecurrenceStart(*series.StartsAt, series.Recurrence, request.OccurrenceStart) {
+		return nil, fmt.Errorf("%w: occurrence not found", dberrors.ErrNotFound)
+	}
+
+	overrides, err := c.getEventsOccurrences(ctx, driver, []string{request.EventId})
+	if err != nil {
+		return nil, err
+	}
+
+	occurrence := dbtypes.EventOccurrence{
+		EventId:         request.EventId,
+		OccurrenceStart: request.OccurrenceStart,
+	}
+
+	if current := overrides[request.EventId][request.OccurrenceStart.Unix()]; current != nil {
+		occurrence = *current
+	}
+
+	if request.Title != nil {
+		occurrence.Title = request.Title
+	}
+
+	if request.Description != nil {
+		occurrence.Description = request.Description
+	}
+
+	if request.Location != nil {
+		occurrence.Location = request.Location
+	}
+
+	if request.StartsAt != nil {
+		occurrence.StartsAt = request.StartsAt
+	}
+
+	if request.EndsAt != nil {
+		occurrence.EndsAt = request.EndsAt
+	}
+
+	if request.Cancelled != nil {
+		occurrence.Cancelled = *request.Cancelled
+	}
+
+	effective := applyEventOccurrence(series, request.OccurrenceStart, &occurrence)
+	if effective.EndsAt != nil && effective.EndsAt.Before(*effective.StartsAt) {
+		return nil, fmt.Errorf("%w: occurrence ends before it starts", dberrors.ErrBadRequest)
+	}
+
+	query, args, err := psql.Insert(eventOccurrencesTable).
+		Columns(
+			"event_id", "occurrence_start", "cancelled", "title", "description",
+			"starts_at", "ends_at", "location",
+		).
+		Values(
+			occurrence.EventId,
+			occurrence.OccurrenceStart,
+			occurrence.Cancelled,
+			occurrence.Title,
+			occurrence.Description,
+			occurrence.StartsAt,
+			occurrence.EndsAt,
+			occurrence.Location,
+		).
+		Suffix(`ON CONFLICT (event_id, occurrence_start) DO UPDATE SET
+			cancelled = EXCLUDED.cancelled,
+			title = EXCLUDED.title,
+			description = EXCLUDED.description,
+			starts_at = EXCLUDED.starts_at,
+			ends_at = EXCLUDED.ends_at,
+			location = EXCLUDED.location,
+			updated_at = CURRENT_TIMESTAMP
+			RETURNING updated_at`).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building update event occurrence query: %v", dberrors.ErrInternal, err)
+	}
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&occurrence.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("%w: error updating event occurrence: %v", dberrors.ErrInternal, err)
+	}
+
+	return &dbtypes.UpdateEventOccurrenceResponse{
+		Occurrence: occurrence,
+	}, nil
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+func (c *Database) GetEventsSyndication(
+	ctx context.Context,
+	request *dbtypes.GetEventsSyndicationRequest,
+) (*dbtypes.GetEventsSyndicationResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getEventsSyndication(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// getEventsSyndication возвращает последние опубликованные посты общежития.
+// Закрепление на порядок не влияет: агрегаторы ждут ленту по времени публикации
+func (c *Database) getEventsSyndication(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetEventsSyndicationRequest,
+) (*dbtypes.GetEventsSyndicationResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		feedTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.FeedTableName)
+	)
+
+	query, args, err := psql.
+		Select(eventColumns...).
+		From(feedTable).
+		Where(squirrel.Eq{"dormitory_id": request.DormitoryId}).
+		Where(squirrel.Or{
+			squirrel.Eq{"expires_at": nil},
+			squirrel.Expr("expires_at > CURRENT_TIMESTAMP"),
+		}).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(request.Limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get events syndication query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get events syndication query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	resp := dbtypes.GetEventsSyndicationResponse{
+		Events: make([]dbtypes.Event, 0),
+	}
+
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		resp.Events = append(resp.Events, *event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+func (c *Database) GetGradeCriteria(
+	ctx context.Context,
+	request *dbtypes.GetGradeCriteriaRequest,
+) (*dbtypes.GetGradeCriteriaResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getGradeCriteria(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) getGradeCriteria(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetGradeCriteriaRequest,
+) (*dbtypes.GetGradeCriteriaResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		criteriaTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradeCriteriaTableName)
+	)
+
+	queryBuilder := psql.
+		Select("code", "labels", "weight", "position", "active", "created_at").
+		From(criteriaTable).
+		OrderBy("position ASC", "code ASC")
+
+	if request.ActiveOnly {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"active": true})
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get grade criteria query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get grade criteria query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	var resp dbtypes.GetGradeCriteriaResponse
+	for rows.Next() {
+		var (
+			criterion dbtypes.GradeCriterion
+			labels    []byte
+		)
+
+		if err := rows.Scan(
+			&criterion.Code,
+			&labels,
+			&criterion.Weight,
+			&criterion.Position,
+			&criterion.Active,
+			&criterion.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		if err := json.Unmarshal(labels, &criterion.Labels); err != nil {
+			return nil, fmt.Errorf("%w: error unmarshalling criterion labels: %v", dberrors.ErrInternal, err)
+		}
+
+		resp.Criteria = append(resp.Criteria, criterion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) CreateGradeCriterion(
+	ctx context.Context,
+	request *dbtypes.CreateGradeCriterionRequest,
+) (*dbtypes.CreateGradeCriterionResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.createGradeCriterion(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) createGradeCriterion(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.CreateGradeCriterionRequest,
+) (*dbtypes.CreateGradeCriterionResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	labels, err := json.Marshal(request.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error marshalling criterion labels: %v", dberrors.ErrBadRequest, err)
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		criteriaTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradeCriteriaTableName)
+	)
+
+	queryBuilder := psql.Insert(criteriaTable).
+		Columns("code", "labels", "weight", "position").
+		Values(request.Code, string(labels), request.Weight, request.Position).
+		Suffix("RETURNING code")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building create grade criterion query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.CreateGradeCriterionResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.Code); err != nil {
+		if pgErrorCode(err) == dberrors.PGErrUniqueViolation {
+			return nil, fmt.Errorf("%w: criterion %s already exists", dberrors.ErrConflict, request.Code)
+		}
+
+		return nil, fmt.Errorf("%w: error scanning created grade criterion: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) UpdateGradeCriterion(
+	ctx context.Context,
+	request *dbtypes.UpdateGradeCriterionRequest,
+) (*dbtypes.UpdateGradeCriterionResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.updateGradeCriterion(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// updateGradeCriterion обновляет переданные поля критерия, переводы меток дописываются к существующим
+func (c *Database) updateGradeCriterion(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.UpdateGradeCriterionRequest,
+) (*dbtypes.UpdateGradeCriterionResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	if len(request.Labels) == 0 && request.Weight == nil && request.Position == nil && request.Active == nil {
+		return nil, fmt.Errorf("%w: nothing to update", dberrors.ErrBadRequest)
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		criteriaTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradeCriteriaTableName)
+	)
+
+	queryBuilder := psql.Update(criteriaTable).Where(squirrel.Eq{"code": request.Code})
+
+	if len(request.Labels) > 0 {
+		labels, err := json.Marshal(request.Labels)
+		if err != nil {
+			return nil, fmt.Errorf("%w: error marshalling criterion labels: %v", dberrors.ErrBadRequest, err)
+		}
+
+		queryBuilder = queryBuilder.Set("labels", squirrel.Expr("labels || ?::jsonb", string(labels)))
+	}
+
+	if request.Weight != nil {
+		queryBuilder = queryBuilder.Set("weight", *request.Weight)
+	}
+
+	if request.Position != nil {
+		queryBuilder = queryBuilder.Set("position", *request.Position)
+	}
+
+	if request.Active != nil {
+		queryBuilder = queryBuilder.Set("active", *request.Active)
+	}
+
+	query, args, err := queryBuilder.Suffix("RETURNING code").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building update grade criterion query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.UpdateGradeCriterionResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.Code); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: criterion not found", dberrors.ErrNotFound)
+		}
+
+		if pgErrorCode(err) == dberrors.PGErrCheckViolation {
+			return nil, fmt.Errorf("%w: weight must be positive", dberrors.ErrBadRequest)
+		}
+
+		return nil, fmt.Errorf("%w: error scanning updated grade criterion: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
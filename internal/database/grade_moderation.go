@@ -0,0 +1,315 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	"github.com/lib/pq"
+)
+
+func (c *Database) GetGradeSignals(
+	ctx context.Context,
+	request *dbtypes.GetGradeSignalsRequest,
+) (*dbtypes.GetGradeSignalsResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getGradeSignals(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// getGradeSignals собирает данные для поиска накруток: возраст аккаунта
+// и число оценок общежития за последнее время и за базовый период до него
+func (c *Database) getGradeSignals(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetGradeSignalsRequest,
+) (*dbtypes.GetGradeSignalsResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		userTable            = fmt.Sprintf("%s.%s", constants.SchemaName, constants.UsersTableName)
+		dormitoryGradesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradesTable)
+	)
+
+	recentGrades := squirrel.
+		Select("COUNT(*)").
+		From(dormitoryGradesTable).
+		Where(squirrel.Eq{"dormitory_id": request.DormitoryId}).
+		Where(squirrel.GtOrEq{"updated_at": request.ActivitySince})
+
+	baselineGrades := squirrel.
+		Select("COUNT(*)").
+		From(dormitoryGradesTable).
+		Where(squirrel.Eq{"dormitory_id": request.DormitoryId}).
+		Where(squirrel.GtOrEq{"updated_at": request.BaselineSince}).
+		Where(squirrel.Lt{"updated_at": request.ActivitySince})
+
+	queryBuilder := psql.
+		Select("u.created_at").
+		Column(squirrel.Alias(recentGrades, "recent_grades")).
+		Column(squirrel.Alias(baselineGrades, "baseline_grades")).
+		From(userTable + " u").
+		Where(squirrel.Eq{"u.id": request.UserId})
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get grade signals query: %v", dberrors.ErrInternal, err)
+	}
+
+	var (
+		resp      dbtypes.GetGradeSignalsResponse
+		createdAt sql.NullTime
+	)
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(
+		&createdAt,
+		&resp.RecentGrades,
+		&resp.BaselineGrades,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: user not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error executing get grade signals query: %v", dberrors.ErrInternal, err)
+	}
+
+	resp.AccountCreatedAt = createdAt.Time
+
+	return &resp, nil
+}
+
+func (c *Database) GetModerationGrades(
+	ctx context.Context,
+	request *dbtypes.GetModerationGradesRequest,
+) (*dbtypes.GetModerationGradesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getModerationGrades(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) getModerationGrades(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetModerationGradesRequest,
+) (*dbtypes.GetModerationGradesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		dormitoryGradesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradesTable)
+		gradeScoresTable     = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradeScoresTableName)
+
+		condition = squirrel.Eq{
+			"dormitory_id": request.DormitoryId,
+			"status":       request.Status,
+		}
+	)
+
+	countQuery, countArgs, err := psql.
+		Select("COUNT(*)").
+		From(dormitoryGradesTable).
+		Where(condition).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building count moderation grades query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.GetModerationGradesResponse
+
+	if err := driver.QueryRowContext(ctx, countQuery, countArgs...).Scan(&resp.Total); err != nil {
+		return nil, fmt.Errorf("%w: error counting moderation grades: %v", dberrors.ErrInternal, err)
+	}
+
+	query, args, err := psql.
+		Select("id", "dormitory_id", "user_id", "status", "flag_reasons", "created_at", "updated_at").
+		From(dormitoryGradesTable).
+		Where(condition).
+		OrderBy("created_at DESC").
+		Offset(countOffset(request.Page, constants.DefaultPaginationPageSize)).
+		Limit(constants.DefaultPaginationPageSize).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get moderation grades query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get moderation grades query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	var (
+		gradeIds []string
+		gradeIdx = make(map[string]int)
+	)
+
+	for rows.Next() {
+		var (
+			grade       dbtypes.Grade
+			flagReasons pq.StringArray
+		)
+
+		if err := rows.Scan(
+			&grade.Id,
+			&grade.DormitoryId,
+			&grade.UserId,
+			&grade.Status,
+			&flagReasons,
+			&grade.CreatedAt,
+			&grade.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		grade.FlagReasons = flagReasons
+		grade.Scores = make(map[string]int)
+
+		gradeIdx[grade.Id] = len(resp.Grades)
+		gradeIds = append(gradeIds, grade.Id)
+		resp.Grades = append(resp.Grades, grade)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	if len(gradeIds) == 0 {
+		return &resp, nil
+	}
+
+	scoresQuery, scoresArgs, err := psql.
+		Select("grade_id", "criterion_code", "score").
+		From(gradeScoresTable).
+		Where("grade_id = ANY(?::uuid[])", pq.Array(gradeIds)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get grade scores query: %v", dberrors.ErrInternal, err)
+	}
+
+	scoreRows, err := driver.QueryContext(ctx, scoresQuery, scoresArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get grade scores query: %v", dberrors.ErrInternal, err)
+	}
+	defer scoreRows.Close()
+
+	for scoreRows.Next() {
+		var (
+			gradeId, code string
+			score         int
+		)
+
+		if err := scoreRows.Scan(&gradeId, &code, &score); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		if idx, ok := gradeIdx[gradeId]; ok {
+			resp.Grades[idx].Scores[code] = score
+		}
+	}
+
+	if err := scoreRows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) ModerateGrade(
+	ctx context.Context,
+	request *dbtypes.ModerateGradeRequest,
+) (*dbtypes.ModerateGradeResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var resp *dbtypes.ModerateGradeResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.moderateGrade(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// moderateGrade сохраняет решение администратора и пересчитывает средние периода оценки
+func (c *Database) moderateGrade(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.ModerateGradeRequest,
+) (*dbtypes.ModerateGradeResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql                 = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+		dormitoryGradesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradesTable)
+	)
+
+	queryBuilder := psql.Update(dormitoryGradesTable).
+		Set("status", request.Status).
+		Set("reviewed_by", request.ReviewerId).
+		Set("reviewed_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{
+			"id":           request.GradeId,
+			"dormitory_id": request.DormitoryId,
+		}).
+		Suffix("RETURNING id, status, created_at")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building moderate grade query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.ModerateGradeResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.GradeId, &resp.Status, &resp.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: grade not found", dberrors.ErrNotFound)
+		}
+
+		if pgErrorCode(err) == dberrors.PGErrCheckViolation {
+			return nil, fmt.Errorf("%w: invalid grade status %s", dberrors.ErrBadRequest, request.Status)
+		}
+
+		return nil, fmt.Errorf("%w: error moderating grade: %v", dberrors.ErrInternal, err)
+	}
+
+	if err := c.recomputeDormitoryAverages(ctx, driver, request.DormitoryId, resp.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -2,15 +2,31 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
-	"strings"
+	"math"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/dormitory-life/core/internal/constants"
 	dberrors "github.com/dormitory-life/core/internal/database/errors"
 	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	"github.com/lib/pq"
 )
 
+// currentMonthGradeCondition совпадает с выражением уникального индекса idx_grades_unique_per_month
+const currentMonthGradeCondition = "DATE_TRUNC('month', created_at) = DATE_TRUNC('month', CURRENT_TIMESTAMP)"
+
+var avgGradeColumns = []string{
+	"id",
+	"dormitory_id",
+	"period_date",
+	"overall_average",
+	"total_ratings",
+	"created_at",
+	"updated_at",
+}
+
 func (c *Database) GetDormitoriesAvgGrades(
 	ctx context.Context,
 	request *dbtypes.GetDormitoriesAvgGradesRequest,
@@ -42,7 +58,7 @@ func (c *Database) getDormitoriesAvgGrades(
 		dormitoryAvgGradesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryAvgGradesTableName)
 	)
 
-	queryBuilder := psql.Select("*").
+	queryBuilder := psql.Select(avgGradeColumns...).
 		From(dormitoryAvgGradesTable).
 		Where("period_date = (SELECT MAX(period_date) FROM dormitory_average_grades dag2 WHERE dag2.dormitory_id = dormitory_average_grades.dormitory_id)")
 
@@ -51,42 +67,9 @@ func (c *Database) getDormitoriesAvgGrades(
 		return nil, fmt.Errorf("%w: error building get dormitories avg grades query: %v", dberrors.ErrInternal, err)
 	}
 
-	rows, err := driver.QueryContext(ctx, query, args...)
+	averageGrades, err := c.queryAvgGrades(ctx, driver, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: error executing get dormitories avg grades query: %v", dberrors.ErrInternal, err)
-	}
-	defer rows.Close()
-
-	var averageGrades []dbtypes.AvgGrade
-	for rows.Next() {
-		var avgGrade dbtypes.AvgGrade
-		if err := rows.Scan(
-			&avgGrade.Id,
-			&avgGrade.DormitoryId,
-			&avgGrade.PeriodDate,
-			&avgGrade.AvgBathroomCleanliness,
-			&avgGrade.AvgCorridorCleanliness,
-			&avgGrade.AvgKitchenCleanliness,
-			&avgGrade.AvgCleaningFrequency,
-			&avgGrade.AvgRoomSpaciousness,
-			&avgGrade.AvgCorridorSpaciousness,
-			&avgGrade.AvgKitchenSpaciousness,
-			&avgGrade.AvgShowerLocationConvenience,
-			&avgGrade.AvgEquipmentMaintenance,
-			&avgGrade.AvgWindowCondition,
-			&avgGrade.AvgNoiseIsolation,
-			&avgGrade.AvgCommonAreasEquipment,
-			&avgGrade.AvgTransportAccessibility,
-			&avgGrade.AvgAdministrationQuality,
-			&avgGrade.AvgResidentsCultureLevel,
-			&avgGrade.OverallAverage,
-			&avgGrade.TotalRatings,
-			&avgGrade.CreatedAt,
-			&avgGrade.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
-		}
-		averageGrades = append(averageGrades, avgGrade)
+		return nil, err
 	}
 
 	return &dbtypes.GetDormitoriesAvgGradesResponse{
@@ -125,19 +108,45 @@ func (c *Database) getDormitoryAvgGrades(
 		dormitoryAvgGradesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryAvgGradesTableName)
 	)
 
-	queryBuilder := psql.Select("*").
+	queryBuilder := psql.Select(avgGradeColumns...).
 		From(dormitoryAvgGradesTable).
 		Where(squirrel.Eq{"dormitory_id": request.DormitoryId}).
 		OrderBy("period_date DESC")
 
+	if request.From != nil {
+		queryBuilder = queryBuilder.Where(squirrel.Expr("period_date >= ?::date", request.From.Format(time.DateOnly)))
+	}
+
+	if request.To != nil {
+		queryBuilder = queryBuilder.Where(squirrel.Expr("period_date <= ?::date", request.To.Format(time.DateOnly)))
+	}
+
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%w: error building get dormitories avg grades query: %v", dberrors.ErrInternal, err)
 	}
 
+	averageGrades, err := c.queryAvgGrades(ctx, driver, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbtypes.GetDormitoryAvgGradesResponse{
+		Grades: averageGrades,
+	}, nil
+}
+
+// queryAvgGrades читает строки периодов из dormitory_average_grades
+// и дополняет их средними по критериям
+func (c *Database) queryAvgGrades(
+	ctx context.Context,
+	driver Driver,
+	query string,
+	args ...any,
+) ([]dbtypes.AvgGrade, error) {
 	rows, err := driver.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: error executing get dormitories avg grades query: %v", dberrors.ErrInternal, err)
+		return nil, fmt.Errorf("%w: error executing get avg grades query: %v", dberrors.ErrInternal, err)
 	}
 	defer rows.Close()
 
@@ -148,21 +157,6 @@ func (c *Database) getDormitoryAvgGrades(
 			&avgGrade.Id,
 			&avgGrade.DormitoryId,
 			&avgGrade.PeriodDate,
-			&avgGrade.AvgBathroomCleanliness,
-			&avgGrade.AvgCorridorCleanliness,
-			&avgGrade.AvgKitchenCleanliness,
-			&avgGrade.AvgCleaningFrequency,
-			&avgGrade.AvgRoomSpaciousness,
-			&avgGrade.AvgCorridorSpaciousness,
-			&avgGrade.AvgKitchenSpaciousness,
-			&avgGrade.AvgShowerLocationConvenience,
-			&avgGrade.AvgEquipmentMaintenance,
-			&avgGrade.AvgWindowCondition,
-			&avgGrade.AvgNoiseIsolation,
-			&avgGrade.AvgCommonAreasEquipment,
-			&avgGrade.AvgTransportAccessibility,
-			&avgGrade.AvgAdministrationQuality,
-			&avgGrade.AvgResidentsCultureLevel,
 			&avgGrade.OverallAverage,
 			&avgGrade.TotalRatings,
 			&avgGrade.CreatedAt,
@@ -173,9 +167,91 @@ func (c *Database) getDormitoryAvgGrades(
 		averageGrades = append(averageGrades, avgGrade)
 	}
 
-	return &dbtypes.GetDormitoryAvgGradesResponse{
-		Grades: averageGrades,
-	}, nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	if err := c.fillCriterionAverages(ctx, driver, averageGrades); err != nil {
+		return nil, err
+	}
+
+	return averageGrades, nil
+}
+
+func (c *Database) fillCriterionAverages(
+	ctx context.Context,
+	driver Driver,
+	averageGrades []dbtypes.AvgGrade,
+) error {
+	if len(averageGrades) == 0 {
+		return nil
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		avgScoresTable = fmt.Sprintf("%s.%s s", constants.SchemaName, constants.DormitoryAvgScoresTableName)
+		criteriaTable  = fmt.Sprintf("%s.%s c", constants.SchemaName, constants.GradeCriteriaTableName)
+
+		dormitoryIds = make([]string, 0, len(averageGrades))
+		periods      = make([]string, 0, len(averageGrades))
+		gradeIdx     = make(map[string]int, len(averageGrades))
+	)
+
+	for i, grade := range averageGrades {
+		period := grade.PeriodDate.Format(time.DateOnly)
+
+		dormitoryIds = append(dormitoryIds, grade.DormitoryId)
+		periods = append(periods, period)
+		gradeIdx[grade.DormitoryId+"/"+period] = i
+	}
+
+	queryBuilder := psql.
+		Select("s.dormitory_id", "s.period_date", "s.criterion_code", "s.avg_score", "s.total_ratings").
+		From(avgScoresTable).
+		Join(fmt.Sprintf("%s ON c.code = s.criterion_code", criteriaTable)).
+		Where("s.dormitory_id = ANY(?)", pq.Array(dormitoryIds)).
+		Where("s.period_date = ANY(?::date[])", pq.Array(periods)).
+		OrderBy("c.position ASC", "c.code ASC")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: error building get criterion averages query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: error executing get criterion averages query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			dormitoryId string
+			periodDate  time.Time
+			average     dbtypes.CriterionAverage
+		)
+
+		if err := rows.Scan(
+			&dormitoryId,
+			&periodDate,
+			&average.Code,
+			&average.Average,
+			&average.TotalRatings,
+		); err != nil {
+			return fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		// the ANY filters are independent, so foreign pairs are skipped here
+		idx, ok := gradeIdx[dormitoryId+"/"+periodDate.Format(time.DateOnly)]
+		if !ok {
+			continue
+		}
+
+		averageGrades[idx].Criteria = append(averageGrades[idx].Criteria, average)
+	}
+
+	return rows.Err()
 }
 
 func (c *Database) CreateDormitoryGrade(
@@ -186,7 +262,15 @@ func (c *Database) CreateDormitoryGrade(
 		return nil, fmt.Errorf("%w: request is nil", dberrors.ErrBadRequest)
 	}
 
-	resp, err := c.createDormitoryGrade(ctx, c.db, request)
+	var resp *dbtypes.CreateDormitoryGradeResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.createDormitoryGrade(ctx, driver, request)
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -212,42 +296,16 @@ func (c *Database) createDormitoryGrade(
 		Columns(
 			"dormitory_id",
 			"user_id",
-			"bathroom_cleanliness",
-			"corridor_cleanliness",
-			"kitchen_cleanliness",
-			"cleaning_frequency",
-			"room_spaciousness",
-			"corridor_spaciousness",
-			"kitchen_spaciousness",
-			"shower_location_convenience",
-			"equipment_maintenance",
-			"window_condition",
-			"noise_isolation",
-			"common_areas_equipment",
-			"transport_accessibility",
-			"administration_quality",
-			"residents_culture_level",
+			"status",
+			"flag_reasons",
 		).
 		Values(
 			request.DormitoryId,
 			request.UserId,
-			request.BathroomCleanliness,
-			request.CorridorCleanliness,
-			request.KitchenCleanliness,
-			request.CleaningFrequency,
-			request.RoomSpaciousness,
-			request.CorridorSpaciousness,
-			request.KitchenSpaciousness,
-			request.ShowerLocationConvenience,
-			request.EquipmentMaintenance,
-			request.WindowCondition,
-			request.NoiseIsolation,
-			request.CommonAreasEquipment,
-			request.TransportAccessibility,
-			request.AdministrationQuality,
-			request.ResidentsCultureLevel,
+			gradeStatus(request.FlagReasons),
+			textArray(request.FlagReasons),
 		).
-		Suffix("RETURNING id")
+		Suffix("RETURNING id, status, created_at")
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
@@ -256,16 +314,668 @@ func (c *Database) createDormitoryGrade(
 
 	row := driver.QueryRowContext(ctx, query, args...)
 
-	var resp dbtypes.CreateDormitoryGradeResponse
+	var (
+		resp      dbtypes.CreateDormitoryGradeResponse
+		createdAt time.Time
+	)
 
-	err = row.Scan(&resp.GradeId)
+	err = row.Scan(&resp.GradeId, &resp.Status, &createdAt)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key") {
+		if pgErrorCode(err) == dberrors.PGErrUniqueViolation {
 			return nil, fmt.Errorf("%w: user can only rate this dormitory once per month", dberrors.ErrConflict)
 		}
 
 		return nil, fmt.Errorf("%w: error creating grade: %v", dberrors.ErrInternal, err)
 	}
 
+	if err := c.insertGradeScores(ctx, driver, resp.GradeId, request.Scores); err != nil {
+		return nil, err
+	}
+
+	if err := c.recomputeDormitoryAverages(ctx, driver, request.DormitoryId, createdAt); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) UpdateDormitoryGrade(
+	ctx context.Context,
+	request *dbtypes.UpdateDormitoryGradeRequest,
+) (*dbtypes.UpdateDormitoryGradeResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", dberrors.ErrBadRequest)
+	}
+
+	var resp *dbtypes.UpdateDormitoryGradeResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.updateDormitoryGrade(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// updateDormitoryGrade заменяет оценки пользователя за текущий месяц и пересчитывает средние периода
+func (c *Database) updateDormitoryGrade(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.UpdateDormitoryGradeRequest,
+) (*dbtypes.UpdateDormitoryGradeResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql                 = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+		dormitoryGradesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradesTable)
+		gradeScoresTable     = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradeScoresTableName)
+	)
+
+	// a rejected grade stays rejected, otherwise the edited scores are checked again
+	queryBuilder := psql.Update(dormitoryGradesTable).
+		Set("status", squirrel.Expr("CASE WHEN status = ? THEN status ELSE ? END", dbtypes.GradeStatusRejected, gradeStatus(request.FlagReasons))).
+		Set("flag_reasons", textArray(request.FlagReasons)).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{
+			"dormitory_id": request.DormitoryId,
+			"user_id":      request.UserId,
+		}).
+		Where(currentMonthGradeCondition).
+		Suffix("RETURNING id, status, created_at")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building update grade query: %v", dberrors.ErrInternal, err)
+	}
+
+	var (
+		resp      dbtypes.UpdateDormitoryGradeResponse
+		createdAt time.Time
+	)
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.GradeId, &resp.Status, &createdAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: no grade for the current month", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error updating grade: %v", dberrors.ErrInternal, err)
+	}
+
+	deleteQuery, deleteArgs, err := psql.Delete(gradeScoresTable).
+		Where(squirrel.Eq{"grade_id": resp.GradeId}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building delete grade scores query: %v", dberrors.ErrInternal, err)
+	}
+
+	if _, err := driver.ExecContext(ctx, deleteQuery, deleteArgs...); err != nil {
+		return nil, fmt.Errorf("%w: error deleting grade scores: %v", dberrors.ErrInternal, err)
+	}
+
+	if err := c.insertGradeScores(ctx, driver, resp.GradeId, request.Scores); err != nil {
+		return nil, err
+	}
+
+	if err := c.recomputeDormitoryAverages(ctx, driver, request.DormitoryId, createdAt); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) DeleteDormitoryGrade(
+	ctx context.Context,
+	request *dbtypes.DeleteDormitoryGradeRequest,
+) (*dbtypes.DeleteDormitoryGradeResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", dberrors.ErrBadRequest)
+	}
+
+	var resp *dbtypes.DeleteDormitoryGradeResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.deleteDormitoryGrade(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// deleteDormitoryGrade удаляет оценку пользователя за текущий месяц и пересчитывает средние периода
+func (c *Database) deleteDormitoryGrade(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.DeleteDormitoryGradeRequest,
+) (*dbtypes.DeleteDormitoryGradeResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql                 = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+		dormitoryGradesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradesTable)
+	)
+
+	// scores are removed by ON DELETE CASCADE
+	queryBuilder := psql.Delete(dormitoryGradesTable).
+		Where(squirrel.Eq{
+			"dormitory_id": request.DormitoryId,
+			"user_id":      request.UserId,
+		}).
+		Where(currentMonthGradeCondition).
+		Suffix("RETURNING id, created_at")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building delete grade query: %v", dberrors.ErrInternal, err)
+	}
+
+	var (
+		resp      dbtypes.DeleteDormitoryGradeResponse
+		createdAt time.Time
+	)
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.GradeId, &createdAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: no grade for the current month", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error deleting grade: %v", dberrors.ErrInternal, err)
+	}
+
+	if err := c.recomputeDormitoryAverages(ctx, driver, request.DormitoryId, createdAt); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) insertGradeScores(
+	ctx context.Context,
+	driver Driver,
+	gradeId string,
+	scores map[string]int,
+) error {
+	if len(scores) == 0 {
+		return fmt.Errorf("%w: grade has no scores", dberrors.ErrBadRequest)
+	}
+
+	var (
+		psql             = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+		gradeScoresTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradeScoresTableName)
+	)
+
+	queryBuilder := psql.Insert(gradeScoresTable).
+		Columns("grade_id", "criterion_code", "score")
+
+	for code, score := range scores {
+		queryBuilder = queryBuilder.Values(gradeId, code, score)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: error building insert grade scores query: %v", dberrors.ErrInternal, err)
+	}
+
+	if _, err := driver.ExecContext(ctx, query, args...); err != nil {
+		switch pgErrorCode(err) {
+		case dberrors.PGErrForeignKeyViolation:
+			return fmt.Errorf("%w: unknown grade criterion", dberrors.ErrBadRequest)
+		case dberrors.PGErrCheckViolation:
+			return fmt.Errorf("%w: grades must be between 1 and 5", dberrors.ErrBadRequest)
+		}
+
+		return fmt.Errorf("%w: error inserting grade scores: %v", dberrors.ErrInternal, err)
+	}
+
+	return nil
+}
+
+// recomputeDormitoryAverages пересобирает средние общежития за месяц, в который попадает at,
+// из сырых одобренных оценок. Общая средняя - взвешенное весами критериев среднее по критериям.
+// Если оценок за месяц не осталось, строки периода удаляются. Вызывается только в транзакции
+func (c *Database) recomputeDormitoryAverages(
+	ctx context.Context,
+	driver Driver,
+	dormitoryId string,
+	at time.Time,
+) error {
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		dormitoryGradesTable    = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradesTable)
+		gradeScoresTable        = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradeScoresTableName)
+		criteriaTable           = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradeCriteriaTableName)
+		dormitoryAvgGradesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryAvgGradesTableName)
+		avgScoresTable          = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryAvgScoresTableName)
+
+		period = time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC).Format(time.DateOnly)
+	)
+
+	// пересчеты одного месяца идут по очереди до конца транзакции. Иначе параллельная запись
+	// считает средние по снимку без чужой оценки и затирает ими правильный итог
+	lockQuery, lockArgs, err := psql.
+		Select().
+		Column(squirrel.Expr("pg_advisory_xact_lock(hashtext(?))", dormitoryId+":"+period)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: error building lock period averages query: %v", dberrors.ErrInternal, err)
+	}
+
+	if _, err := driver.ExecContext(ctx, lockQuery, lockArgs...); err != nil {
+		return fmt.Errorf("%w: error locking period averages: %v", dberrors.ErrInternal, err)
+	}
+
+	periodGrades := squirrel.
+		Select("g.id").
+		From(dormitoryGradesTable+" g").
+		Where(squirrel.Eq{
+			"g.dormitory_id": dormitoryId,
+			"g.status":       dbtypes.GradeStatusApproved,
+		}).
+		Where("DATE_TRUNC('month', g.created_at) = ?::timestamp", period)
+
+	countQuery, countArgs, err := psql.
+		Select("COUNT(*)").
+		FromSelect(periodGrades, "pg").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: error building count period grades query: %v", dberrors.ErrInternal, err)
+	}
+
+	var totalRatings int
+	if err := driver.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalRatings); err != nil {
+		return fmt.Errorf("%w: error counting period grades: %v", dberrors.ErrInternal, err)
+	}
+
+	// строка периода остается, пока есть оценки, и обновляется ниже, чтобы сохранить id и created_at
+	staleTables := []string{avgScoresTable}
+	if totalRatings == 0 {
+		staleTables = append(staleTables, dormitoryAvgGradesTable)
+	}
+
+	for _, table := range staleTables {
+		deleteQuery, deleteArgs, err := psql.Delete(table).
+			Where(squirrel.Eq{"dormitory_id": dormitoryId}).
+			Where("period_date = ?::date", period).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("%w: error building delete period averages query: %v", dberrors.ErrInternal, err)
+		}
+
+		if _, err := driver.ExecContext(ctx, deleteQuery, deleteArgs...); err != nil {
+			return fmt.Errorf("%w: error deleting period averages: %v", dberrors.ErrInternal, err)
+		}
+	}
+
+	if totalRatings == 0 {
+		return nil
+	}
+
+	averagesQuery, averagesArgs, err := psql.
+		Select("s.criterion_code", "AVG(s.score::DECIMAL)", "COUNT(*)", "c.weight").
+		From(gradeScoresTable+" s").
+		Join(criteriaTable+" c ON c.code = s.criterion_code").
+		Where(squirrel.Expr("s.grade_id IN (?)", periodGrades)).
+		GroupBy("s.criterion_code", "c.weight").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: error building period averages query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, averagesQuery, averagesArgs...)
+	if err != nil {
+		return fmt.Errorf("%w: error executing period averages query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	var (
+		averages    []dbtypes.CriterionAverage
+		weightedSum float64
+		weightSum   float64
+	)
+
+	for rows.Next() {
+		var (
+			average dbtypes.CriterionAverage
+			weight  float64
+		)
+
+		if err := rows.Scan(&average.Code, &average.Average, &average.TotalRatings, &weight); err != nil {
+			return fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		weightedSum += average.Average * weight
+		weightSum += weight
+
+		average.Average = roundGrade(average.Average)
+		averages = append(averages, average)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	var overallAverage float64
+	if weightSum > 0 {
+		overallAverage = roundGrade(weightedSum / weightSum)
+	}
+
+	upsertQuery, upsertArgs, err := psql.Insert(dormitoryAvgGradesTable).
+		Columns("dormitory_id", "period_date", "overall_average", "total_ratings").
+		Values(dormitoryId, squirrel.Expr("?::date", period), overallAverage, totalRatings).
+		Suffix(`ON CONFLICT (dormitory_id, period_date) DO UPDATE SET
+			overall_average = EXCLUDED.overall_average,
+			total_ratings = EXCLUDED.total_ratings,
+			updated_at = CURRENT_TIMESTAMP`).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: error building upsert period average query: %v", dberrors.ErrInternal, err)
+	}
+
+	if _, err := driver.ExecContext(ctx, upsertQuery, upsertArgs...); err != nil {
+		return fmt.Errorf("%w: error upserting period average: %v", dberrors.ErrInternal, err)
+	}
+
+	if len(averages) == 0 {
+		return nil
+	}
+
+	insertBuilder := psql.Insert(avgScoresTable).
+		Columns("dormitory_id", "period_date", "criterion_code", "avg_score", "total_ratings")
+
+	for _, average := range averages {
+		insertBuilder = insertBuilder.Values(
+			dormitoryId,
+			squirrel.Expr("?::date", period),
+			average.Code,
+			average.Average,
+			average.TotalRatings,
+		)
+	}
+
+	insertQuery, insertArgs, err := insertBuilder.
+		Suffix(`ON CONFLICT (dormitory_id, period_date, criterion_code) DO UPDATE SET
+			avg_score = EXCLUDED.avg_score,
+			total_ratings = EXCLUDED.total_ratings`).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: error building insert criterion averages query: %v", dberrors.ErrInternal, err)
+	}
+
+	if _, err := driver.ExecContext(ctx, insertQuery, insertArgs...); err != nil {
+		return fmt.Errorf("%w: error inserting criterion averages: %v", dberrors.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func gradeStatus(flagReasons []string) dbtypes.GradeStatus {
+	if len(flagReasons) > 0 {
+		return dbtypes.GradeStatusFlagged
+	}
+
+	return dbtypes.GradeStatusApproved
+}
+
+// textArray не дает записать NULL в NOT NULL колонку TEXT[]
+func textArray(values []string) pq.StringArray {
+	if values == nil {
+		return pq.StringArray{}
+	}
+
+	return pq.StringArray(values)
+}
+
+// roundGrade округляет среднее до точности колонок DECIMAL(3,2)
+func roundGrade(val float64) float64 {
+	return math.Round(val*100) / 100
+}
+
+func (c *Database) GetGradesDistribution(
+	ctx context.Context,
+	request *dbtypes.GetGradesDistributionRequest,
+) (*dbtypes.GetGradesDistributionResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", dberrors.ErrBadRequest)
+	}
+
+	resp, err := c.getGradesDistribution(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// getGradesDistribution считает, сколько раз каждый балл 1-5 был поставлен по каждому критерию
+func (c *Database) getGradesDistribution(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetGradesDistributionRequest,
+) (*dbtypes.GetGradesDistributionResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql                 = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+		dormitoryGradesTable = fmt.Sprintf("%s.%s g", constants.SchemaName, constants.GradesTable)
+		gradeScoresTable     = fmt.Sprintf("%s.%s s", constants.SchemaName, constants.GradeScoresTableName)
+	)
+
+	queryBuilder := psql.
+		Select("s.criterion_code", "s.score", "COUNT(*)").
+		From(gradeScoresTable).
+		Join(fmt.Sprintf("%s ON g.id = s.grade_id", dormitoryGradesTable)).
+		Where(squirrel.Eq{
+			"g.dormitory_id": request.DormitoryId,
+			"g.status":       dbtypes.GradeStatusApproved,
+		}).
+		GroupBy("s.criterion_code", "s.score").
+		OrderBy("s.criterion_code", "s.score")
+
+	// criteria may be skipped, so the number of grades is counted separately
+	countBuilder := psql.
+		Select("COUNT(*)").
+		From(dormitoryGradesTable).
+		Where(squirrel.Eq{
+			"g.dormitory_id": request.DormitoryId,
+			"g.status":       dbtypes.GradeStatusApproved,
+		})
+
+	if request.Period != nil {
+		periodCondition := squirrel.Expr("DATE_TRUNC('month', g.created_at) = ?::timestamp", request.Period.Format(time.DateOnly))
+
+		queryBuilder = queryBuilder.Where(periodCondition)
+		countBuilder = countBuilder.Where(periodCondition)
+	}
+
+	countQuery, countArgs, err := countBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building count grades query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.GetGradesDistributionResponse
+
+	if err := driver.QueryRowContext(ctx, countQuery, countArgs...).Scan(&resp.TotalRatings); err != nil {
+		return nil, fmt.Errorf("%w: error counting grades: %v", dberrors.ErrInternal, err)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get grades distribution query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get grades distribution query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var count dbtypes.GradeScoreCount
+		if err := rows.Scan(
+			&count.Criterion,
+			&count.Score,
+			&count.Count,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		resp.Counts = append(resp.Counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) GetDormitoriesPeriodScores(
+	ctx context.Context,
+	request *dbtypes.GetDormitoriesPeriodScoresRequest,
+) (*dbtypes.GetDormitoriesPeriodScoresResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", dberrors.ErrBadRequest)
+	}
+
+	resp, err := c.getDormitoriesPeriodScores(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// getDormitoriesPeriodScores сводит помесячные средние всех общежитий за период.
+// Среднее критерия за несколько месяцев взвешивается количеством оценок каждого месяца
+func (c *Database) getDormitoriesPeriodScores(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetDormitoriesPeriodScoresRequest,
+) (*dbtypes.GetDormitoriesPeriodScoresResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		dormitoryTable          = fmt.Sprintf("%s.%s d", constants.SchemaName, constants.DormitoryTableName)
+		dormitoryAvgGradesTable = fmt.Sprintf("%s.%s dag", constants.SchemaName, constants.DormitoryAvgGradesTableName)
+		avgScoresTable          = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryAvgScoresTableName)
+
+		from = request.From.Format(time.DateOnly)
+		to   = request.To.Format(time.DateOnly)
+	)
+
+	query, args, err := psql.
+		Select("dag.dormitory_id", "d.name", "SUM(dag.total_ratings)").
+		From(dormitoryAvgGradesTable).
+		Join(fmt.Sprintf("%s ON d.id = dag.dormitory_id", dormitoryTable)).
+		Where("dag.period_date >= ?::date", from).
+		Where("dag.period_date < ?::date", to).
+		GroupBy("dag.dormitory_id", "d.name").
+		OrderBy("dag.dormitory_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get period ratings query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get period ratings query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	var (
+		resp         dbtypes.GetDormitoriesPeriodScoresResponse
+		dormitoryIdx = make(map[string]int)
+	)
+
+	for rows.Next() {
+		var dormitory dbtypes.DormitoryPeriodScores
+		if err := rows.Scan(
+			&dormitory.DormitoryId,
+			&dormitory.Name,
+			&dormitory.TotalRatings,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		dormitoryIdx[dormitory.DormitoryId] = len(resp.Dormitories)
+		resp.Dormitories = append(resp.Dormitories, dormitory)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	query, args, err = psql.
+		Select(
+			"dormitory_id",
+			"criterion_code",
+			"SUM(avg_score * total_ratings) / SUM(total_ratings)",
+			"SUM(total_ratings)",
+		).
+		From(avgScoresTable).
+		Where("period_date >= ?::date", from).
+		Where("period_date < ?::date", to).
+		GroupBy("dormitory_id", "criterion_code").
+		Having("SUM(total_ratings) > 0").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get period scores query: %v", dberrors.ErrInternal, err)
+	}
+
+	scoreRows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get period scores query: %v", dberrors.ErrInternal, err)
+	}
+	defer scoreRows.Close()
+
+	for scoreRows.Next() {
+		var (
+			dormitoryId string
+			average     dbtypes.CriterionAverage
+		)
+
+		if err := scoreRows.Scan(
+			&dormitoryId,
+			&average.Code,
+			&average.Average,
+			&average.TotalRatings,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		idx, ok := dormitoryIdx[dormitoryId]
+		if !ok {
+			continue
+		}
+
+		resp.Dormitories[idx].Criteria = append(resp.Dormitories[idx].Criteria, average)
+	}
+
+	if err := scoreRows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
 	return &resp, nil
 }
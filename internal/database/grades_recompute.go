@@ -0,0 +1,318 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+// errDryRun откатывает транзакцию пробного пересчета
+var errDryRun = errors.New("dry run")
+
+type avgGradePeriod struct {
+	dormitoryId string
+	period      time.Time
+}
+
+type avgGradeValueKey struct {
+	avgGradePeriod
+	field string
+}
+
+// RecomputeAvgGrades пересобирает помесячные средние из сырых оценок в одной транзакции
+// и возвращает изменившиеся значения. При DryRun транзакция откатывается
+func (c *Database) RecomputeAvgGrades(
+	ctx context.Context,
+	request *dbtypes.RecomputeAvgGradesRequest,
+) (*dbtypes.RecomputeAvgGradesResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", dberrors.ErrBadRequest)
+	}
+
+	var resp *dbtypes.RecomputeAvgGradesResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.recomputeAvgGrades(ctx, driver, request)
+		if err != nil {
+			return err
+		}
+
+		if request.DryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) recomputeAvgGrades(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.RecomputeAvgGradesRequest,
+) (*dbtypes.RecomputeAvgGradesResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	before, err := c.snapshotAvgGrades(ctx, driver, request)
+	if err != nil {
+		return nil, err
+	}
+
+	periods, err := c.gradedPeriods(ctx, driver, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// periods that only have stale averages are recomputed too, which removes them
+	for key := range before {
+		periods[key.avgGradePeriod] = struct{}{}
+	}
+
+	ordered := make([]avgGradePeriod, 0, len(periods))
+	for period := range periods {
+		ordered = append(ordered, period)
+	}
+
+	// пересчет берет блокировку каждого месяца, общий порядок не дает двум пересчетам ждать друг друга
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].dormitoryId != ordered[j].dormitoryId {
+			return ordered[i].dormitoryId < ordered[j].dormitoryId
+		}
+
+		return ordered[i].period.Before(ordered[j].period)
+	})
+
+	for _, period := range ordered {
+		if err := c.recomputeDormitoryAverages(ctx, driver, period.dormitoryId, period.period); err != nil {
+			return nil, err
+		}
+	}
+
+	after, err := c.snapshotAvgGrades(ctx, driver, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbtypes.RecomputeAvgGradesResponse{
+		Periods: len(periods),
+		Changes: diffAvgGrades(before, after),
+	}, nil
+}
+
+// gradedPeriods возвращает месяцы, за которые в общежитиях есть оценки
+func (c *Database) gradedPeriods(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.RecomputeAvgGradesRequest,
+) (map[avgGradePeriod]struct{}, error) {
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		dormitoryGradesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.GradesTable)
+	)
+
+	queryBuilder := psql.
+		Select("DISTINCT dormitory_id", "DATE_TRUNC('month', created_at)::date").
+		From(dormitoryGradesTable)
+
+	if request.DormitoryId != "" {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"dormitory_id": request.DormitoryId})
+	}
+
+	if request.From != nil {
+		queryBuilder = queryBuilder.Where("created_at >= ?::timestamp", request.From.Format(time.DateOnly))
+	}
+
+	if request.To != nil {
+		queryBuilder = queryBuilder.Where("created_at < ?::timestamp", request.To.AddDate(0, 1, 0).Format(time.DateOnly))
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get graded periods query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get graded periods query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	periods := make(map[avgGradePeriod]struct{})
+	for rows.Next() {
+		var period avgGradePeriod
+		if err := rows.Scan(&period.dormitoryId, &period.period); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		period.period = normalizePeriod(period.period)
+		periods[period] = struct{}{}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return periods, nil
+}
+
+// snapshotAvgGrades читает текущие средние периодов в плоском виде для сравнения
+func (c *Database) snapshotAvgGrades(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.RecomputeAvgGradesRequest,
+) (map[avgGradeValueKey]float64, error) {
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		dormitoryAvgGradesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryAvgGradesTableName)
+		avgScoresTable          = fmt.Sprintf("%s.%s", constants.SchemaName, constants.DormitoryAvgScoresTableName)
+	)
+
+	filter := func(builder squirrel.SelectBuilder) squirrel.SelectBuilder {
+		if request.DormitoryId != "" {
+			builder = builder.Where(squirrel.Eq{"dormitory_id": request.DormitoryId})
+		}
+
+		if request.From != nil {
+			builder = builder.Where("period_date >= ?::date", request.From.Format(time.DateOnly))
+		}
+
+		if request.To != nil {
+			builder = builder.Where("period_date <= ?::date", request.To.Format(time.DateOnly))
+		}
+
+		return builder
+	}
+
+	snapshot := make(map[avgGradeValueKey]float64)
+
+	queries := []squirrel.SelectBuilder{
+		filter(psql.
+			Select("dormitory_id", "period_date", "'overall_average'", "overall_average").
+			From(dormitoryAvgGradesTable)),
+		filter(psql.
+			Select("dormitory_id", "period_date", "'total_ratings'", "total_ratings").
+			From(dormitoryAvgGradesTable)),
+		filter(psql.
+			Select("dormitory_id", "period_date", "criterion_code", "avg_score").
+			From(avgScoresTable)),
+		filter(psql.
+			Select("dormitory_id", "period_date", "criterion_code || '.total_ratings'", "total_ratings").
+			From(avgScoresTable)),
+	}
+
+	for _, queryBuilder := range queries {
+		query, args, err := queryBuilder.ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("%w: error building snapshot avg grades query: %v", dberrors.ErrInternal, err)
+		}
+
+		if err := scanAvgGradeValues(ctx, driver, snapshot, query, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+func scanAvgGradeValues(
+	ctx context.Context,
+	driver Driver,
+	snapshot map[avgGradeValueKey]float64,
+	query string,
+	args ...any,
+) error {
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: error executing snapshot avg grades query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			key   avgGradeValueKey
+			value float64
+		)
+
+		if err := rows.Scan(&key.dormitoryId, &key.period, &key.field, &value); err != nil {
+			return fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		key.period = normalizePeriod(key.period)
+		snapshot[key] = value
+	}
+
+	return rows.Err()
+}
+
+func diffAvgGrades(before, after map[avgGradeValueKey]float64) []dbtypes.AvgGradeChange {
+	keys := make(map[avgGradeValueKey]struct{}, len(after))
+	for key := range before {
+		keys[key] = struct{}{}
+	}
+
+	for key := range after {
+		keys[key] = struct{}{}
+	}
+
+	var changes []dbtypes.AvgGradeChange
+	for key := range keys {
+		beforeVal, hadBefore := before[key]
+		afterVal, hasAfter := after[key]
+
+		if hadBefore && hasAfter && beforeVal == afterVal {
+			continue
+		}
+
+		change := dbtypes.AvgGradeChange{
+			DormitoryId: key.dormitoryId,
+			PeriodDate:  key.period,
+			Field:       key.field,
+		}
+
+		if hadBefore {
+			change.Before = &beforeVal
+		}
+
+		if hasAfter {
+			change.After = &afterVal
+		}
+
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].DormitoryId != changes[j].DormitoryId {
+			return changes[i].DormitoryId < changes[j].DormitoryId
+		}
+
+		if !changes[i].PeriodDate.Equal(changes[j].PeriodDate) {
+			return changes[i].PeriodDate.Before(changes[j].PeriodDate)
+		}
+
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+// normalizePeriod приводит дату из Postgres к первому числу месяца в UTC, чтобы даты сравнивались как ключи
+func normalizePeriod(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
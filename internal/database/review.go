@@ -9,6 +9,7 @@ import (
 	"github.com/dormitory-life/core/internal/constants"
 	dberrors "github.com/dormitory-life/core/internal/database/errors"
 	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	"github.com/lib/pq"
 )
 
 func (c *Database) GetReviews(
@@ -44,13 +45,35 @@ func (c *Database) getReviews(
 
 	queryBuilder := psql.
 		Select(
-			"id", "owner_id", "dormitory_id", "title", "description", "created_at",
+			"id", "owner_id", "dormitory_id", "title", "description", "status", "created_at", "updated_at",
+			"helpful_count", "unhelpful_count",
 		).
 		From(reviewTable).
 		Where(squirrel.Eq{"dormitory_id": request.DormitoryId}).
 		Offset(countOffset(request.Page, constants.DefaultReviewsPageSize)).
 		Limit(constants.DefaultReviewsPageSize).
-		OrderBy("created_at DESC")
+		OrderBy(reviewsOrderBy(request.Sort)...)
+
+	if !request.IncludeHidden {
+		queryBuilder = queryBuilder.Where(visibleReviewsCondition(request.ViewerId))
+	}
+
+	if request.MinGrade != nil || request.MaxGrade != nil {
+		gradedReviews := squirrel.
+			Select("review_id").
+			From(fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewAuthorGradesViewName)).
+			Where(squirrel.Eq{"dormitory_id": request.DormitoryId})
+
+		if request.MinGrade != nil {
+			gradedReviews = gradedReviews.Where(squirrel.GtOrEq{"overall": *request.MinGrade})
+		}
+
+		if request.MaxGrade != nil {
+			gradedReviews = gradedReviews.Where(squirrel.LtOrEq{"overall": *request.MaxGrade})
+		}
+
+		queryBuilder = queryBuilder.Where(squirrel.Expr("id IN (?)", gradedReviews))
+	}
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
@@ -75,7 +98,11 @@ func (c *Database) getReviews(
 			&review.DormitoryId,
 			&review.Title,
 			&review.Description,
+			&review.Status,
 			&review.CreatedAt,
+			&review.UpdatedAt,
+			&review.HelpfulCount,
+			&review.UnhelpfulCount,
 		); err != nil {
 			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
 		}
@@ -83,11 +110,63 @@ func (c *Database) getReviews(
 		reviews = append(reviews, review)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	reviewIds := make([]string, 0, len(reviews))
+	for _, review := range reviews {
+		reviewIds = append(reviewIds, review.ReviewId)
+	}
+
+	replies, err := c.getReviewsReplies(ctx, driver, reviewIds)
+	if err != nil {
+		return nil, err
+	}
+
+	authorGrades, err := c.getReviewsAuthorGrades(ctx, driver, reviewIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range reviews {
+		reviews[i].Replies = replies[reviews[i].ReviewId]
+		reviews[i].AuthorGrade = authorGrades[reviews[i].ReviewId]
+	}
+
 	return &dbtypes.GetDormitoryReviewsResponse{
 		Reviews: reviews,
 	}, nil
 }
 
+// visibleReviewsCondition - скрытые отзывы видит только их автор
+func visibleReviewsCondition(viewerId string) squirrel.Sqlizer {
+	visible := squirrel.Or{squirrel.NotEq{"status": dbtypes.ReviewStatusHidden}}
+	if viewerId != "" {
+		visible = append(visible, squirrel.Eq{"owner_id": viewerId})
+	}
+
+	return visible
+}
+
+// reviewsOrderBy повторяет выражения индексов idx_reviews_dormitory_*, чтобы сортировка шла по индексу
+func reviewsOrderBy(sort dbtypes.ReviewSort) []string {
+	switch sort {
+	case dbtypes.ReviewSortOldest:
+		return []string{"created_at ASC"}
+	case dbtypes.ReviewSortMostHelpful:
+		return []string{"(helpful_count - unhelpful_count) DESC", "created_at DESC"}
+	case dbtypes.ReviewSortControversial:
+		return []string{
+			"LEAST(helpful_count, unhelpful_count) DESC",
+			"(helpful_count + unhelpful_count) DESC",
+			"created_at DESC",
+		}
+	default:
+		return []string{"created_at DESC"}
+	}
+}
+
 func (c *Database) CreateReview(
 	ctx context.Context,
 	request *dbtypes.CreateReviewRequest,
@@ -230,7 +309,8 @@ func (c *Database) getReviewById(
 
 	queryBuilder := psql.
 		Select(
-			"id", "owner_id", "dormitory_id", "title", "description", "created_at",
+			"id", "owner_id", "dormitory_id", "title", "description", "status", "created_at", "updated_at",
+			"helpful_count", "unhelpful_count",
 		).
 		From(reviewTable).
 		Where(squirrel.Eq{"id": request.ReviewId}).
@@ -249,7 +329,11 @@ func (c *Database) getReviewById(
 		&review.DormitoryId,
 		&review.Title,
 		&review.Description,
+		&review.Status,
 		&review.CreatedAt,
+		&review.UpdatedAt,
+		&review.HelpfulCount,
+		&review.UnhelpfulCount,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -263,3 +347,288 @@ func (c *Database) getReviewById(
 		Review: review,
 	}, nil
 }
+
+func (c *Database) UpdateReview(
+	ctx context.Context,
+	request *dbtypes.UpdateReviewRequest,
+) (*dbtypes.UpdateReviewResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var resp *dbtypes.UpdateReviewResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.updateReview(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// updateReview меняет отзыв и записывает правку в историю
+func (c *Database) updateReview(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.UpdateReviewRequest,
+) (*dbtypes.UpdateReviewResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		reviewTable      = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewTableName)
+		reviewEditsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewEditsTableName)
+	)
+
+	// блокируем строку, чтобы старые значения в истории совпали с тем, что перезаписываем
+	selectQuery, selectArgs, err := psql.
+		Select("title", "description").
+		From(reviewTable).
+		Where(squirrel.Eq{"id": request.ReviewId}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building select review query: %v", dberrors.ErrInternal, err)
+	}
+
+	var oldTitle, oldDescription string
+
+	if err := driver.QueryRowContext(ctx, selectQuery, selectArgs...).Scan(&oldTitle, &oldDescription); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: review not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error selecting review: %v", dberrors.ErrInternal, err)
+	}
+
+	queryBuilder := psql.Update(reviewTable).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{"id": request.ReviewId})
+
+	if request.Title != nil {
+		queryBuilder = queryBuilder.Set("title", *request.Title)
+	}
+
+	if request.Description != nil {
+		queryBuilder = queryBuilder.Set("description", *request.Description)
+	}
+
+	queryBuilder = queryBuilder.
+		Suffix("RETURNING id, owner_id, dormitory_id, title, description, status, created_at, updated_at, helpful_count, unhelpful_count")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building update review query: %v", dberrors.ErrInternal, err)
+	}
+
+	var review dbtypes.Review
+
+	err = driver.QueryRowContext(ctx, query, args...).Scan(
+		&review.ReviewId,
+		&review.OwnerId,
+		&review.DormitoryId,
+		&review.Title,
+		&review.Description,
+		&review.Status,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+		&review.HelpfulCount,
+		&review.UnhelpfulCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing update review query: %v", dberrors.ErrInternal, err)
+	}
+
+	editQuery, editArgs, err := psql.Insert(reviewEditsTable).
+		Columns(
+			"review_id", "editor_id", "old_title", "new_title",
+			"old_description", "new_description", "added_photos", "removed_photos", "edited_at",
+		).
+		Values(
+			review.ReviewId,
+			request.EditorId,
+			oldTitle,
+			review.Title,
+			oldDescription,
+			review.Description,
+			textArray(request.AddedPhotos),
+			textArray(request.RemovedPhotos),
+			review.UpdatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building insert review edit query: %v", dberrors.ErrInternal, err)
+	}
+
+	if _, err := driver.ExecContext(ctx, editQuery, editArgs...); err != nil {
+		return nil, fmt.Errorf("%w: error inserting review edit: %v", dberrors.ErrInternal, err)
+	}
+
+	return &dbtypes.UpdateReviewResponse{
+		Review: review,
+	}, nil
+}
+
+func (c *Database) GetReviewEdits(
+	ctx context.Context,
+	request *dbtypes.GetReviewEditsRequest,
+) (*dbtypes.GetReviewEditsResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getReviewEdits(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) getReviewEdits(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetReviewEditsRequest,
+) (*dbtypes.GetReviewEditsResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		reviewEditsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewEditsTableName)
+	)
+
+	queryBuilder := psql.
+		Select(
+			"id", "review_id", "editor_id", "old_title", "new_title",
+			"old_description", "new_description", "added_photos", "removed_photos", "edited_at",
+		).
+		From(reviewEditsTable).
+		Where(squirrel.Eq{"review_id": request.ReviewId}).
+		OrderBy("edited_at DESC")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get review edits query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get review edits query: %v", dberrors.ErrInternal, err)
+	}
+
+	defer rows.Close()
+
+	resp := dbtypes.GetReviewEditsResponse{
+		Edits: make([]dbtypes.ReviewEdit, 0),
+	}
+
+	for rows.Next() {
+		var (
+			edit          dbtypes.ReviewEdit
+			addedPhotos   pq.StringArray
+			removedPhotos pq.StringArray
+		)
+
+		if err := rows.Scan(
+			&edit.EditId,
+			&edit.ReviewId,
+			&edit.EditorId,
+			&edit.OldTitle,
+			&edit.NewTitle,
+			&edit.OldDescription,
+			&edit.NewDescription,
+			&addedPhotos,
+			&removedPhotos,
+			&edit.EditedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		edit.AddedPhotos = addedPhotos
+		edit.RemovedPhotos = removedPhotos
+
+		resp.Edits = append(resp.Edits, edit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+// getReviewsAuthorGrades загружает оценки авторов за месяц отзыва для страницы отзывов
+func (c *Database) getReviewsAuthorGrades(
+	ctx context.Context,
+	driver Driver,
+	reviewIds []string,
+) (map[string]*dbtypes.ReviewAuthorGrade, error) {
+	grades := make(map[string]*dbtypes.ReviewAuthorGrade, len(reviewIds))
+
+	if len(reviewIds) == 0 {
+		return grades, nil
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		authorGradesView = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewAuthorGradesViewName)
+	)
+
+	query, args, err := psql.
+		Select(
+			"review_id", "grade_id", "overall",
+			"lowest_code", "lowest_score", "highest_code", "highest_score",
+		).
+		From(authorGradesView).
+		Where("review_id = ANY(?::uuid[])", pq.Array(reviewIds)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get review author grades query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get review author grades query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			reviewId string
+			grade    dbtypes.ReviewAuthorGrade
+		)
+
+		if err := rows.Scan(
+			&reviewId,
+			&grade.GradeId,
+			&grade.Overall,
+			&grade.LowestCode,
+			&grade.LowestScore,
+			&grade.HighestCode,
+			&grade.HighestScore,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		grades[reviewId] = &grade
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return grades, nil
+}
@@ -0,0 +1,341 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	"github.com/lib/pq"
+)
+
+func (c *Database) CreateReviewReport(
+	ctx context.Context,
+	request *dbtypes.CreateReviewReportRequest,
+) (*dbtypes.CreateReviewReportResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var resp *dbtypes.CreateReviewReportResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.createReviewReport(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// createReviewReport сохраняет жалобу и отправляет опубликованный отзыв на проверку
+func (c *Database) createReviewReport(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.CreateReviewReportRequest,
+) (*dbtypes.CreateReviewReportResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		reviewTable        = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewTableName)
+		reviewReportsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewReportsTableName)
+	)
+
+	statusQuery, statusArgs, err := psql.Update(reviewTable).
+		Set("status", squirrel.Expr(
+			"CASE WHEN status = ? THEN ? ELSE status END",
+			dbtypes.ReviewStatusPublished, dbtypes.ReviewStatusPending,
+		)).
+		Where(squirrel.Eq{
+			"id":           request.ReviewId,
+			"dormitory_id": request.DormitoryId,
+		}).
+		Suffix("RETURNING status").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building update review status query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.CreateReviewReportResponse
+
+	if err := driver.QueryRowContext(ctx, statusQuery, statusArgs...).Scan(&resp.ReviewStatus); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: review not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error updating review status: %v", dberrors.ErrInternal, err)
+	}
+
+	query, args, err := psql.Insert(reviewReportsTable).
+		Columns("review_id", "reporter_id", "reason", "comment").
+		Values(request.ReviewId, request.ReporterId, request.Reason, request.Comment).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building create review report query: %v", dberrors.ErrInternal, err)
+	}
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.ReportId); err != nil {
+		if pgErrorCode(err) == dberrors.PGErrUniqueViolation {
+			return nil, fmt.Errorf("%w: review already reported by user", dberrors.ErrConflict)
+		}
+
+		return nil, fmt.Errorf("%w: error creating review report: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) GetModerationReviews(
+	ctx context.Context,
+	request *dbtypes.GetModerationReviewsRequest,
+) (*dbtypes.GetModerationReviewsResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getModerationReviews(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// getModerationReviews возвращает отзывы с нужным статусом вместе с жалобами на них
+func (c *Database) getModerationReviews(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetModerationReviewsRequest,
+) (*dbtypes.GetModerationReviewsResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		reviewTable        = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewTableName)
+		reviewReportsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewReportsTableName)
+
+		condition = squirrel.Eq{
+			"dormitory_id": request.DormitoryId,
+			"status":       request.Status,
+		}
+	)
+
+	countQuery, countArgs, err := psql.
+		Select("COUNT(*)").
+		From(reviewTable).
+		Where(condition).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building count moderation reviews query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.GetModerationReviewsResponse
+
+	if err := driver.QueryRowContext(ctx, countQuery, countArgs...).Scan(&resp.Total); err != nil {
+		return nil, fmt.Errorf("%w: error counting moderation reviews: %v", dberrors.ErrInternal, err)
+	}
+
+	query, args, err := psql.
+		Select(
+			"id", "owner_id", "dormitory_id", "title", "description", "status", "created_at", "updated_at",
+			"helpful_count", "unhelpful_count",
+		).
+		From(reviewTable).
+		Where(condition).
+		OrderBy("created_at DESC").
+		Offset(countOffset(request.Page, constants.DefaultPaginationPageSize)).
+		Limit(constants.DefaultPaginationPageSize).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get moderation reviews query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get moderation reviews query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	var (
+		reviewIds []string
+		reviewIdx = make(map[string]int)
+	)
+
+	for rows.Next() {
+		var review dbtypes.ModerationReview
+
+		if err := rows.Scan(
+			&review.ReviewId,
+			&review.OwnerId,
+			&review.DormitoryId,
+			&review.Title,
+			&review.Description,
+			&review.Status,
+			&review.CreatedAt,
+			&review.UpdatedAt,
+			&review.HelpfulCount,
+			&review.UnhelpfulCount,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		review.Reports = make([]dbtypes.ReviewReport, 0)
+
+		reviewIdx[review.ReviewId] = len(resp.Reviews)
+		reviewIds = append(reviewIds, review.ReviewId)
+		resp.Reviews = append(resp.Reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	if len(reviewIds) == 0 {
+		return &resp, nil
+	}
+
+	reportsQuery, reportsArgs, err := psql.
+		Select("id", "review_id", "reporter_id", "reason", "comment", "created_at", "resolved_at").
+		From(reviewReportsTable).
+		Where("review_id = ANY(?::uuid[])", pq.Array(reviewIds)).
+		OrderBy("created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get review reports query: %v", dberrors.ErrInternal, err)
+	}
+
+	reportRows, err := driver.QueryContext(ctx, reportsQuery, reportsArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get review reports query: %v", dberrors.ErrInternal, err)
+	}
+	defer reportRows.Close()
+
+	for reportRows.Next() {
+		var report dbtypes.ReviewReport
+
+		if err := reportRows.Scan(
+			&report.ReportId,
+			&report.ReviewId,
+			&report.ReporterId,
+			&report.Reason,
+			&report.Comment,
+			&report.CreatedAt,
+			&report.ResolvedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		if idx, ok := reviewIdx[report.ReviewId]; ok {
+			resp.Reviews[idx].Reports = append(resp.Reviews[idx].Reports, report)
+		}
+	}
+
+	if err := reportRows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) ModerateReview(
+	ctx context.Context,
+	request *dbtypes.ModerateReviewRequest,
+) (*dbtypes.ModerateReviewResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var resp *dbtypes.ModerateReviewResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.moderateReview(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// moderateReview сохраняет решение администратора и закрывает открытые жалобы на отзыв
+func (c *Database) moderateReview(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.ModerateReviewRequest,
+) (*dbtypes.ModerateReviewResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		reviewTable        = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewTableName)
+		reviewReportsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewReportsTableName)
+	)
+
+	query, args, err := psql.Update(reviewTable).
+		Set("status", request.Status).
+		Set("moderated_by", request.ModeratorId).
+		Set("moderated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{
+			"id":           request.ReviewId,
+			"dormitory_id": request.DormitoryId,
+		}).
+		Suffix("RETURNING id, status").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building moderate review query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.ModerateReviewResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.ReviewId, &resp.Status); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: review not found", dberrors.ErrNotFound)
+		}
+
+		if pgErrorCode(err) == dberrors.PGErrCheckViolation {
+			return nil, fmt.Errorf("%w: invalid review status %s", dberrors.ErrBadRequest, request.Status)
+		}
+
+		return nil, fmt.Errorf("%w: error moderating review: %v", dberrors.ErrInternal, err)
+	}
+
+	resolveQuery, resolveArgs, err := psql.Update(reviewReportsTable).
+		Set("resolved_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{
+			"review_id":   request.ReviewId,
+			"resolved_at": nil,
+		}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building resolve review reports query: %v", dberrors.ErrInternal, err)
+	}
+
+	if _, err := driver.ExecContext(ctx, resolveQuery, resolveArgs...); err != nil {
+		return nil, fmt.Errorf("%w: error resolving review reports: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
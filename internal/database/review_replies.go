@@ -0,0 +1,189 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	"github.com/lib/pq"
+)
+
+func (c *Database) CreateReviewReply(
+	ctx context.Context,
+	request *dbtypes.CreateReviewReplyRequest,
+) (*dbtypes.CreateReviewReplyResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.createReviewReply(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) createReviewReply(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.CreateReviewReplyRequest,
+) (*dbtypes.CreateReviewReplyResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		reviewRepliesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewRepliesTableName)
+	)
+
+	queryBuilder := psql.Insert(reviewRepliesTable).
+		Columns("review_id", "author_id", "text").
+		Values(request.ReviewId, request.AuthorId, request.Text).
+		Suffix("RETURNING id, review_id, author_id, text, created_at")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building create review reply query: %v", dberrors.ErrInternal, err)
+	}
+
+	var reply dbtypes.ReviewReply
+
+	err = driver.QueryRowContext(ctx, query, args...).Scan(
+		&reply.ReplyId,
+		&reply.ReviewId,
+		&reply.AuthorId,
+		&reply.Text,
+		&reply.CreatedAt,
+	)
+	if err != nil {
+		if pgErrorCode(err) == dberrors.PGErrForeignKeyViolation {
+			return nil, fmt.Errorf("%w: review not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error creating review reply: %v", dberrors.ErrInternal, err)
+	}
+
+	return &dbtypes.CreateReviewReplyResponse{
+		Reply: reply,
+	}, nil
+}
+
+func (c *Database) DeleteReviewReply(
+	ctx context.Context,
+	request *dbtypes.DeleteReviewReplyRequest,
+) (*dbtypes.DeleteReviewReplyResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.deleteReviewReply(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) deleteReviewReply(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.DeleteReviewReplyRequest,
+) (*dbtypes.DeleteReviewReplyResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		reviewRepliesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewRepliesTableName)
+	)
+
+	queryBuilder := psql.Delete(reviewRepliesTable).
+		Where(squirrel.Eq{
+			"id":        request.ReplyId,
+			"review_id": request.ReviewId,
+		}).
+		Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building delete review reply query: %v", dberrors.ErrInternal, err)
+	}
+
+	var replyId string
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&replyId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: review reply not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error executing delete review reply query: %v", dberrors.ErrInternal, err)
+	}
+
+	return &dbtypes.DeleteReviewReplyResponse{}, nil
+}
+
+// getReviewsReplies загружает ответы сразу для страницы отзывов, от старых к новым
+func (c *Database) getReviewsReplies(
+	ctx context.Context,
+	driver Driver,
+	reviewIds []string,
+) (map[string][]dbtypes.ReviewReply, error) {
+	replies := make(map[string][]dbtypes.ReviewReply, len(reviewIds))
+
+	if len(reviewIds) == 0 {
+		return replies, nil
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		reviewRepliesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewRepliesTableName)
+	)
+
+	query, args, err := psql.
+		Select("id", "review_id", "author_id", "text", "created_at").
+		From(reviewRepliesTable).
+		Where("review_id = ANY(?::uuid[])", pq.Array(reviewIds)).
+		OrderBy("created_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get review replies query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get review replies query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reply dbtypes.ReviewReply
+
+		if err := rows.Scan(
+			&reply.ReplyId,
+			&reply.ReviewId,
+			&reply.AuthorId,
+			&reply.Text,
+			&reply.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		replies[reply.ReviewId] = append(replies[reply.ReviewId], reply)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return replies, nil
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+func (c *Database) VoteReview(
+	ctx context.Context,
+	request *dbtypes.VoteReviewRequest,
+) (*dbtypes.VoteReviewResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var resp *dbtypes.VoteReviewResponse
+
+	err := c.withTx(ctx, func(driver Driver) error {
+		var err error
+
+		resp, err = c.voteReview(ctx, driver, request)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// voteReview сохраняет или снимает голос и пересчитывает счетчики отзыва.
+// Строка отзыва блокируется первой, чтобы параллельные голоса не потеряли друг друга
+func (c *Database) voteReview(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.VoteReviewRequest,
+) (*dbtypes.VoteReviewResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		reviewTable      = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewTableName)
+		reviewVotesTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewVotesTableName)
+	)
+
+	lockQuery, lockArgs, err := psql.
+		Select("id").
+		From(reviewTable).
+		Where(squirrel.Eq{"id": request.ReviewId}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building lock review query: %v", dberrors.ErrInternal, err)
+	}
+
+	var reviewId string
+
+	if err := driver.QueryRowContext(ctx, lockQuery, lockArgs...).Scan(&reviewId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: review not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error locking review: %v", dberrors.ErrInternal, err)
+	}
+
+	var (
+		voteQuery string
+		voteArgs  []any
+	)
+
+	if request.Helpful != nil {
+		voteQuery, voteArgs, err = psql.Insert(reviewVotesTable).
+			Columns("review_id", "user_id", "helpful").
+			Values(request.ReviewId, request.UserId, *request.Helpful).
+			Suffix("ON CONFLICT (review_id, user_id) DO UPDATE SET helpful = EXCLUDED.helpful, updated_at = CURRENT_TIMESTAMP").
+			ToSql()
+	} else {
+		voteQuery, voteArgs, err = psql.Delete(reviewVotesTable).
+			Where(squirrel.Eq{
+				"review_id": request.ReviewId,
+				"user_id":   request.UserId,
+			}).
+			ToSql()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building review vote query: %v", dberrors.ErrInternal, err)
+	}
+
+	if _, err := driver.ExecContext(ctx, voteQuery, voteArgs...); err != nil {
+		return nil, fmt.Errorf("%w: error saving review vote: %v", dberrors.ErrInternal, err)
+	}
+
+	countsQuery, countsArgs, err := psql.Update(reviewTable).
+		Set("helpful_count", squirrel.Expr(
+			fmt.Sprintf("(SELECT COUNT(*) FROM %s v WHERE v.review_id = ? AND v.helpful)", reviewVotesTable),
+			request.ReviewId,
+		)).
+		Set("unhelpful_count", squirrel.Expr(
+			fmt.Sprintf("(SELECT COUNT(*) FROM %s v WHERE v.review_id = ? AND NOT v.helpful)", reviewVotesTable),
+			request.ReviewId,
+		)).
+		Where(squirrel.Eq{"id": request.ReviewId}).
+		Suffix("RETURNING id, helpful_count, unhelpful_count").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building update review votes query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.VoteReviewResponse
+
+	if err := driver.QueryRowContext(ctx, countsQuery, countsArgs...).Scan(
+		&resp.ReviewId,
+		&resp.HelpfulCount,
+		&resp.UnhelpfulCount,
+	); err != nil {
+		return nil, fmt.Errorf("%w: error updating review votes: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,762 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+func (c *Database) GetDormitoryRooms(
+	ctx context.Context,
+	request *dbtypes.GetDormitoryRoomsRequest,
+) (*dbtypes.GetDormitoryRoomsResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getDormitoryRooms(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) getDormitoryRooms(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetDormitoryRoomsRequest,
+) (*dbtypes.GetDormitoryRoomsResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	buildings, err := c.getBuildings(ctx, driver, request.DormitoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	floors, err := c.getFloors(ctx, driver, request.DormitoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := c.getRooms(ctx, driver, request.DormitoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbtypes.GetDormitoryRoomsResponse{
+		Buildings: buildings,
+		Floors:    floors,
+		Rooms:     rooms,
+	}, nil
+}
+
+func (c *Database) getBuildings(
+	ctx context.Context,
+	driver Driver,
+	dormitoryId string,
+) ([]dbtypes.Building, error) {
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		buildingsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.BuildingsTableName)
+	)
+
+	queryBuilder := psql.
+		Select("id", "dormitory_id", "name", "created_at").
+		From(buildingsTable).
+		Where(squirrel.Eq{"dormitory_id": dormitoryId}).
+		OrderBy("name ASC")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get buildings query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get buildings query: %v", dberrors.ErrInternal, err)
+	}
+
+	defer rows.Close()
+
+	var buildings []dbtypes.Building
+
+	for rows.Next() {
+		var building dbtypes.Building
+
+		if err := rows.Scan(
+			&building.Id,
+			&building.DormitoryId,
+			&building.Name,
+			&building.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		buildings = append(buildings, building)
+	}
+
+	return buildings, nil
+}
+
+func (c *Database) getFloors(
+	ctx context.Context,
+	driver Driver,
+	dormitoryId string,
+) ([]dbtypes.Floor, error) {
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		buildingsTable = fmt.Sprintf("%s.%s b", constants.SchemaName, constants.BuildingsTableName)
+		floorsTable    = fmt.Sprintf("%s.%s f", constants.SchemaName, constants.FloorsTableName)
+	)
+
+	queryBuilder := psql.
+		Select("f.id", "f.building_id", "f.number", "f.created_at").
+		From(floorsTable).
+		Join(fmt.Sprintf("%s ON b.id = f.building_id", buildingsTable)).
+		Where(squirrel.Eq{"b.dormitory_id": dormitoryId}).
+		OrderBy("f.number ASC")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get floors query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get floors query: %v", dberrors.ErrInternal, err)
+	}
+
+	defer rows.Close()
+
+	var floors []dbtypes.Floor
+
+	for rows.Next() {
+		var floor dbtypes.Floor
+
+		if err := rows.Scan(
+			&floor.Id,
+			&floor.BuildingId,
+			&floor.Number,
+			&floor.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		floors = append(floors, floor)
+	}
+
+	return floors, nil
+}
+
+func (c *Database) getRooms(
+	ctx context.Context,
+	driver Driver,
+	dormitoryId string,
+) ([]dbtypes.Room, error) {
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		roomsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.RoomsTableName)
+	)
+
+	queryBuilder := psql.
+		Select("id", "floor_id", "number", "type", "capacity", "occupied", "created_at").
+		From(roomsTable).
+		Where(squirrel.Expr("floor_id IN (?)", dormitoryFloorIds(dormitoryId))).
+		OrderBy("number ASC")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get rooms query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get rooms query: %v", dberrors.ErrInternal, err)
+	}
+
+	defer rows.Close()
+
+	var rooms []dbtypes.Room
+
+	for rows.Next() {
+		var room dbtypes.Room
+
+		if err := rows.Scan(
+			&room.Id,
+			&room.FloorId,
+			&room.Number,
+			&room.Type,
+			&room.Capacity,
+			&room.Occupied,
+			&room.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		rooms = append(rooms, room)
+	}
+
+	return rooms, nil
+}
+
+func (c *Database) CreateBuilding(
+	ctx context.Context,
+	request *dbtypes.CreateBuildingRequest,
+) (*dbtypes.CreateBuildingResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.createBuilding(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) createBuilding(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.CreateBuildingRequest,
+) (*dbtypes.CreateBuildingResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		buildingsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.BuildingsTableName)
+	)
+
+	queryBuilder := psql.Insert(buildingsTable).
+		Columns("dormitory_id", "name").
+		Values(request.DormitoryId, request.Name).
+		Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building create building query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.CreateBuildingResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.BuildingId); err != nil {
+		switch pgErrorCode(err) {
+		case dberrors.PGErrUniqueViolation:
+			return nil, fmt.Errorf("%w: building %s already exists", dberrors.ErrConflict, request.Name)
+		case dberrors.PGErrForeignKeyViolation:
+			return nil, fmt.Errorf("%w: dormitory not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error scanning created building: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) DeleteBuilding(
+	ctx context.Context,
+	request *dbtypes.DeleteBuildingRequest,
+) (*dbtypes.DeleteBuildingResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.deleteBuilding(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) deleteBuilding(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.DeleteBuildingRequest,
+) (*dbtypes.DeleteBuildingResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		buildingsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.BuildingsTableName)
+	)
+
+	// floors and rooms are removed by ON DELETE CASCADE
+	queryBuilder := psql.Delete(buildingsTable).
+		Where(squirrel.Eq{
+			"id":           request.BuildingId,
+			"dormitory_id": request.DormitoryId,
+		}).
+		Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building delete building query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.DeleteBuildingResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.BuildingId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: building not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error executing delete building query: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) CreateFloor(
+	ctx context.Context,
+	request *dbtypes.CreateFloorRequest,
+) (*dbtypes.CreateFloorResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.createFloor(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) createFloor(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.CreateFloorRequest,
+) (*dbtypes.CreateFloorResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		buildingsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.BuildingsTableName)
+		floorsTable    = fmt.Sprintf("%s.%s", constants.SchemaName, constants.FloorsTableName)
+	)
+
+	// the building has to belong to the dormitory from the request
+	queryBuilder := psql.Insert(floorsTable).
+		Columns("building_id", "number").
+		Select(
+			psql.Select("id").
+				Column("?::integer", request.Number).
+				From(buildingsTable).
+				Where(squirrel.Eq{
+					"id":           request.BuildingId,
+					"dormitory_id": request.DormitoryId,
+				}),
+		).
+		Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building create floor query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.CreateFloorResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.FloorId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: building not found", dberrors.ErrNotFound)
+		}
+
+		if pgErrorCode(err) == dberrors.PGErrUniqueViolation {
+			return nil, fmt.Errorf("%w: floor %d already exists", dberrors.ErrConflict, request.Number)
+		}
+
+		return nil, fmt.Errorf("%w: error scanning created floor: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) DeleteFloor(
+	ctx context.Context,
+	request *dbtypes.DeleteFloorRequest,
+) (*dbtypes.DeleteFloorResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.deleteFloor(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) deleteFloor(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.DeleteFloorRequest,
+) (*dbtypes.DeleteFloorResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		floorsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.FloorsTableName)
+	)
+
+	queryBuilder := psql.Delete(floorsTable).
+		Where(squirrel.Eq{"id": request.FloorId}).
+		Where(squirrel.Expr("id IN (?)", dormitoryFloorIds(request.DormitoryId))).
+		Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building delete floor query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.DeleteFloorResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.FloorId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: floor not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error executing delete floor query: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) CreateRoom(
+	ctx context.Context,
+	request *dbtypes.CreateRoomRequest,
+) (*dbtypes.CreateRoomResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.createRoom(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) createRoom(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.CreateRoomRequest,
+) (*dbtypes.CreateRoomResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		floorsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.FloorsTableName)
+		roomsTable  = fmt.Sprintf("%s.%s", constants.SchemaName, constants.RoomsTableName)
+	)
+
+	// the floor has to belong to the dormitory from the request
+	queryBuilder := psql.Insert(roomsTable).
+		Columns("floor_id", "number", "type", "capacity", "occupied").
+		Select(
+			psql.Select("id").
+				Column("?::varchar", request.Number).
+				Column("?::varchar", request.Type).
+				Column("?::integer", request.Capacity).
+				Column("?::integer", request.Occupied).
+				From(floorsTable).
+				Where(squirrel.Eq{"id": request.FloorId}).
+				Where(squirrel.Expr("id IN (?)", dormitoryFloorIds(request.DormitoryId))),
+		).
+		Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building create room query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.CreateRoomResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.RoomId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: floor not found", dberrors.ErrNotFound)
+		}
+
+		switch pgErrorCode(err) {
+		case dberrors.PGErrUniqueViolation:
+			return nil, fmt.Errorf("%w: room %s already exists", dberrors.ErrConflict, request.Number)
+		case dberrors.PGErrCheckViolation:
+			return nil, fmt.Errorf("%w: invalid room capacity or occupancy", dberrors.ErrBadRequest)
+		}
+
+		return nil, fmt.Errorf("%w: error scanning created room: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) UpdateRoom(
+	ctx context.Context,
+	request *dbtypes.UpdateRoomRequest,
+) (*dbtypes.UpdateRoomResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.updateRoom(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) updateRoom(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.UpdateRoomRequest,
+) (*dbtypes.UpdateRoomResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		roomsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.RoomsTableName)
+	)
+
+	queryBuilder := psql.Update(roomsTable).
+		Where(squirrel.Eq{"id": request.RoomId}).
+		Where(squirrel.Expr("floor_id IN (?)", dormitoryFloorIds(request.DormitoryId)))
+
+	if !setupRoomUpdateFields(&queryBuilder, request) {
+		return nil, fmt.Errorf("%w: nothing to update", dberrors.ErrBadRequest)
+	}
+
+	queryBuilder = queryBuilder.Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building update room query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.UpdateRoomResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.RoomId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: room not found", dberrors.ErrNotFound)
+		}
+
+		switch pgErrorCode(err) {
+		case dberrors.PGErrUniqueViolation:
+			return nil, fmt.Errorf("%w: room with this number already exists", dberrors.ErrConflict)
+		case dberrors.PGErrCheckViolation:
+			return nil, fmt.Errorf("%w: invalid room capacity or occupancy", dberrors.ErrBadRequest)
+		}
+
+		return nil, fmt.Errorf("%w: error scanning updated room: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func setupRoomUpdateFields(
+	queryBuilder *squirrel.UpdateBuilder,
+	request *dbtypes.UpdateRoomRequest,
+) bool {
+	updated := false
+
+	if request.Number != nil {
+		*queryBuilder = queryBuilder.Set("number", request.Number)
+		updated = true
+	}
+
+	if request.Type != nil {
+		*queryBuilder = queryBuilder.Set("type", request.Type)
+		updated = true
+	}
+
+	if request.Capacity != nil {
+		*queryBuilder = queryBuilder.Set("capacity", request.Capacity)
+		updated = true
+	}
+
+	if request.Occupied != nil {
+		*queryBuilder = queryBuilder.Set("occupied", request.Occupied)
+		updated = true
+	}
+
+	return updated
+}
+
+func (c *Database) DeleteRoom(
+	ctx context.Context,
+	request *dbtypes.DeleteRoomRequest,
+) (*dbtypes.DeleteRoomResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.deleteRoom(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) deleteRoom(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.DeleteRoomRequest,
+) (*dbtypes.DeleteRoomResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		roomsTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.RoomsTableName)
+	)
+
+	queryBuilder := psql.Delete(roomsTable).
+		Where(squirrel.Eq{"id": request.RoomId}).
+		Where(squirrel.Expr("floor_id IN (?)", dormitoryFloorIds(request.DormitoryId))).
+		Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building delete room query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.DeleteRoomResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.RoomId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: room not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error executing delete room query: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) GetDormitoryOccupancy(
+	ctx context.Context,
+	request *dbtypes.GetDormitoryOccupancyRequest,
+) (*dbtypes.GetDormitoryOccupancyResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getDormitoryOccupancy(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// getDormitoryOccupancy возвращает заполненность общежития в разрезе корпусов и типов комнат
+func (c *Database) getDormitoryOccupancy(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetDormitoryOccupancyRequest,
+) (*dbtypes.GetDormitoryOccupancyResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		buildingsTable = fmt.Sprintf("%s.%s b", constants.SchemaName, constants.BuildingsTableName)
+		floorsTable    = fmt.Sprintf("%s.%s f", constants.SchemaName, constants.FloorsTableName)
+		roomsTable     = fmt.Sprintf("%s.%s r", constants.SchemaName, constants.RoomsTableName)
+	)
+
+	queryBuilder := psql.
+		Select("b.id", "b.name", "r.type", "COUNT(*)", "SUM(r.capacity)", "SUM(r.occupied)").
+		From(roomsTable).
+		Join(fmt.Sprintf("%s ON f.id = r.floor_id", floorsTable)).
+		Join(fmt.Sprintf("%s ON b.id = f.building_id", buildingsTable)).
+		Where(squirrel.Eq{"b.dormitory_id": request.DormitoryId}).
+		GroupBy("b.id", "b.name", "r.type").
+		OrderBy("b.name ASC", "r.type ASC")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get occupancy query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get occupancy query: %v", dberrors.ErrInternal, err)
+	}
+
+	defer rows.Close()
+
+	var buildings []dbtypes.BuildingOccupancy
+
+	for rows.Next() {
+		var building dbtypes.BuildingOccupancy
+
+		if err := rows.Scan(
+			&building.BuildingId,
+			&building.BuildingName,
+			&building.RoomType,
+			&building.Rooms,
+			&building.Capacity,
+			&building.Occupied,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		buildings = append(buildings, building)
+	}
+
+	return &dbtypes.GetDormitoryOccupancyResponse{
+		Buildings: buildings,
+	}, nil
+}
+
+// dormitoryFloorIds - подзапрос id этажей общежития, чтобы не дать изменить чужие комнаты.
+// Подзапросы строятся без PlaceholderFormat: их ? нумерует внешний запрос
+func dormitoryFloorIds(dormitoryId string) squirrel.SelectBuilder {
+	var (
+		buildingsTable = fmt.Sprintf("%s.%s b", constants.SchemaName, constants.BuildingsTableName)
+		floorsTable    = fmt.Sprintf("%s.%s f", constants.SchemaName, constants.FloorsTableName)
+	)
+
+	return squirrel.
+		Select("f.id").
+		From(floorsTable).
+		Join(fmt.Sprintf("%s ON b.id = f.building_id", buildingsTable)).
+		Where(squirrel.Eq{"b.dormitory_id": dormitoryId})
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dormitory-life/core/internal/constants"
+	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+var (
+	searchTitleHeadlineOptions = fmt.Sprintf(
+		"StartSel=%s, StopSel=%s, HighlightAll=true",
+		constants.SearchHighlightStart, constants.SearchHighlightStop,
+	)
+	searchSnippetHeadlineOptions = fmt.Sprintf(
+		`StartSel=%s, StopSel=%s, MaxFragments=2, MinWords=5, MaxWords=25, FragmentDelimiter=" … "`,
+		constants.SearchHighlightStart, constants.SearchHighlightStop,
+	)
+)
+
+// searchSource описывает таблицу, по которой ищем: колонки заголовка, текста и автора
+type searchSource struct {
+	table        string
+	titleColumn  string
+	textColumn   string
+	authorColumn string
+	condition    squirrel.Sqlizer
+}
+
+func (c *Database) SearchDormitory(
+	ctx context.Context,
+	request *dbtypes.SearchDormitoryRequest,
+) (*dbtypes.SearchDormitoryResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.searchDormitory(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) searchDormitory(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.SearchDormitoryRequest,
+) (*dbtypes.SearchDormitoryResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var reviewsCondition squirrel.Sqlizer = squirrel.Eq{"dormitory_id": request.DormitoryId}
+	if !request.IncludeHidden {
+		reviewsCondition = squirrel.And{reviewsCondition, visibleReviewsCondition(request.ViewerId)}
+	}
+
+	reviews, err := c.searchHits(ctx, driver, request, searchSource{
+		table:        fmt.Sprintf("%s.%s", constants.SchemaName, constants.ReviewTableName),
+		titleColumn:  "title",
+		textColumn:   "description",
+		authorColumn: "owner_id::text",
+		condition:    reviewsCondition,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := c.searchHits(ctx, driver, request, searchSource{
+		table:        fmt.Sprintf("%s.%s", constants.SchemaName, constants.FeedTableName),
+		titleColumn:  "title",
+		textColumn:   "description",
+		authorColumn: "NULL::text",
+		condition:    squirrel.Eq{"dormitory_id": request.DormitoryId},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chatMessages, err := c.searchHits(ctx, driver, request, searchSource{
+		table:        fmt.Sprintf("%s.%s", constants.SchemaName, constants.ChatTableName),
+		titleColumn:  "''",
+		textColumn:   "text",
+		authorColumn: "user_id::text",
+		condition:    squirrel.Eq{"dormitory_id": request.DormitoryId},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbtypes.SearchDormitoryResponse{
+		Reviews:      reviews,
+		Events:       events,
+		ChatMessages: chatMessages,
+	}, nil
+}
+
+// searchHits ищет по GIN индексу search_vector и возвращает лучшие совпадения
+// с подсвеченными заголовком и фрагментами текста
+func (c *Database) searchHits(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.SearchDormitoryRequest,
+	source searchSource,
+) ([]dbtypes.SearchHit, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	queryBuilder := psql.
+		Select("id").
+		Column(source.authorColumn).
+		Column(squirrel.Expr(
+			fmt.Sprintf("ts_headline(?::regconfig, %s, q, ?)", source.titleColumn),
+			constants.SearchConfig, searchTitleHeadlineOptions,
+		)).
+		Column(squirrel.Expr(
+			fmt.Sprintf("ts_headline(?::regconfig, %s, q, ?)", source.textColumn),
+			constants.SearchConfig, searchSnippetHeadlineOptions,
+		)).
+		Column("ts_rank(search_vector, q) AS rank").
+		Column("created_at").
+		From(source.table).
+		CrossJoin("websearch_to_tsquery(?::regconfig, ?) AS q", constants.SearchConfig, request.Query).
+		Where(source.condition).
+		Where("search_vector @@ q").
+		OrderBy("rank DESC", "created_at DESC").
+		Limit(request.Limit)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building search query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing search query: %v", dberrors.ErrInternal, err)
+	}
+	defer rows.Close()
+
+	hits := make([]dbtypes.SearchHit, 0)
+
+	for rows.Next() {
+		var hit dbtypes.SearchHit
+
+		if err := rows.Scan(
+			&hit.Id,
+			&hit.AuthorId,
+			&hit.Title,
+			&hit.Snippet,
+			&hit.Rank,
+			&hit.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		hits = append(hits, hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return hits, nil
+}
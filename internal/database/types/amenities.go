@@ -0,0 +1,81 @@
+package types
+
+import "time"
+
+type Amenity struct {
+	Id        string
+	Code      string
+	Name      string
+	CreatedAt time.Time
+}
+
+type (
+	GetAmenitiesRequest  struct{}
+	GetAmenitiesResponse struct {
+		Amenities []Amenity
+	}
+)
+
+type (
+	CreateAmenityRequest struct {
+		Code string
+		Name string
+	}
+
+	CreateAmenityResponse struct {
+		AmenityId string
+	}
+)
+
+type (
+	UpdateAmenityRequest struct {
+		AmenityId string
+		Code      *string
+		Name      *string
+	}
+
+	UpdateAmenityResponse struct {
+		AmenityId string
+	}
+)
+
+type (
+	DeleteAmenityRequest struct {
+		AmenityId string
+	}
+
+	DeleteAmenityResponse struct {
+		AmenityId string
+	}
+)
+
+type (
+	GetAmenityDormitoriesRequest struct {
+		AmenityId string
+	}
+
+	GetAmenityDormitoriesResponse struct {
+		DormitoryIds []string
+	}
+)
+
+type (
+	GetDormitoryAmenitiesRequest struct {
+		DormitoryId string
+	}
+
+	GetDormitoryAmenitiesResponse struct {
+		Amenities []Amenity
+	}
+)
+
+type (
+	SetDormitoryAmenitiesRequest struct {
+		DormitoryId  string
+		AmenityCodes []string
+	}
+
+	SetDormitoryAmenitiesResponse struct {
+		DormitoryId string
+	}
+)
@@ -1,17 +1,37 @@
 package types
 
 type Dormitory struct {
-	Id           string
-	Name         string
-	Address      string
-	SupportEmail string
-	Description  string
+	Id             string
+	Name           string
+	Address        string
+	SupportEmail   string
+	Description    string
+	Latitude       *float64
+	Longitude      *float64
+	OverallAverage float64
 }
 
+type DormitorySortField = string
+
+const (
+	DormitorySortByName   DormitorySortField = "name"
+	DormitorySortByRating DormitorySortField = "rating"
+)
+
 type (
-	GetDormitoriesRequest  struct{}
+	GetDormitoriesRequest struct {
+		Search    string
+		MinRating *float64
+		Amenities []string
+		SortBy    DormitorySortField
+		SortDesc  bool
+		Page      uint64
+		PageSize  uint64
+	}
+
 	GetDormitoriesResponse struct {
 		Dormitories []Dormitory
+		Total       uint64
 	}
 )
 
@@ -32,6 +52,8 @@ type (
 		Address      string
 		SupportEmail string
 		Description  string
+		Latitude     *float64
+		Longitude    *float64
 	}
 
 	CreateDormitoryResponse struct {
@@ -46,9 +68,39 @@ type (
 		Address      *string
 		SupportEmail *string
 		Description  *string
+		Latitude     *float64
+		Longitude    *float64
 	}
 
 	UpdateDormitoryResponse struct {
 		DormitoryId string
 	}
 )
+
+type (
+	DeleteDormitoryRequest struct {
+		DormitoryId string
+	}
+
+	DeleteDormitoryResponse struct {
+		DormitoryId string
+	}
+)
+
+type NearbyDormitory struct {
+	Dormitory  Dormitory
+	DistanceKm float64
+}
+
+type (
+	GetNearbyDormitoriesRequest struct {
+		Latitude  float64
+		Longitude float64
+		RadiusKm  float64
+		Limit     uint64
+	}
+
+	GetNearbyDormitoriesResponse struct {
+		Dormitories []NearbyDormitory
+	}
+)
@@ -0,0 +1,60 @@
+package types
+
+import "time"
+
+type RsvpStatus = string
+
+const (
+	RsvpStatusGoing      RsvpStatus = "going"
+	RsvpStatusMaybe      RsvpStatus = "maybe"
+	RsvpStatusNotGoing   RsvpStatus = "not_going"
+	RsvpStatusWaitlisted RsvpStatus = "waitlisted"
+)
+
+type EventRsvpCounts struct {
+	Going      int
+	Maybe      int
+	NotGoing   int
+	Waitlisted int
+}
+
+type EventAttendee struct {
+	UserId    string
+	Email     string
+	Status    RsvpStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type (
+	// RsvpEventRequest - Status nil снимает ответ пользователя
+	RsvpEventRequest struct {
+		DormitoryId string
+		EventId     string
+		UserId      string
+		Status      *RsvpStatus
+	}
+
+	RsvpEventResponse struct {
+		EventId string
+		// Status - итоговый статус пользователя, пусто, если ответ снят
+		Status RsvpStatus
+		Counts EventRsvpCounts
+		// PromotedUserIds - пользователи, перешедшие из листа ожидания в участники
+		PromotedUserIds []string
+	}
+)
+
+type (
+	GetEventAttendeesRequest struct {
+		DormitoryId string
+		EventId     string
+		Status      RsvpStatus
+	}
+
+	GetEventAttendeesResponse struct {
+		Capacity  *int
+		Counts    EventRsvpCounts
+		Attendees []EventAttendee
+	}
+)
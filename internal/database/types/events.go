@@ -2,18 +2,96 @@ package types
 
 import "time"
 
+type EventCategory = string
+
+const (
+	EventCategoryMeeting   EventCategory = "meeting"
+	EventCategoryCleaning  EventCategory = "cleaning"
+	EventCategorySport     EventCategory = "sport"
+	EventCategoryCulture   EventCategory = "culture"
+	EventCategoryEducation EventCategory = "education"
+	EventCategoryParty     EventCategory = "party"
+	EventCategoryOther     EventCategory = "other"
+)
+
+// EventsPeriod - какие события вернуть относительно текущего момента
+type EventsPeriod = string
+
+const (
+	EventsPeriodAll      EventsPeriod = ""
+	EventsPeriodUpcoming EventsPeriod = "upcoming"
+	EventsPeriodPast     EventsPeriod = "past"
+)
+
+type RecurrenceFreq = string
+
+const (
+	RecurrenceFreqDaily   RecurrenceFreq = "daily"
+	RecurrenceFreqWeekly  RecurrenceFreq = "weekly"
+	RecurrenceFreqMonthly RecurrenceFreq = "monthly"
+)
+
+// Recurrence - правило повторения серии. Until и Count взаимоисключающие,
+// ExDates - исходные начала повторений, которых не будет
+type Recurrence struct {
+	Freq     RecurrenceFreq
+	Interval int
+	Until    *time.Time
+	Count    *int
+	ExDates  []time.Time
+}
+
+// EventOccurrence - изменение одного повторения серии. nil поля берутся из серии
+type EventOccurrence struct {
+	EventId         string
+	OccurrenceStart time.Time
+	Cancelled       bool
+	Title           *string
+	Description     *string
+	StartsAt        *time.Time
+	EndsAt          *time.Time
+	Location        *string
+	UpdatedAt       time.Time
+}
+
 type Event struct {
 	EventId     string
 	DormitoryId string
 	Title       string
 	Description string
 	CreatedAt   time.Time
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	RoomId      *string
+	Location    string
+	Category    EventCategory
+	Capacity    *int
+	RsvpCounts  EventRsvpCounts
+	// ViewerRsvp - ответ пользователя, который запросил ленту
+	ViewerRsvp RsvpStatus
+	Recurrence *Recurrence
+	// OccurrenceStart - исходное начало повторения, если событие развернуто из серии
+	OccurrenceStart *time.Time
+	Cancelled       bool
+	// PinnedAt - время закрепления поста, nil - пост не закреплен
+	PinnedAt  *time.Time
+	ExpiresAt *time.Time
+	Urgent    bool
+	// UpdatedAt - время последнего изменения поста, включая его фото
+	UpdatedAt time.Time
 }
 
 type (
 	GetDormitoryEventsRequest struct {
 		DormitoryId string
 		Page        uint64
+		ViewerId    string
+		Period      EventsPeriod
+		// From и To оставляют события, которые идут в интервале [From, To)
+		From *time.Time
+		To   *time.Time
+		// IncludeExpired - вернуть и посты, срок показа которых истек
+		IncludeExpired bool
 	}
 
 	GetDormitoryEventsResponse struct {
@@ -26,6 +104,16 @@ type (
 		DormitoryId string
 		Title       string
 		Description string
+		StartsAt    *time.Time
+		EndsAt      *time.Time
+		RoomId      *string
+		Location    string
+		Category    EventCategory
+		Capacity    *int
+		Recurrence  *Recurrence
+		Pinned      bool
+		ExpiresAt   *time.Time
+		Urgent      bool
 	}
 
 	CreateDormitoryEventResponse struct {
@@ -36,6 +124,32 @@ type (
 	}
 )
 
+type (
+	// UpdateDormitoryEventRequest меняет только переданные поля.
+	// Clear* поля обнуляют необязательные значения
+	UpdateDormitoryEventRequest struct {
+		DormitoryId   string
+		EventId       string
+		Title         *string
+		Description   *string
+		StartsAt      *time.Time
+		EndsAt        *time.Time
+		ClearEndsAt   bool
+		RoomId        *string
+		ClearRoomId   bool
+		Location      *string
+		Category      *EventCategory
+		Capacity      *int
+		ClearCapacity bool
+	}
+
+	UpdateDormitoryEventResponse struct {
+		Event Event
+		// PromotedUserIds - участники из листа ожидания, получившие место после смены вместимости
+		PromotedUserIds []string
+	}
+)
+
 type (
 	DeleteDormitoryEventRequest struct {
 		EventId string
@@ -44,3 +158,79 @@ type (
 	DeleteDormitoryEventResponse struct {
 	}
 )
+
+// CalendarEvent - событие для экспорта в календарь вместе с номером комнаты
+type CalendarEvent struct {
+	Event
+	RoomNumber *string
+	// Occurrences - измененные и отмененные повторения серии
+	Occurrences []EventOccurrence
+}
+
+type (
+	GetEventsCalendarRequest struct {
+		DormitoryId string
+		// Since - события, закончившиеся раньше, в календарь не попадают
+		Since time.Time
+		Limit uint64
+	}
+
+	GetEventsCalendarResponse struct {
+		Events []CalendarEvent
+	}
+)
+
+type (
+	// GetEventsSyndicationRequest - последние посты общежития для лент RSS и Atom
+	GetEventsSyndicationRequest struct {
+		DormitoryId string
+		Limit       uint64
+	}
+
+	GetEventsSyndicationResponse struct {
+		Events []Event
+	}
+)
+
+type (
+	// UpdateEventOccurrenceRequest меняет только переданные поля повторения
+	UpdateEventOccurrenceRequest struct {
+		DormitoryId     string
+		EventId         string
+		OccurrenceStart time.Time
+		Title           *string
+		Description     *string
+		StartsAt        *time.Time
+		EndsAt          *time.Time
+		Location        *string
+		Cancelled       *bool
+	}
+
+	UpdateEventOccurrenceResponse struct {
+		Occurrence EventOccurrence
+	}
+)
+
+type (
+	// UpdateEventAnnouncementRequest меняет только переданные поля.
+	// ClearExpiresAt снимает срок показа, ExpiresAt при этом игнорируется
+	UpdateEventAnnouncementRequest struct {
+		DormitoryId    string
+		EventId        string
+		Pinned         *bool
+		ExpiresAt      *time.Time
+		ClearExpiresAt bool
+		Urgent         *bool
+	}
+
+	UpdateEventAnnouncementResponse struct {
+		EventId     string
+		Title       string
+		Description string
+		PinnedAt    *time.Time
+		ExpiresAt   *time.Time
+		Urgent      bool
+		// BecameUrgent - пост стал срочным этим запросом, жильцов нужно оповестить
+		BecameUrgent bool
+	}
+)
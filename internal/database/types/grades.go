@@ -2,26 +2,70 @@ package types
 
 import "time"
 
+type GradeCriterion struct {
+	Code      string
+	Labels    map[string]string
+	Weight    float64
+	Position  int
+	Active    bool
+	CreatedAt time.Time
+}
+
+type (
+	GetGradeCriteriaRequest struct {
+		ActiveOnly bool
+	}
+
+	GetGradeCriteriaResponse struct {
+		Criteria []GradeCriterion
+	}
+)
+
+type (
+	CreateGradeCriterionRequest struct {
+		Code     string
+		Labels   map[string]string
+		Weight   float64
+		Position int
+	}
+
+	CreateGradeCriterionResponse struct {
+		Code string
+	}
+)
+
+type (
+	UpdateGradeCriterionRequest struct {
+		Code     string
+		Labels   map[string]string
+		Weight   *float64
+		Position *int
+		Active   *bool
+	}
+
+	UpdateGradeCriterionResponse struct {
+		Code string
+	}
+)
+
+type GradeStatus = string
+
+const (
+	GradeStatusApproved GradeStatus = "approved"
+	GradeStatusFlagged  GradeStatus = "flagged"
+	GradeStatusRejected GradeStatus = "rejected"
+)
+
 type Grade struct {
 	Id          string
 	DormitoryId string
 	UserId      string
 
-	BathroomCleanliness       int
-	CorridorCleanliness       int
-	KitchenCleanliness        int
-	CleaningFrequency         int
-	RoomSpaciousness          int
-	CorridorSpaciousness      int
-	KitchenSpaciousness       int
-	ShowerLocationConvenience int
-	EquipmentMaintenance      int
-	WindowCondition           int
-	NoiseIsolation            int
-	CommonAreasEquipment      int
-	TransportAccessibility    int
-	AdministrationQuality     int
-	ResidentsCultureLevel     int
+	// Scores - оценки 1-5 по кодам критериев
+	Scores map[string]int
+
+	Status      GradeStatus
+	FlagReasons []string
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -31,52 +75,57 @@ type (
 	CreateDormitoryGradeRequest struct {
 		DormitoryId string
 		UserId      string
-
-		BathroomCleanliness       int
-		CorridorCleanliness       int
-		KitchenCleanliness        int
-		CleaningFrequency         int
-		RoomSpaciousness          int
-		CorridorSpaciousness      int
-		KitchenSpaciousness       int
-		ShowerLocationConvenience int
-		EquipmentMaintenance      int
-		WindowCondition           int
-		NoiseIsolation            int
-		CommonAreasEquipment      int
-		TransportAccessibility    int
-		AdministrationQuality     int
-		ResidentsCultureLevel     int
+		Scores      map[string]int
+		// FlagReasons - причины подозрения, с ними оценка не учитывается в средних до проверки
+		FlagReasons []string
 	}
 
 	CreateDormitoryGradeResponse struct {
 		GradeId string
+		Status  GradeStatus
+	}
+)
+
+type (
+	UpdateDormitoryGradeRequest struct {
+		DormitoryId string
+		UserId      string
+		Scores      map[string]int
+		FlagReasons []string
+	}
+
+	UpdateDormitoryGradeResponse struct {
+		GradeId string
+		Status  GradeStatus
 	}
 )
 
+type (
+	DeleteDormitoryGradeRequest struct {
+		DormitoryId string
+		UserId      string
+	}
+
+	DeleteDormitoryGradeResponse struct {
+		GradeId string
+	}
+)
+
+type CriterionAverage struct {
+	Code         string
+	Average      float64
+	TotalRatings int
+}
+
 type AvgGrade struct {
-	Id                           string
-	DormitoryId                  string
-	PeriodDate                   time.Time
-	AvgBathroomCleanliness       float64
-	AvgCorridorCleanliness       float64
-	AvgKitchenCleanliness        float64
-	AvgCleaningFrequency         float64
-	AvgRoomSpaciousness          float64
-	AvgCorridorSpaciousness      float64
-	AvgKitchenSpaciousness       float64
-	AvgShowerLocationConvenience float64
-	AvgEquipmentMaintenance      float64
-	AvgWindowCondition           float64
-	AvgNoiseIsolation            float64
-	AvgCommonAreasEquipment      float64
-	AvgTransportAccessibility    float64
-	AvgAdministrationQuality     float64
-	AvgResidentsCultureLevel     float64
-	OverallAverage               float64
-	TotalRatings                 int
-	CreatedAt                    time.Time
-	UpdatedAt                    time.Time
+	Id             string
+	DormitoryId    string
+	PeriodDate     time.Time
+	Criteria       []CriterionAverage
+	OverallAverage float64
+	TotalRatings   int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 type (
@@ -89,8 +138,118 @@ type (
 type (
 	GetDormitoryAvgGradesRequest struct {
 		DormitoryId string
+		From        *time.Time
+		To          *time.Time
 	}
 	GetDormitoryAvgGradesResponse struct {
 		Grades []AvgGrade
 	}
 )
+
+type GradeScoreCount struct {
+	Criterion string
+	Score     int
+	Count     int
+}
+
+type (
+	GetGradesDistributionRequest struct {
+		DormitoryId string
+		Period      *time.Time
+	}
+
+	GetGradesDistributionResponse struct {
+		TotalRatings int
+		Counts       []GradeScoreCount
+	}
+)
+
+type DormitoryPeriodScores struct {
+	DormitoryId  string
+	Name         string
+	TotalRatings int
+	Criteria     []CriterionAverage
+}
+
+type (
+	// GetDormitoriesPeriodScoresRequest - период [From, To)
+	GetDormitoriesPeriodScoresRequest struct {
+		From time.Time
+		To   time.Time
+	}
+
+	GetDormitoriesPeriodScoresResponse struct {
+		Dormitories []DormitoryPeriodScores
+	}
+)
+
+type (
+	// GetGradeSignalsRequest - активность считается за [ActivitySince, now),
+	// базовый уровень - за [BaselineSince, ActivitySince)
+	GetGradeSignalsRequest struct {
+		DormitoryId   string
+		UserId        string
+		ActivitySince time.Time
+		BaselineSince time.Time
+	}
+
+	GetGradeSignalsResponse struct {
+		AccountCreatedAt time.Time
+		RecentGrades     int
+		BaselineGrades   int
+	}
+)
+
+type (
+	GetModerationGradesRequest struct {
+		DormitoryId string
+		Status      GradeStatus
+		Page        uint64
+	}
+
+	GetModerationGradesResponse struct {
+		Grades []Grade
+		Total  int
+	}
+)
+
+type (
+	ModerateGradeRequest struct {
+		DormitoryId string
+		GradeId     string
+		Status      GradeStatus
+		ReviewerId  string
+	}
+
+	ModerateGradeResponse struct {
+		GradeId   string
+		Status    GradeStatus
+		CreatedAt time.Time
+	}
+)
+
+// AvgGradeChange - изменение одного значения средних при пересчете, nil - значения не было
+type AvgGradeChange struct {
+	DormitoryId string
+	PeriodDate  time.Time
+	// Field - overall_average, total_ratings, код критерия или код критерия с суффиксом .total_ratings
+	Field  string
+	Before *float64
+	After  *float64
+}
+
+type (
+	// RecomputeAvgGradesRequest - пустые поля снимают соответствующее ограничение,
+	// From и To - первые числа месяцев, обе границы включаются
+	RecomputeAvgGradesRequest struct {
+		DormitoryId string
+		From        *time.Time
+		To          *time.Time
+		DryRun      bool
+	}
+
+	RecomputeAvgGradesResponse struct {
+		Periods int
+		Changes []AvgGradeChange
+	}
+)
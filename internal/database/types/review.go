@@ -2,19 +2,72 @@ package types
 
 import "time"
 
+type ReviewStatus = string
+
+const (
+	ReviewStatusPublished ReviewStatus = "published"
+	ReviewStatusHidden    ReviewStatus = "hidden"
+	// ReviewStatusPending - на отзыв пожаловались, ждет решения администратора
+	ReviewStatusPending ReviewStatus = "pending"
+)
+
+type ReviewSort = string
+
+const (
+	ReviewSortNewest        ReviewSort = "newest"
+	ReviewSortOldest        ReviewSort = "oldest"
+	ReviewSortMostHelpful   ReviewSort = "most_helpful"
+	ReviewSortControversial ReviewSort = "controversial"
+)
+
 type Review struct {
 	ReviewId    string
 	OwnerId     string
 	DormitoryId string
 	Title       string
 	Description string
+	Status      ReviewStatus
 	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+	Replies     []ReviewReply
+
+	HelpfulCount   int
+	UnhelpfulCount int
+
+	// AuthorGrade - оценка автора за месяц отзыва, nil если автор не оценивал общежитие
+	AuthorGrade *ReviewAuthorGrade
+}
+
+type ReviewAuthorGrade struct {
+	GradeId      string
+	Overall      float64
+	LowestCode   string
+	LowestScore  int
+	HighestCode  string
+	HighestScore int
+}
+
+type ReviewReply struct {
+	ReplyId   string
+	ReviewId  string
+	AuthorId  *string
+	Text      string
+	CreatedAt time.Time
 }
 
 type (
 	GetDormitoryReviewsRequest struct {
 		DormitoryId string
 		Page        uint64
+		Sort        ReviewSort
+
+		// MinGrade и MaxGrade оставляют отзывы, автор которых поставил общий балл в диапазоне
+		MinGrade *float64
+		MaxGrade *float64
+
+		// ViewerId видит свои скрытые отзывы, IncludeHidden - все скрытые
+		ViewerId      string
+		IncludeHidden bool
 	}
 
 	GetDormitoryReviewsResponse struct {
@@ -53,3 +106,135 @@ type (
 		Review Review
 	}
 )
+
+type (
+	UpdateReviewRequest struct {
+		ReviewId      string
+		EditorId      string
+		Title         *string
+		Description   *string
+		AddedPhotos   []string
+		RemovedPhotos []string
+	}
+
+	UpdateReviewResponse struct {
+		Review Review
+	}
+)
+
+type ReviewEdit struct {
+	EditId         string
+	ReviewId       string
+	EditorId       *string
+	OldTitle       string
+	NewTitle       string
+	OldDescription string
+	NewDescription string
+	AddedPhotos    []string
+	RemovedPhotos  []string
+	EditedAt       time.Time
+}
+
+type (
+	GetReviewEditsRequest struct {
+		ReviewId string
+	}
+
+	GetReviewEditsResponse struct {
+		Edits []ReviewEdit
+	}
+)
+
+type ReviewReport struct {
+	ReportId   string
+	ReviewId   string
+	ReporterId string
+	Reason     string
+	Comment    string
+	CreatedAt  time.Time
+	ResolvedAt *time.Time
+}
+
+type (
+	CreateReviewReportRequest struct {
+		DormitoryId string
+		ReviewId    string
+		ReporterId  string
+		Reason      string
+		Comment     string
+	}
+
+	CreateReviewReportResponse struct {
+		ReportId     string
+		ReviewStatus ReviewStatus
+	}
+)
+
+type ModerationReview struct {
+	Review
+	Reports []ReviewReport
+}
+
+type (
+	GetModerationReviewsRequest struct {
+		DormitoryId string
+		Status      ReviewStatus
+		Page        uint64
+	}
+
+	GetModerationReviewsResponse struct {
+		Reviews []ModerationReview
+		Total   int
+	}
+)
+
+type (
+	ModerateReviewRequest struct {
+		DormitoryId string
+		ReviewId    string
+		Status      ReviewStatus
+		ModeratorId string
+	}
+
+	ModerateReviewResponse struct {
+		ReviewId string
+		Status   ReviewStatus
+	}
+)
+
+type (
+	CreateReviewReplyRequest struct {
+		ReviewId string
+		AuthorId string
+		Text     string
+	}
+
+	CreateReviewReplyResponse struct {
+		Reply ReviewReply
+	}
+)
+
+type (
+	DeleteReviewReplyRequest struct {
+		ReviewId string
+		ReplyId  string
+	}
+
+	DeleteReviewReplyResponse struct {
+	}
+)
+
+type (
+	// VoteReviewRequest - Helpful nil снимает голос пользователя
+	VoteReviewRequest struct {
+		ReviewId string
+		UserId   string
+		Helpful  *bool
+	}
+
+	VoteReviewResponse struct {
+		ReviewId       string
+		HelpfulCount   int
+		UnhelpfulCount int
+	}
+)
@@ -0,0 +1,155 @@
+package types
+
+import "time"
+
+type RoomType = string
+
+const (
+	RoomTypeCorridor RoomType = "corridor"
+	RoomTypeBlock    RoomType = "block"
+)
+
+type Building struct {
+	Id          string
+	DormitoryId string
+	Name        string
+	CreatedAt   time.Time
+}
+
+type Floor struct {
+	Id         string
+	BuildingId string
+	Number     int
+	CreatedAt  time.Time
+}
+
+type Room struct {
+	Id        string
+	FloorId   string
+	Number    string
+	Type      RoomType
+	Capacity  int
+	Occupied  int
+	CreatedAt time.Time
+}
+
+type (
+	GetDormitoryRoomsRequest struct {
+		DormitoryId string
+	}
+
+	GetDormitoryRoomsResponse struct {
+		Buildings []Building
+		Floors    []Floor
+		Rooms     []Room
+	}
+)
+
+type (
+	CreateBuildingRequest struct {
+		DormitoryId string
+		Name        string
+	}
+
+	CreateBuildingResponse struct {
+		BuildingId string
+	}
+)
+
+type (
+	DeleteBuildingRequest struct {
+		DormitoryId string
+		BuildingId  string
+	}
+
+	DeleteBuildingResponse struct {
+		BuildingId string
+	}
+)
+
+type (
+	CreateFloorRequest struct {
+		DormitoryId string
+		BuildingId  string
+		Number      int
+	}
+
+	CreateFloorResponse struct {
+		FloorId string
+	}
+)
+
+type (
+	DeleteFloorRequest struct {
+		DormitoryId string
+		FloorId     string
+	}
+
+	DeleteFloorResponse struct {
+		FloorId string
+	}
+)
+
+type (
+	CreateRoomRequest struct {
+		DormitoryId string
+		FloorId     string
+		Number      string
+		Type        RoomType
+		Capacity    int
+		Occupied    int
+	}
+
+	CreateRoomResponse struct {
+		RoomId string
+	}
+)
+
+type (
+	UpdateRoomRequest struct {
+		DormitoryId string
+		RoomId      string
+		Number      *string
+		Type        *RoomType
+		Capacity    *int
+		Occupied    *int
+	}
+
+	UpdateRoomResponse struct {
+		RoomId string
+	}
+)
+
+type (
+	DeleteRoomRequest struct {
+		DormitoryId string
+		RoomId      string
+	}
+
+	DeleteRoomResponse struct {
+		RoomId string
+	}
+)
+
+type OccupancyStats struct {
+	Rooms    int
+	Capacity int
+	Occupied int
+}
+
+type BuildingOccupancy struct {
+	BuildingId   string
+	BuildingName string
+	RoomType     RoomType
+	OccupancyStats
+}
+
+type (
+	GetDormitoryOccupancyRequest struct {
+		DormitoryId string
+	}
+
+	GetDormitoryOccupancyResponse struct {
+		Buildings []BuildingOccupancy
+	}
+)
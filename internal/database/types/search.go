@@ -0,0 +1,30 @@
+package types
+
+import "time"
+
+type SearchHit struct {
+	Id        string
+	AuthorId  *string
+	Title     string
+	Snippet   string
+	Rank      float64
+	CreatedAt time.Time
+}
+
+type (
+	SearchDormitoryRequest struct {
+		DormitoryId string
+		Query       string
+		Limit       uint64
+
+		// ViewerId и IncludeHidden - как в GetDormitoryReviewsRequest
+		ViewerId      string
+		IncludeHidden bool
+	}
+
+	SearchDormitoryResponse struct {
+		Reviews      []SearchHit
+		Events       []SearchHit
+		ChatMessages []SearchHit
+	}
+)
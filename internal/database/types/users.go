@@ -14,3 +14,19 @@ type GetUsersRoleRequest struct {
 type GetUsersRoleResponse struct {
 	Role UserRole
 }
+
+type GetUserEmailRequest struct {
+	UserId string
+}
+
+type GetUserEmailResponse struct {
+	Email string
+}
+
+type GetDormitoryResidentsEmailsRequest struct {
+	DormitoryId string
+}
+
+type GetDormitoryResidentsEmailsResponse struct {
+	Emails []string
+}
@@ -68,3 +68,129 @@ func (c *Database) getUsersRole(
 
 	return &resp, nil
 }
+
+func (c *Database) GetUserEmail(
+	ctx context.Context,
+	request *dbtypes.GetUserEmailRequest,
+) (*dbtypes.GetUserEmailResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getUserEmail(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) getUserEmail(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetUserEmailRequest,
+) (*dbtypes.GetUserEmailResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		userTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.UsersTableName)
+	)
+
+	queryBuilder := psql.
+		Select("email").
+		From(userTable).
+		Where(squirrel.Eq{"id": request.UserId}).
+		Limit(1)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get user email query: %v", dberrors.ErrInternal, err)
+	}
+
+	var resp dbtypes.GetUserEmailResponse
+
+	if err := driver.QueryRowContext(ctx, query, args...).Scan(&resp.Email); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: user not found", dberrors.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%w: error executing get user email query: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (c *Database) GetDormitoryResidentsEmails(
+	ctx context.Context,
+	request *dbtypes.GetDormitoryResidentsEmailsRequest,
+) (*dbtypes.GetDormitoryResidentsEmailsResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	resp, err := c.getDormitoryResidentsEmails(ctx, c.db, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Database) getDormitoryResidentsEmails(
+	ctx context.Context,
+	driver Driver,
+	request *dbtypes.GetDormitoryResidentsEmailsRequest,
+) (*dbtypes.GetDormitoryResidentsEmailsResponse, error) {
+	if request == nil {
+		return nil, dberrors.ErrBadRequest
+	}
+
+	var (
+		psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+		userTable = fmt.Sprintf("%s.%s", constants.SchemaName, constants.UsersTableName)
+	)
+
+	queryBuilder := psql.
+		Select("email").
+		From(userTable).
+		Where(squirrel.Eq{
+			"dormitory_id": request.DormitoryId,
+			"role":         dbtypes.UserStudentRole,
+		}).
+		OrderBy("email ASC")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error building get residents emails query: %v", dberrors.ErrInternal, err)
+	}
+
+	rows, err := driver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error executing get residents emails query: %v", dberrors.ErrInternal, err)
+	}
+
+	defer rows.Close()
+
+	var resp dbtypes.GetDormitoryResidentsEmailsResponse
+
+	for rows.Next() {
+		var email string
+
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("%w: error scanning row: %v", dberrors.ErrInternal, err)
+		}
+
+		resp.Emails = append(resp.Emails, email)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: error iterating rows: %v", dberrors.ErrInternal, err)
+	}
+
+	return &resp, nil
+}
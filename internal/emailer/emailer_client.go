@@ -49,8 +49,6 @@ func (e *Emailer) SendMessage(
 		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
 	}
 
-	auth := smtp.PlainAuth("", e.user, e.password, e.host)
-
 	body := fmt.Sprintf(
 		emailTemplate,
 		html.EscapeString(req.UserEmail),
@@ -58,23 +56,70 @@ func (e *Emailer) SendMessage(
 		html.EscapeString(req.Description),
 	)
 
-	message := buildHTMLMessage(e.email, req.SupportEmail, supportHeader, body)
+	if err := e.send(req.SupportEmail, supportHeader, body); err != nil {
+		return nil, err
+	}
+
+	return &SendMessageResponse{
+		UserEmail:    req.UserEmail,
+		SupportEmail: req.SupportEmail,
+	}, nil
+}
+
+// SendReviewReplyMessage уведомляет автора отзыва об ответе администрации
+func (e *Emailer) SendReviewReplyMessage(
+	ctx context.Context,
+	req *SendReviewReplyRequest,
+) error {
+	if req == nil {
+		return fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	body := fmt.Sprintf(
+		reviewReplyTemplate,
+		html.EscapeString(req.ReviewTitle),
+		html.EscapeString(req.ReplyText),
+	)
+
+	return e.send(req.UserEmail, reviewReplyHeader, body)
+}
+
+// SendAnnouncementMessage рассылает жильцу срочное объявление общежития
+func (e *Emailer) SendAnnouncementMessage(
+	ctx context.Context,
+	req *SendAnnouncementRequest,
+) error {
+	if req == nil {
+		return fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	body := fmt.Sprintf(
+		announcementTemplate,
+		html.EscapeString(req.DormitoryName),
+		html.EscapeString(req.Title),
+		html.EscapeString(req.Description),
+	)
+
+	return e.send(req.UserEmail, fmt.Sprintf("%s: %s", announcementHeader, req.Title), body)
+}
+
+func (e *Emailer) send(to, subject, body string) error {
+	auth := smtp.PlainAuth("", e.user, e.password, e.host)
+
+	message := buildHTMLMessage(e.email, to, subject, body)
 
 	if err := smtp.SendMail(
 		fmt.Sprintf("%s:%d", e.host, e.port),
 		auth,
 		e.email,
-		[]string{req.SupportEmail},
+		[]string{to},
 		[]byte(message),
 	); err != nil {
 		e.logger.Error("error sending mail", slog.String("error", err.Error()))
-		return nil, fmt.Errorf("%w: error sending email: %v", ErrInternal, err)
+		return fmt.Errorf("%w: error sending email: %v", ErrInternal, err)
 	}
 
-	return &SendMessageResponse{
-		UserEmail:    req.UserEmail,
-		SupportEmail: req.SupportEmail,
-	}, nil
+	return nil
 }
 
 func buildHTMLMessage(from, to, subject, body string) string {
@@ -11,3 +11,16 @@ type SendMessageResponse struct {
 	UserEmail    string
 	SupportEmail string
 }
+
+type SendReviewReplyRequest struct {
+	UserEmail   string
+	ReviewTitle string
+	ReplyText   string
+}
+
+type SendAnnouncementRequest struct {
+	UserEmail     string
+	DormitoryName string
+	Title         string
+	Description   string
+}
@@ -0,0 +1,250 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Получение каталога удобств
+// @Description Получение всех удобств, которые можно привязать к общежитию
+// @Tags Amenities
+// @Produce json
+// @Success 200 {object} rmodel.GetAmenitiesResponse "Удобства"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/amenities [get]
+func (s *Server) getAmenitiesHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getAmenitiesHandler"
+
+	resp, err := s.coreService.GetAmenities(r.Context(), &rmodel.GetAmenitiesRequest{})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Создание удобства
+// @Description Добавляет удобство в каталог
+// @Tags Amenities
+// @Accept json
+// @Produce json
+// @Params request body rmodel.CreateAmenityRequest true "Удобство"
+// @Success 201 {object} rmodel.CreateAmenityResponse "Удобство создано"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 409 {object} rmodel.ErrorResponse "Удобство с таким кодом уже существует"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/amenities [post]
+func (s *Server) createAmenityHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "createAmenityHandler"
+
+	var req rmodel.CreateAmenityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	resp, err := s.coreService.CreateAmenity(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Обновление удобства
+// @Description Обновляет код или название удобства
+// @Tags Amenities
+// @Accept json
+// @Produce json
+// @Params amenity_id path string true "ID удобства"
+// @Params request body rmodel.UpdateAmenityRequest true "Информация для обновления"
+// @Success 200 {object} rmodel.UpdateAmenityResponse "Удобство обновлено"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Удобство не найдено"
+// @Failure 409 {object} rmodel.ErrorResponse "Удобство с таким кодом уже существует"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/amenities/{amenity_id} [put]
+func (s *Server) updateAmenityHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "updateAmenityHandler"
+
+	var (
+		vars      = mux.Vars(r)
+		amenityId = vars["amenity_id"]
+	)
+
+	var req rmodel.UpdateAmenityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.AmenityId = amenityId
+
+	resp, err := s.coreService.UpdateAmenity(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Удаление удобства
+// @Description Удаляет удобство из каталога и отвязывает его от всех общежитий
+// @Tags Amenities
+// @Produce json
+// @Params amenity_id path string true "ID удобства"
+// @Success 200 {object} rmodel.DeleteAmenityResponse "Удобство удалено"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Удобство не найдено"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/amenities/{amenity_id} [delete]
+func (s *Server) deleteAmenityHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "deleteAmenityHandler"
+
+	var (
+		vars      = mux.Vars(r)
+		amenityId = vars["amenity_id"]
+	)
+
+	resp, err := s.coreService.DeleteAmenity(r.Context(), &rmodel.DeleteAmenityRequest{
+		AmenityId: amenityId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Установка удобств общежития
+// @Description Заменяет набор удобств общежития на переданный список кодов
+// @Tags Amenities
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params request body rmodel.SetDormitoryAmenitiesRequest true "Коды удобств"
+// @Success 200 {object} rmodel.SetDormitoryAmenitiesResponse "Удобства обновлены"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Общежитие не найдено"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/amenities [put]
+func (s *Server) setDormitoryAmenitiesHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "setDormitoryAmenitiesHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	var req rmodel.SetDormitoryAmenitiesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+
+	resp, err := s.coreService.SetDormitoryAmenities(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Календарь событий общежития
+// @Description События ленты в формате iCalendar (RFC 5545) для подписки из календаря.
+// @Description Посты без времени проведения отображаются событиями на весь день публикации.
+// @Description Поддерживает условный запрос с If-None-Match
+// @Tags Feed
+// @Produce text/calendar
+// @Params dormitory_id path string true "ID общежития"
+// @Success 200 {string} string "Календарь"
+// @Success 304 "Календарь не изменился"
+// @Failure 404 {object} rmodel.ErrorResponse "Общежитие не найдено"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/events.ics [get]
+func (s *Server) getEventsCalendarHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getEventsCalendarHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	resp, err := s.coreService.GetEventsCalendar(r.Context(), &rmodel.GetEventsCalendarRequest{
+		DormitoryId: dormitoryId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.Header().Set("ETag", resp.ETag)
+	// клиент может держать копию, но перед использованием обязан свериться по ETag
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if rmodel.ETagMatches(r.Header.Get("If-None-Match"), resp.ETag) {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="dormitory-%s.ics"`, dormitoryId))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(resp.Calendar); err != nil {
+		s.logger.Error("error writing response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
@@ -10,9 +10,16 @@ import (
 )
 
 // @Summary Получение списка общежитий
-// @Description Получение списка краткой информации о всех общежитиях
+// @Description Получение списка краткой информации об общежитиях с фильтрацией, сортировкой и пагинацией
 // @Tags Dormitories
 // @Produce json
+// @Param search query string false "Подстрока названия или адреса"
+// @Param min_rating query number false "Минимальная общая средняя оценка"
+// @Param amenities query string false "Коды обязательных удобств через запятую"
+// @Param sort query string false "Сортировка: name или rating"
+// @Param order query string false "Направление сортировки: asc или desc"
+// @Param page query int false "Номер страницы"
+// @Param page_size query int false "Размер страницы"
 // @Success 200 {object} rmodel.GetDormitoriesResponse "Общежития"
 // @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
 // @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
@@ -21,7 +28,66 @@ import (
 func (s *Server) getDormitoriesHandler(w http.ResponseWriter, r *http.Request) {
 	const handlerName = "getDormitoriesHandler"
 
-	resp, err := s.coreService.GetDormitories(r.Context(), &rmodel.GetDormitoriesRequest{})
+	req, err := new(rmodel.GetDormitoriesRequest).FromUrlQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing query",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	resp, err := s.coreService.GetDormitories(r.Context(), req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Поиск ближайших общежитий
+// @Description Получение общежитий в заданном радиусе, отсортированных по расстоянию
+// @Tags Dormitories
+// @Produce json
+// @Param lat query number true "Широта точки"
+// @Param lon query number true "Долгота точки"
+// @Param radius_km query number false "Радиус поиска в километрах"
+// @Success 200 {object} rmodel.GetNearbyDormitoriesResponse "Общежития"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/nearby [get]
+func (s *Server) getNearbyDormitoriesHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getNearbyDormitoriesHandler"
+
+	req, err := new(rmodel.GetNearbyDormitoriesRequest).FromUrlQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing query",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	resp, err := s.coreService.GetNearbyDormitories(r.Context(), req)
 	if err != nil {
 		s.handleError(w, err)
 		s.logger.Error("error",
@@ -193,7 +259,7 @@ func (s *Server) updateDormitoryHandler(w http.ResponseWriter, r *http.Request)
 }
 
 // @Summary Удаление общежития
-// @Description Удаляет общежитие из системы
+// @Description Удаляет общежитие вместе с оценками, отзывами, лентой, чатом и всеми файлами
 // @Tags Dormitories
 // @Produce json
 // @Params dormitory_id path string true "ID общежития"
@@ -210,20 +276,11 @@ func (s *Server) deleteDormitoryHandler(w http.ResponseWriter, r *http.Request)
 		dormitoryId = vars["dormitory_id"]
 	)
 
-	var req rmodel.DeleteDormitoryRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeErrorResponse(w, err, http.StatusBadRequest)
-		s.logger.Error("error decoding request",
-			slog.String("error", err.Error()),
-			slog.String("handler", handlerName),
-		)
-
-		return
+	req := &rmodel.DeleteDormitoryRequest{
+		DormitoryId: dormitoryId,
 	}
 
-	req.DormitoryId = dormitoryId
-
-	resp, err := s.coreService.DeleteDormitory(r.Context(), &req)
+	resp, err := s.coreService.DeleteDormitory(r.Context(), req)
 	if err != nil {
 		s.handleError(w, err)
 		s.logger.Error("error",
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Ответить на приглашение к событию
+// @Description going, maybe или not_going. Если мест нет, going ставит пользователя в лист ожидания,
+// @Description освободившиеся места автоматически достаются следующим в очереди
+// @Tags Feed
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params event_id path string true "ID события"
+// @Params request body rmodel.RsvpEventRequest true "Ответ"
+// @Success 200 {object} rmodel.RsvpEventResponse "Итоговый статус и счетчики ответов"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные или событие уже прошло"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Событие не найдено"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/events/{event_id}/rsvp [put]
+func (s *Server) rsvpEventHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "rsvpEventHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		eventId     = vars["event_id"]
+	)
+
+	var req rmodel.RsvpEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+	req.EventId = eventId
+
+	resp, err := s.coreService.RsvpEvent(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Снять ответ на приглашение
+// @Description Удаляет ответ пользователя. Если он был участником, его место получает первый из листа ожидания
+// @Tags Feed
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params event_id path string true "ID события"
+// @Success 200 {object} rmodel.RsvpEventResponse "Счетчики ответов"
+// @Failure 400 {object} rmodel.ErrorResponse "Событие уже прошло"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Событие не найдено"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/events/{event_id}/rsvp [delete]
+func (s *Server) deleteEventRsvpHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "deleteEventRsvpHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		eventId     = vars["event_id"]
+	)
+
+	resp, err := s.coreService.DeleteEventRsvp(r.Context(), &rmodel.DeleteEventRsvpRequest{
+		DormitoryId: dormitoryId,
+		EventId:     eventId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Участники события
+// @Description Список ответов на событие для администрации: участники, лист ожидания в порядке очереди, остальные
+// @Tags Feed
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params event_id path string true "ID события"
+// @Params status query string false "going, maybe, not_going или waitlisted"
+// @Success 200 {object} rmodel.GetEventAttendeesResponse "Участники"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Событие не найдено"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/events/{event_id}/attendees [get]
+func (s *Server) getEventAttendeesHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getEventAttendeesHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		eventId     = vars["event_id"]
+	)
+
+	req, err := new(rmodel.GetEventAttendeesRequest).FromUrlQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing query",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+	req.EventId = eventId
+
+	resp, err := s.coreService.GetEventAttendees(r.Context(), req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
@@ -5,17 +5,25 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	rmodel "github.com/dormitory-life/core/internal/server/request_models"
 	"github.com/gorilla/mux"
 )
 
 // @Summary Получение ленты
-// @Description Получение списка событий общежития
+// @Description Получение списка событий общежития. Закрепленные посты идут первыми, посты с истекшим сроком показа скрыты
+// @Description При фильтре по времени повторяющиеся события разворачиваются в отдельные повторения
 // @Tags Feed
 // @Produce json
 // @Params dormitory_id path string true "ID общежития"
 // @Params page query int false "номер страницы"
+// @Params period query string false "upcoming - предстоящие и идущие события по времени начала, past - прошедшие"
+// @Params from query string false "Начало интервала, RFC 3339 или YYYY-MM-DD"
+// @Params to query string false "Конец интервала, RFC 3339 или YYYY-MM-DD (дата включается целиком)"
+// @Params include_expired query bool false "Вернуть и посты с истекшим сроком показа"
 // @Success 200 {object} rmodel.GetDormitoryEventsResponse "Лента"
 // @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
 // @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
@@ -73,6 +81,20 @@ func (s *Server) getDormitoryEventsHandler(w http.ResponseWriter, r *http.Reques
 // @Param title formData string true "Заголовок события"
 // @Param description formData string true "Описание события"
 // @Param photos formData file true "Фотографии события"
+// @Param starts_at formData string false "Время начала, RFC 3339"
+// @Param ends_at formData string false "Время окончания, RFC 3339"
+// @Param room_id formData string false "ID комнаты общежития, где проходит событие"
+// @Param location formData string false "Общая зона или уточнение места"
+// @Param category formData string false "meeting, cleaning, sport, culture, education, party или other (по умолчанию)"
+// @Param capacity formData int false "Максимальное число участников"
+// @Param recurrence_freq formData string false "Повторение: daily, weekly или monthly"
+// @Param recurrence_interval formData int false "Шаг повторения, по умолчанию 1"
+// @Param recurrence_until formData string false "Повторять до, RFC 3339"
+// @Param recurrence_count formData int false "Число повторений, нельзя вместе с recurrence_until"
+// @Param recurrence_exdates formData string false "Исключенные начала повторений, RFC 3339 через запятую"
+// @Param pinned formData bool false "Закрепить пост вверху ленты"
+// @Param expires_at formData string false "Срок показа в ленте, RFC 3339"
+// @Param urgent formData bool false "Срочный пост, рассылается письмом всем жильцам"
 // @Success 201 {object} rmodel.CreateDormitoryEventResponse "Событие создано"
 // @Failure 400 {object} rmodel.ErrorResponse "Некорректные данные формы или отсутствуют обязательные поля"
 // @Failure 401 {object} rmodel.ErrorResponse "Пользователь не авторизован"
@@ -111,6 +133,63 @@ func (s *Server) createDormitoryEventHandler(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// @Summary Изменить событие общежития
+// @Description Изменяет только переданные поля события. Пустые ends_at, room_id и capacity снимают значение.
+// @Description Фото можно добавить и удалить по одному, остальные фото события не меняются
+// @Tags Feed
+// @Accept multipart/form-data
+// @Produce json
+// @Param dormitory_id path string true "ID общежития"
+// @Param event_id path string true "ID события"
+// @Param title formData string false "Новый заголовок"
+// @Param description formData string false "Новое описание"
+// @Param starts_at formData string false "Время начала, RFC 3339. У повторяющихся событий не меняется"
+// @Param ends_at formData string false "Время окончания, RFC 3339"
+// @Param room_id formData string false "ID комнаты общежития"
+// @Param location formData string false "Общая зона или уточнение места"
+// @Param category formData string false "meeting, cleaning, sport, culture, education, party или other"
+// @Param capacity formData int false "Максимальное число участников"
+// @Param photos formData []file false "Добавляемые фотографии" collectionFormat(multi)
+// @Param remove_photos formData []string false "Пути или имена удаляемых фотографий" collectionFormat(multi)
+// @Success 200 {object} rmodel.UpdateDormitoryEventResponse "Событие изменено"
+// @Failure 400 {object} rmodel.ErrorResponse "Некорректные данные формы"
+// @Failure 401 {object} rmodel.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Событие или комната не найдены"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/events/{event_id} [put]
+func (s *Server) updateDormitoryEventHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "updateDormitoryEventHandler"
+
+	req, err := s.parseUpdateEventRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	resp, err := s.coreService.UpdateDormitoryEvent(r.Context(), req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
 // @Summary Удалить событие общежития
 // @Description Удаляет событие из ленты общежития
 // @Tags Feed
@@ -190,5 +269,256 @@ func (s *Server) parseCreateEventRequest(w http.ResponseWriter, r *http.Request)
 		return nil, err
 	}
 
+	if err := parseEventScheduleForm(r, req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		return nil, err
+	}
+
+	if err := parseEventAnnouncementForm(r, req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// parseUpdateEventRequest читает правку события: поля формы, новые фото из photos
+// и имена удаляемых фото из remove_photos
+func (s *Server) parseUpdateEventRequest(w http.ResponseWriter, r *http.Request) (*rmodel.UpdateDormitoryEventRequest, error) {
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		eventId     = vars["event_id"]
+	)
+
+	req := &rmodel.UpdateDormitoryEventRequest{
+		DormitoryId: dormitoryId,
+		EventId:     eventId,
+	}
+
+	err := r.ParseMultipartForm(50 << 20)
+	if err != nil {
+		writeErrorResponse(w, fmt.Errorf("failed to parse form: %v", err), http.StatusBadRequest)
+		s.logger.Error("parse form error", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := parseEventUpdateForm(r.MultipartForm.Value, req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		return nil, err
+	}
+
+	req.PhotoFilesHeaders = r.MultipartForm.File["photos"]
+	req.RemovePhotos = r.MultipartForm.Value["remove_photos"]
+
+	if err := req.Validate(); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		return nil, err
+	}
+
 	return req, nil
 }
+
+// parseEventUpdateForm читает переданные поля события. Пустые ends_at, room_id
+// и capacity снимают значение, пустой starts_at не допускается
+func parseEventUpdateForm(form map[string][]string, req *rmodel.UpdateDormitoryEventRequest) error {
+	value := func(field string) (string, bool) {
+		val, ok := form[field]
+		if !ok || len(val) == 0 {
+			return "", false
+		}
+
+		return val[0], true
+	}
+
+	if val, ok := value("title"); ok {
+		req.Title = &val
+	}
+
+	if val, ok := value("description"); ok {
+		req.Description = &val
+	}
+
+	if val, ok := value("starts_at"); ok {
+		startsAt, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return fmt.Errorf("invalid starts_at: %v", err)
+		}
+
+		req.StartsAt = &startsAt
+	}
+
+	if val, ok := value("ends_at"); ok {
+		if len(val) == 0 {
+			req.ClearEndsAt = true
+		} else {
+			endsAt, err := time.Parse(time.RFC3339, val)
+			if err != nil {
+				return fmt.Errorf("invalid ends_at: %v", err)
+			}
+
+			req.EndsAt = &endsAt
+		}
+	}
+
+	if val, ok := value("room_id"); ok {
+		if len(val) == 0 {
+			req.ClearRoomId = true
+		} else {
+			req.RoomId = &val
+		}
+	}
+
+	if val, ok := value("location"); ok {
+		location := strings.TrimSpace(val)
+		req.Location = &location
+	}
+
+	if val, ok := value("category"); ok {
+		req.Category = &val
+	}
+
+	if val, ok := value("capacity"); ok {
+		if len(val) == 0 {
+			req.ClearCapacity = true
+		} else {
+			capacity, err := strconv.Atoi(val)
+			if err != nil {
+				return fmt.Errorf("invalid capacity: %v", err)
+			}
+
+			req.Capacity = &capacity
+		}
+	}
+
+	return nil
+}
+
+// parseEventScheduleForm читает необязательные поля расписания и места события
+func parseEventScheduleForm(r *http.Request, req *rmodel.CreateDormitoryEventRequest) error {
+	for field, target := range map[string]**time.Time{
+		"starts_at": &req.StartsAt,
+		"ends_at":   &req.EndsAt,
+	} {
+		val := r.FormValue(field)
+		if len(val) == 0 {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", field, err)
+		}
+
+		*target = &t
+	}
+
+	if roomId := r.FormValue("room_id"); len(roomId) != 0 {
+		req.RoomId = &roomId
+	}
+
+	if val := r.FormValue("capacity"); len(val) != 0 {
+		capacity, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid capacity: %v", err)
+		}
+
+		req.Capacity = &capacity
+	}
+
+	req.Location = strings.TrimSpace(r.FormValue("location"))
+	req.Category = r.FormValue("category")
+
+	return parseEventRecurrenceForm(r, req)
+}
+
+// parseEventRecurrenceForm читает правило повторения, если задана его частота
+func parseEventRecurrenceForm(r *http.Request, req *rmodel.CreateDormitoryEventRequest) error {
+	freq := r.FormValue("recurrence_freq")
+	if len(freq) == 0 {
+		return nil
+	}
+
+	recurrence := &rmodel.Recurrence{
+		Freq: freq,
+	}
+
+	if val := r.FormValue("recurrence_interval"); len(val) != 0 {
+		interval, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid recurrence_interval: %v", err)
+		}
+
+		recurrence.Interval = interval
+	}
+
+	if val := r.FormValue("recurrence_count"); len(val) != 0 {
+		count, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid recurrence_count: %v", err)
+		}
+
+		recurrence.Count = &count
+	}
+
+	if val := r.FormValue("recurrence_until"); len(val) != 0 {
+		until, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return fmt.Errorf("invalid recurrence_until: %v", err)
+		}
+
+		recurrence.Until = &until
+	}
+
+	// исключенные даты можно передать несколькими полями или через запятую
+	for _, val := range r.MultipartForm.Value["recurrence_exdates"] {
+		for _, exdate := range strings.Split(val, ",") {
+			exdate = strings.TrimSpace(exdate)
+			if len(exdate) == 0 {
+				continue
+			}
+
+			t, err := time.Parse(time.RFC3339, exdate)
+			if err != nil {
+				return fmt.Errorf("invalid recurrence_exdates: %v", err)
+			}
+
+			recurrence.ExDates = append(recurrence.ExDates, t)
+		}
+	}
+
+	req.Recurrence = recurrence
+
+	return nil
+}
+
+// parseEventAnnouncementForm читает закрепление, срок показа и срочность поста
+func parseEventAnnouncementForm(r *http.Request, req *rmodel.CreateDormitoryEventRequest) error {
+	for field, target := range map[string]*bool{
+		"pinned": &req.Pinned,
+		"urgent": &req.Urgent,
+	} {
+		val := r.FormValue(field)
+		if len(val) == 0 {
+			continue
+		}
+
+		flag, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", field, err)
+		}
+
+		*target = flag
+	}
+
+	if val := r.FormValue("expires_at"); len(val) != 0 {
+		expiresAt, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return fmt.Errorf("invalid expires_at: %v", err)
+		}
+
+		req.ExpiresAt = &expiresAt
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Закрепить пост или сделать его срочным
+// @Description Закрепляет пост вверху ленты, меняет срок показа или делает пост срочным. Передаются только изменяемые поля.
+// @Description Пост, ставший срочным, рассылается письмом всем жильцам общежития
+// @Tags Feed
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params event_id path string true "ID поста"
+// @Params request body rmodel.UpdateEventAnnouncementRequest true "Изменения поста"
+// @Success 200 {object} rmodel.UpdateEventAnnouncementResponse "Пост"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 401 {object} rmodel.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Пост не найден"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/events/{event_id}/announcement [put]
+func (s *Server) updateEventAnnouncementHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "updateEventAnnouncementHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		eventId     = vars["event_id"]
+	)
+
+	var req rmodel.UpdateEventAnnouncementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+	req.EventId = eventId
+
+	resp, err := s.coreService.UpdateEventAnnouncement(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Изменить повторение события
+// @Description Меняет одно повторение серии, не трогая остальные. Передаются только изменяемые поля,
+// @Description cancelled=true отменяет повторение, false возвращает его
+// @Tags Feed
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params event_id path string true "ID серии"
+// @Params occurrence_start path string true "Исходное начало повторения, RFC 3339"
+// @Params request body rmodel.UpdateEventOccurrenceRequest true "Изменения повторения"
+// @Success 200 {object} rmodel.UpdateEventOccurrenceResponse "Повторение"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные или событие не повторяется"
+// @Failure 401 {object} rmodel.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Событие или повторение не найдено"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/events/{event_id}/occurrences/{occurrence_start} [put]
+func (s *Server) updateEventOccurrenceHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "updateEventOccurrenceHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		eventId     = vars["event_id"]
+	)
+
+	occurrenceStart, err := rmodel.ParseOccurrenceStart(vars["occurrence_start"])
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing path",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	var req rmodel.UpdateEventOccurrenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+	req.EventId = eventId
+	req.OccurrenceStart = occurrenceStart
+
+	resp, err := s.coreService.UpdateEventOccurrence(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Отменить повторение события
+// @Description Отменяет одно повторение серии. Повторение остается в ленте и календаре с пометкой об отмене
+// @Tags Feed
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params event_id path string true "ID серии"
+// @Params occurrence_start path string true "Исходное начало повторения, RFC 3339"
+// @Success 200 {object} rmodel.UpdateEventOccurrenceResponse "Отмененное повторение"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные или событие не повторяется"
+// @Failure 401 {object} rmodel.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Событие или повторение не найдено"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/events/{event_id}/occurrences/{occurrence_start} [delete]
+func (s *Server) cancelEventOccurrenceHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "cancelEventOccurrenceHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		eventId     = vars["event_id"]
+	)
+
+	occurrenceStart, err := rmodel.ParseOccurrenceStart(vars["occurrence_start"])
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing path",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	resp, err := s.coreService.CancelEventOccurrence(r.Context(), &rmodel.CancelEventOccurrenceRequest{
+		DormitoryId:     dormitoryId,
+		EventId:         eventId,
+		OccurrenceStart: occurrenceStart,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
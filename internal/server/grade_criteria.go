@@ -0,0 +1,163 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Получение критериев оценки
+// @Description Получение реестра критериев оценки общежитий с метками на выбранном языке
+// @Tags Grades
+// @Produce json
+// @Params lang query string false "Язык меток, по умолчанию ru"
+// @Params all query bool false "Включая отключенные критерии"
+// @Success 200 {object} rmodel.GetGradeCriteriaResponse "Критерии оценки"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные параметры запроса"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/grades/criteria [get]
+func (s *Server) getGradeCriteriaHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getGradeCriteriaHandler"
+
+	req, err := new(rmodel.GetGradeCriteriaRequest).FromUrlQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing query",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	resp, err := s.coreService.GetGradeCriteria(r.Context(), req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Создание критерия оценки
+// @Description Добавляет критерий в реестр, по нему сразу можно оценивать общежития
+// @Tags Grades
+// @Accept json
+// @Produce json
+// @Params request body rmodel.CreateGradeCriterionRequest true "Критерий"
+// @Success 201 {object} rmodel.CreateGradeCriterionResponse "Критерий создан"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 409 {object} rmodel.ErrorResponse "Критерий с таким кодом уже существует"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/grades/criteria [post]
+func (s *Server) createGradeCriterionHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "createGradeCriterionHandler"
+
+	var req rmodel.CreateGradeCriterionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	resp, err := s.coreService.CreateGradeCriterion(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Обновление критерия оценки
+// @Description Обновляет метки, вес, порядок или активность критерия
+// @Tags Grades
+// @Accept json
+// @Produce json
+// @Params code path string true "Код критерия"
+// @Params request body rmodel.UpdateGradeCriterionRequest true "Информация для обновления"
+// @Success 200 {object} rmodel.UpdateGradeCriterionResponse "Критерий обновлен"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Критерий не найден"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/grades/criteria/{code} [put]
+func (s *Server) updateGradeCriterionHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "updateGradeCriterionHandler"
+
+	var (
+		vars = mux.Vars(r)
+		code = vars["code"]
+	)
+
+	var req rmodel.UpdateGradeCriterionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.Code = code
+
+	resp, err := s.coreService.UpdateGradeCriterion(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
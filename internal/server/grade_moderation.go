@@ -0,0 +1,126 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Оценки на проверке
+// @Description Список подозрительных или отклоненных оценок общежития с причинами
+// @Tags Grades
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params status query string false "flagged (по умолчанию) или rejected"
+// @Params page query int false "Номер страницы"
+// @Success 200 {object} rmodel.GetModerationGradesResponse "Оценки"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/grades/moderation [get]
+func (s *Server) getModerationGradesHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getModerationGradesHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	req, err := new(rmodel.GetModerationGradesRequest).FromUrlQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing query",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+
+	resp, err := s.coreService.GetModerationGrades(r.Context(), req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Проверка оценки
+// @Description Одобряет оценку (она учитывается в средних) или отклоняет ее
+// @Tags Grades
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params grade_id path string true "ID оценки"
+// @Params request body rmodel.ModerateGradeRequest true "Решение"
+// @Success 200 {object} rmodel.ModerateGradeResponse "Решение сохранено"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Оценка не найдена"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/grades/{grade_id}/moderation [put]
+func (s *Server) moderateGradeHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "moderateGradeHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		gradeId     = vars["grade_id"]
+	)
+
+	var req rmodel.ModerateGradeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+	req.GradeId = gradeId
+
+	resp, err := s.coreService.ModerateGrade(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
@@ -48,6 +48,8 @@ func (s *Server) getDormitoriesAvgGradesHandler(w http.ResponseWriter, r *http.R
 // @Tags Grades
 // @Produce json
 // @Params dormitory_id path string true "ID общежития"
+// @Param from query string false "Начальный месяц (YYYY-MM)"
+// @Param to query string false "Конечный месяц включительно (YYYY-MM)"
 // @Success 200 {object} rmodel.GetDormitoryAvgGradesResponse "Оценки"
 // @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
 // @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
@@ -60,9 +62,20 @@ func (s *Server) getDormitoryAvgGradesHandler(w http.ResponseWriter, r *http.Req
 		dormitoryId = vars["dormitory_id"]
 	)
 
-	resp, err := s.coreService.GetDormitoryAvgGrades(r.Context(), &rmodel.GetDormitoryAvgGradesRequest{
-		DormitoryId: dormitoryId,
-	})
+	req, err := new(rmodel.GetDormitoryAvgGradesRequest).FromUrlQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing query",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+
+	resp, err := s.coreService.GetDormitoryAvgGrades(r.Context(), req)
 	if err != nil {
 		s.handleError(w, err)
 		s.logger.Error("error",
@@ -141,3 +154,264 @@ func (s *Server) createDormitoryGradeHandler(w http.ResponseWriter, r *http.Requ
 		)
 	}
 }
+
+// @Summary Изменение своей оценки общежития
+// @Description Исправление оценки текущего пользователя за текущий месяц с пересчетом средних
+// @Tags Grades
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params request body true rmodel.UpdateDormitoryGradeRequest "Оценки по критериям"
+// @Success 200 {object} rmodel.UpdateDormitoryGradeResponse "Оценка изменена"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Оценки за текущий месяц нет"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/grades/mine [put]
+func (s *Server) updateDormitoryGradeHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "updateDormitoryGradeHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	var req rmodel.UpdateDormitoryGradeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+
+	resp, err := s.coreService.UpdateDormitoryGrade(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Отзыв своей оценки общежития
+// @Description Удаление оценки текущего пользователя за текущий месяц с пересчетом средних
+// @Tags Grades
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Success 200 {object} rmodel.DeleteDormitoryGradeResponse "Оценка удалена"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Оценки за текущий месяц нет"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/grades/mine [delete]
+func (s *Server) deleteDormitoryGradeHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "deleteDormitoryGradeHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	resp, err := s.coreService.DeleteDormitoryGrade(r.Context(), &rmodel.DeleteDormitoryGradeRequest{
+		DormitoryId: dormitoryId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary История оценок общежития
+// @Description Временной ряд средних оценок по каждому критерию и общей средней для графиков
+// @Tags Grades
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Param from query string false "Начальный месяц (YYYY-MM)"
+// @Param to query string false "Конечный месяц включительно (YYYY-MM)"
+// @Param granularity query string false "Период: month, semester или year"
+// @Success 200 {object} rmodel.GetDormitoryGradesHistoryResponse "История оценок"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/grades/history [get]
+func (s *Server) getDormitoryGradesHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getDormitoryGradesHistoryHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	req, err := new(rmodel.GetDormitoryGradesHistoryRequest).FromUrlQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing query",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+
+	resp, err := s.coreService.GetDormitoryGradesHistory(r.Context(), req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Распределение оценок общежития
+// @Description Количество каждой оценки от 1 до 5 по каждому критерию за месяц или за все время
+// @Tags Grades
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Param period query string false "Месяц (YYYY-MM), по умолчанию за все время"
+// @Success 200 {object} rmodel.GetGradesDistributionResponse "Распределение оценок"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/grades/distribution [get]
+func (s *Server) getGradesDistributionHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getGradesDistributionHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	req, err := new(rmodel.GetGradesDistributionRequest).FromUrlQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing query",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+
+	resp, err := s.coreService.GetGradesDistribution(r.Context(), req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Рейтинг общежитий
+// @Description Рейтинг общежитий за период по взвешенной оценке критериев со сглаживанием
+// @Description к общему среднему, с изменением места относительно предыдущего периода
+// @Tags Grades
+// @Produce json
+// @Params period query string false "Период в формате YYYY-MM, по умолчанию текущий"
+// @Params granularity query string false "month, semester или year"
+// @Params weights query string false "Веса критериев в формате code:weight через запятую"
+// @Params min_ratings query int false "Число оценок, с которым среднее общежития весит как общее среднее"
+// @Success 200 {object} rmodel.GetGradesLeaderboardResponse "Рейтинг"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные параметры запроса"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/grades/leaderboard [get]
+func (s *Server) getGradesLeaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getGradesLeaderboardHandler"
+
+	req, err := new(rmodel.GetGradesLeaderboardRequest).FromUrlQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing query",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	resp, err := s.coreService.GetGradesLeaderboard(r.Context(), req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
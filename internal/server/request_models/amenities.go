@@ -0,0 +1,208 @@
+package requestmodels
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+var amenityCodeRegexp = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+type Amenity struct {
+	Id   string `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+func ConvertAmenities(msg []dbtypes.Amenity) []Amenity {
+	amenities := make([]Amenity, 0, len(msg))
+	for _, val := range msg {
+		amenities = append(amenities, Amenity{
+			Id:   val.Id,
+			Code: val.Code,
+			Name: val.Name,
+		})
+	}
+
+	return amenities
+}
+
+type (
+	GetAmenitiesRequest struct{}
+
+	GetAmenitiesResponse struct {
+		Amenities []Amenity `json:"amenities"`
+	}
+)
+
+func (r *GetAmenitiesResponse) From(msg *dbtypes.GetAmenitiesResponse) *GetAmenitiesResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &GetAmenitiesResponse{
+		Amenities: ConvertAmenities(msg.Amenities),
+	}
+}
+
+type (
+	CreateAmenityRequest struct {
+		Code string `json:"code"`
+		Name string `json:"name"`
+	}
+
+	CreateAmenityResponse struct {
+		AmenityId string `json:"amenity_id"`
+	}
+)
+
+func (r *CreateAmenityRequest) Validate() error {
+	if !amenityCodeRegexp.MatchString(r.Code) {
+		return fmt.Errorf("invalid amenity code: %q", r.Code)
+	}
+
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("empty amenity name")
+	}
+
+	return nil
+}
+
+func (r *CreateAmenityResponse) From(msg *dbtypes.CreateAmenityResponse) *CreateAmenityResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &CreateAmenityResponse{
+		AmenityId: msg.AmenityId,
+	}
+}
+
+type (
+	UpdateAmenityRequest struct {
+		AmenityId string
+		Code      *string `json:"code"`
+		Name      *string `json:"name"`
+	}
+
+	UpdateAmenityResponse struct {
+		AmenityId string `json:"amenity_id"`
+	}
+)
+
+func (r *UpdateAmenityRequest) Validate() error {
+	if r.Code == nil && r.Name == nil {
+		return fmt.Errorf("nothing to update")
+	}
+
+	if r.Code != nil && !amenityCodeRegexp.MatchString(*r.Code) {
+		return fmt.Errorf("invalid amenity code: %q", *r.Code)
+	}
+
+	if r.Name != nil && strings.TrimSpace(*r.Name) == "" {
+		return fmt.Errorf("empty amenity name")
+	}
+
+	return nil
+}
+
+func (r *UpdateAmenityResponse) From(msg *dbtypes.UpdateAmenityResponse) *UpdateAmenityResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &UpdateAmenityResponse{
+		AmenityId: msg.AmenityId,
+	}
+}
+
+type (
+	DeleteAmenityRequest struct {
+		AmenityId string
+	}
+
+	DeleteAmenityResponse struct {
+		AmenityId string `json:"amenity_id"`
+	}
+)
+
+func (r *DeleteAmenityResponse) From(msg *dbtypes.DeleteAmenityResponse) *DeleteAmenityResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &DeleteAmenityResponse{
+		AmenityId: msg.AmenityId,
+	}
+}
+
+type (
+	SetDormitoryAmenitiesRequest struct {
+		DormitoryId  string
+		AmenityCodes []string `json:"amenity_codes"`
+	}
+
+	SetDormitoryAmenitiesResponse struct {
+		DormitoryId string `json:"dormitory_id"`
+	}
+)
+
+// Normalize проверяет коды удобств и убирает дубликаты
+func (r *SetDormitoryAmenitiesRequest) Normalize() error {
+	codes, err := normalizeAmenityCodes(r.AmenityCodes)
+	if err != nil {
+		return err
+	}
+
+	r.AmenityCodes = codes
+
+	return nil
+}
+
+func (r *SetDormitoryAmenitiesResponse) From(msg *dbtypes.SetDormitoryAmenitiesResponse) *SetDormitoryAmenitiesResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &SetDormitoryAmenitiesResponse{
+		DormitoryId: msg.DormitoryId,
+	}
+}
+
+// parseAmenityCodes разбирает список кодов удобств, перечисленных через запятую
+func parseAmenityCodes(val string) ([]string, error) {
+	var codes []string
+	for _, code := range strings.Split(val, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+
+	return normalizeAmenityCodes(codes)
+}
+
+func normalizeAmenityCodes(codes []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(codes))
+	res := make([]string, 0, len(codes))
+
+	for _, code := range codes {
+		if !amenityCodeRegexp.MatchString(code) {
+			return nil, fmt.Errorf("invalid amenity code: %q", code)
+		}
+
+		if _, ok := seen[code]; ok {
+			continue
+		}
+
+		seen[code] = struct{}{}
+		res = append(res, code)
+	}
+
+	// стабильный порядок нужен для ключа кэша
+	sort.Strings(res)
+
+	return res, nil
+}
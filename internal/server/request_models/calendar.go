@@ -0,0 +1,246 @@
+package requestmodels
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dormitory-life/core/internal/constants"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+const (
+	icsDateTimeLayout = "20060102T150405Z"
+	icsDateLayout     = "20060102"
+	// icsMaxLineOctets - RFC 5545 3.1: строки длиннее 75 октетов переносятся
+	icsMaxLineOctets = 75
+)
+
+type (
+	GetEventsCalendarRequest struct {
+		DormitoryId string
+	}
+
+	GetEventsCalendarResponse struct {
+		Calendar []byte
+		// ETag - сильный валидатор содержимого календаря
+		ETag string
+	}
+)
+
+// From рендерит события в календарь iCalendar (RFC 5545).
+// Посты без расписания попадают в календарь событиями на весь день публикации
+func (r *GetEventsCalendarResponse) From(dormitoryName string, msg *dbtypes.GetEventsCalendarResponse) *GetEventsCalendarResponse {
+	if msg == nil {
+		return nil
+	}
+
+	var ics icsWriter
+
+	ics.line("BEGIN", "VCALENDAR")
+	ics.line("VERSION", "2.0")
+	ics.line("PRODID", constants.CalendarProductId)
+	ics.line("CALSCALE", "GREGORIAN")
+	ics.line("METHOD", "PUBLISH")
+	ics.line("X-WR-CALNAME", icsText(dormitoryName))
+
+	for _, event := range msg.Events {
+		ics.line("BEGIN", "VEVENT")
+		ics.line("UID", fmt.Sprintf("%s@%s", event.EventId, constants.CalendarUidDomain))
+		ics.line("DTSTAMP", event.CreatedAt.UTC().Format(icsDateTimeLayout))
+		ics.line("CREATED", event.CreatedAt.UTC().Format(icsDateTimeLayout))
+
+		if event.StartsAt != nil {
+			ics.line("DTSTART", event.StartsAt.UTC().Format(icsDateTimeLayout))
+
+			if event.EndsAt != nil {
+				ics.line("DTEND", event.EndsAt.UTC().Format(icsDateTimeLayout))
+			}
+		} else {
+			day := event.CreatedAt.UTC()
+
+			ics.line("DTSTART;VALUE=DATE", day.Format(icsDateLayout))
+			ics.line("DTEND;VALUE=DATE", day.AddDate(0, 0, 1).Format(icsDateLayout))
+			ics.line("TRANSP", "TRANSPARENT")
+		}
+
+		ics.line("SUMMARY", icsText(event.Title))
+
+		if len(event.Description) != 0 {
+			ics.line("DESCRIPTION", icsText(event.Description))
+		}
+
+		if location := calendarEventLocation(&event); len(location) != 0 {
+			ics.line("LOCATION", icsText(location))
+		}
+
+		if event.Recurrence != nil {
+			ics.line("RRULE", icsRecurrenceRule(event.Recurrence))
+
+			for _, exdate := range event.Recurrence.ExDates {
+				ics.line("EXDATE", exdate.UTC().Format(icsDateTimeLayout))
+			}
+		}
+
+		ics.line("CATEGORIES", icsText(strings.ToUpper(event.Category)))
+		ics.line("STATUS", "CONFIRMED")
+		ics.line("END", "VEVENT")
+
+		for _, occurrence := range event.Occurrences {
+			ics.occurrence(&event, &occurrence)
+		}
+	}
+
+	ics.line("END", "VCALENDAR")
+
+	calendar := []byte(ics.String())
+	sum := sha256.Sum256(calendar)
+
+	return &GetEventsCalendarResponse{
+		Calendar: calendar,
+		ETag:     fmt.Sprintf("%q", hex.EncodeToString(sum[:16])),
+	}
+}
+
+// occurrence рендерит измененное или отмененное повторение серии отдельным VEVENT
+// с тем же UID и RECURRENCE-ID, равным исходному началу повторения
+func (w *icsWriter) occurrence(series *dbtypes.CalendarEvent, occurrence *dbtypes.EventOccurrence) {
+	startsAt := occurrence.OccurrenceStart
+	if occurrence.StartsAt != nil {
+		startsAt = *occurrence.StartsAt
+	}
+
+	var endsAt *time.Time
+	switch {
+	case occurrence.EndsAt != nil:
+		endsAt = occurrence.EndsAt
+	case series.EndsAt != nil:
+		end := startsAt.Add(series.EndsAt.Sub(*series.StartsAt))
+		endsAt = &end
+	}
+
+	event := *series
+	if occurrence.Title != nil {
+		event.Title = *occurrence.Title
+	}
+
+	if occurrence.Description != nil {
+		event.Description = *occurrence.Description
+	}
+
+	if occurrence.Location != nil {
+		event.Location = *occurrence.Location
+	}
+
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", fmt.Sprintf("%s@%s", series.EventId, constants.CalendarUidDomain))
+	w.line("DTSTAMP", occurrence.UpdatedAt.UTC().Format(icsDateTimeLayout))
+	w.line("RECURRENCE-ID", occurrence.OccurrenceStart.UTC().Format(icsDateTimeLayout))
+	w.line("DTSTART", startsAt.UTC().Format(icsDateTimeLayout))
+
+	if endsAt != nil {
+		w.line("DTEND", endsAt.UTC().Format(icsDateTimeLayout))
+	}
+
+	w.line("SUMMARY", icsText(event.Title))
+
+	if len(event.Description) != 0 {
+		w.line("DESCRIPTION", icsText(event.Description))
+	}
+
+	if location := calendarEventLocation(&event); len(location) != 0 {
+		w.line("LOCATION", icsText(location))
+	}
+
+	if occurrence.Cancelled {
+		w.line("STATUS", "CANCELLED")
+	} else {
+		w.line("STATUS", "CONFIRMED")
+	}
+
+	w.line("END", "VEVENT")
+}
+
+// icsRecurrenceRule переводит правило повторения в значение RRULE
+func icsRecurrenceRule(recurrence *dbtypes.Recurrence) string {
+	rule := fmt.Sprintf("FREQ=%s;INTERVAL=%d", strings.ToUpper(recurrence.Freq), max(recurrence.Interval, 1))
+
+	if recurrence.Until != nil {
+		rule += ";UNTIL=" + recurrence.Until.UTC().Format(icsDateTimeLayout)
+	}
+
+	if recurrence.Count != nil {
+		rule += fmt.Sprintf(";COUNT=%d", *recurrence.Count)
+	}
+
+	return rule
+}
+
+func calendarEventLocation(event *dbtypes.CalendarEvent) string {
+	if event.RoomNumber == nil {
+		return event.Location
+	}
+
+	room := fmt.Sprintf("Комната %s", *event.RoomNumber)
+	if len(event.Location) == 0 {
+		return room
+	}
+
+	return fmt.Sprintf("%s, %s", room, event.Location)
+}
+
+// icsWriter собирает строки календаря с CRLF и переносом длинных строк
+type icsWriter struct {
+	strings.Builder
+}
+
+func (w *icsWriter) line(name, value string) {
+	line := name + ":" + value
+
+	for limit := icsMaxLineOctets; len(line) > limit; limit = icsMaxLineOctets - 1 {
+		// переносим по границе символа, чтобы не разрезать UTF-8 последовательность
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+	}
+
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+// icsText экранирует значение типа TEXT (RFC 5545 3.3.11)
+func icsText(val string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(val)
+}
+
+// ETagMatches проверяет If-None-Match: список тегов, слабые теги или *
+func ETagMatches(ifNoneMatch, etag string) bool {
+	if len(ifNoneMatch) == 0 {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,39 +1,177 @@
 package requestmodels
 
 import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dormitory-life/core/internal/constants"
 	dbtypes "github.com/dormitory-life/core/internal/database/types"
 	"github.com/dormitory-life/core/internal/storage"
 )
 
 type Dormitory struct {
-	Id           string     `json:"id"`
-	Name         string     `json:"name"`
-	Address      string     `json:"address"`
-	SupportEmail string     `json:"support_email"`
-	Description  string     `json:"description"`
-	Photos       []FileInfo `json:"photo_links"`
+	Id             string     `json:"id"`
+	Name           string     `json:"name"`
+	Address        string     `json:"address"`
+	SupportEmail   string     `json:"support_email"`
+	Description    string     `json:"description"`
+	Latitude       *float64   `json:"latitude,omitempty"`
+	Longitude      *float64   `json:"longitude,omitempty"`
+	OverallAverage float64    `json:"overall_average,omitempty"`
+	Amenities      []Amenity  `json:"amenities,omitempty"`
+	Photos         []FileInfo `json:"photo_links"`
 }
 
 type (
-	GetDormitoriesRequest  struct{}
+	GetDormitoriesRequest struct {
+		Search    string
+		MinRating *float64
+		Amenities []string
+		SortBy    string
+		SortDesc  bool
+		Page      uint64
+		PageSize  uint64
+	}
+
 	GetDormitoriesResponse struct {
 		Dormitories []Dormitory `json:"dormitories"`
+		Total       uint64      `json:"total"`
+		Page        uint64      `json:"page"`
+		PageSize    uint64      `json:"page_size"`
+		NextPage    *uint64     `json:"next_page"`
 	}
 )
 
+func (*GetDormitoriesRequest) FromUrlQuery(query url.Values) (*GetDormitoriesRequest, error) {
+	res := &GetDormitoriesRequest{
+		SortBy:   dbtypes.DormitorySortByName,
+		Page:     1,
+		PageSize: constants.DefaultDormitoriesPageSize,
+	}
+
+	if query == nil {
+		return res, nil
+	}
+
+	res.Search = strings.TrimSpace(query.Get("search"))
+
+	if val, ok := query["min_rating"]; ok {
+		floatVal, err := parseFloat64(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid min_rating param: %w", err)
+		}
+
+		if floatVal < 0 || floatVal > 5 {
+			return nil, fmt.Errorf("invalid min_rating param: must be between 0 and 5")
+		}
+
+		res.MinRating = &floatVal
+	}
+
+	if val, ok := query["amenities"]; ok {
+		amenities, err := parseAmenityCodes(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid amenities param: %w", err)
+		}
+
+		res.Amenities = amenities
+	}
+
+	if val, ok := query["sort"]; ok {
+		switch val[0] {
+		case dbtypes.DormitorySortByName:
+		case dbtypes.DormitorySortByRating:
+			// best rated first unless asked otherwise
+			res.SortDesc = true
+		default:
+			return nil, fmt.Errorf("invalid sort param: %s", val[0])
+		}
+
+		res.SortBy = val[0]
+	}
+
+	if val, ok := query["order"]; ok {
+		switch val[0] {
+		case "asc":
+			res.SortDesc = false
+		case "desc":
+			res.SortDesc = true
+		default:
+			return nil, fmt.Errorf("invalid order param: %s", val[0])
+		}
+	}
+
+	if val, ok := query["page"]; ok {
+		intVal, err := parseUint64(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid page param: %w", err)
+		}
+
+		res.Page = intVal
+	}
+
+	if val, ok := query["page_size"]; ok {
+		intVal, err := parseUint64(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid page_size param: %w", err)
+		}
+
+		res.PageSize = intVal
+	}
+
+	if res.Page == 0 {
+		res.Page = 1
+	}
+
+	if res.PageSize == 0 {
+		res.PageSize = constants.DefaultDormitoriesPageSize
+	}
+
+	if res.PageSize > constants.MaxDormitoriesPageSize {
+		res.PageSize = constants.MaxDormitoriesPageSize
+	}
+
+	return res, nil
+}
+
+// CacheKey - ключ кэша, однозначно определяемый параметрами запроса
+func (r *GetDormitoriesRequest) CacheKey() string {
+	values := url.Values{}
+	values.Set("search", strings.ToLower(r.Search))
+	values.Set("sort", r.SortBy)
+	values.Set("desc", strconv.FormatBool(r.SortDesc))
+	values.Set("page", strconv.FormatUint(r.Page, 10))
+	values.Set("page_size", strconv.FormatUint(r.PageSize, 10))
+
+	if r.MinRating != nil {
+		values.Set("min_rating", strconv.FormatFloat(*r.MinRating, 'f', -1, 64))
+	}
+
+	if len(r.Amenities) > 0 {
+		values.Set("amenities", strings.Join(r.Amenities, ","))
+	}
+
+	return values.Encode()
+}
+
 func (r *Dormitory) From(msg *dbtypes.Dormitory) *Dormitory {
 	if msg == nil {
 		return nil
 	}
 
 	return &Dormitory{
-		Id:           msg.Id,
-		Name:         msg.Name,
-		Address:      msg.Address,
-		SupportEmail: msg.SupportEmail,
-		Description:  msg.Description,
+		Id:             msg.Id,
+		Name:           msg.Name,
+		Address:        msg.Address,
+		SupportEmail:   msg.SupportEmail,
+		Description:    msg.Description,
+		Latitude:       msg.Latitude,
+		Longitude:      msg.Longitude,
+		OverallAverage: msg.OverallAverage,
 	}
 }
 
@@ -44,6 +182,7 @@ func (r *GetDormitoriesResponse) From(msg *dbtypes.GetDormitoriesResponse) *GetD
 
 	res := &GetDormitoriesResponse{
 		Dormitories: make([]Dormitory, 0),
+		Total:       msg.Total,
 	}
 
 	for _, val := range msg.Dormitories {
@@ -54,6 +193,19 @@ func (r *GetDormitoriesResponse) From(msg *dbtypes.GetDormitoriesResponse) *GetD
 	return res
 }
 
+// WithPagination заполняет информацию о текущей и следующей странице
+func (r *GetDormitoriesResponse) WithPagination(page, pageSize uint64) *GetDormitoriesResponse {
+	r.Page = page
+	r.PageSize = pageSize
+
+	if page*pageSize < r.Total {
+		nextPage := page + 1
+		r.NextPage = &nextPage
+	}
+
+	return r
+}
+
 type (
 	GetDormitoryByIdRequest struct {
 		DormitoryId string `json:"dormitory_id"`
@@ -76,6 +228,8 @@ func (r *GetDormitoryByIdResponse) From(msg *dbtypes.GetDormitoryByIdResponse) *
 			Address:      msg.Dormitory.Address,
 			SupportEmail: msg.Dormitory.SupportEmail,
 			Description:  msg.Dormitory.Description,
+			Latitude:     msg.Dormitory.Latitude,
+			Longitude:    msg.Dormitory.Longitude,
 		},
 	}
 }
@@ -105,11 +259,13 @@ func ConvertFileInfos(msg *storage.GetEntityFilesResponse) []FileInfo {
 
 type (
 	CreateDormitoryRequest struct {
-		DormitoryId  string `json:"dormitory_id"`
-		Name         string `json:"name"`
-		Address      string `json:"address"`
-		SupportEmail string `json:"support_email"`
-		Description  string `json:"description"`
+		DormitoryId  string   `json:"dormitory_id"`
+		Name         string   `json:"name"`
+		Address      string   `json:"address"`
+		SupportEmail string   `json:"support_email"`
+		Description  string   `json:"description"`
+		Latitude     *float64 `json:"latitude"`
+		Longitude    *float64 `json:"longitude"`
 	}
 
 	CreateDormitoryResponse struct {
@@ -130,10 +286,12 @@ func (r *CreateDormitoryResponse) From(msg *dbtypes.CreateDormitoryResponse) *Cr
 type (
 	UpdateDormitoryRequest struct {
 		DormitoryId  string
-		Name         *string `json:"name"`
-		Address      *string `json:"address"`
-		SupportEmail *string `json:"support_email"`
-		Description  *string `json:"description"`
+		Name         *string  `json:"name"`
+		Address      *string  `json:"address"`
+		SupportEmail *string  `json:"support_email"`
+		Description  *string  `json:"description"`
+		Latitude     *float64 `json:"latitude"`
+		Longitude    *float64 `json:"longitude"`
 	}
 
 	UpdateDormitoryResponse struct {
@@ -156,5 +314,139 @@ type (
 		DormitoryId string `json:"dormitory_id"`
 	}
 
-	DeleteDormitoryResponse struct{}
+	DeleteDormitoryResponse struct {
+		DormitoryId string `json:"dormitory_id"`
+	}
+)
+
+func (r *DeleteDormitoryResponse) From(msg *dbtypes.DeleteDormitoryResponse) *DeleteDormitoryResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &DeleteDormitoryResponse{
+		DormitoryId: msg.DormitoryId,
+	}
+}
+
+type NearbyDormitory struct {
+	Dormitory
+	DistanceKm float64 `json:"distance_km"`
+}
+
+type (
+	GetNearbyDormitoriesRequest struct {
+		Latitude  float64
+		Longitude float64
+		RadiusKm  float64
+	}
+
+	GetNearbyDormitoriesResponse struct {
+		Dormitories []NearbyDormitory `json:"dormitories"`
+	}
 )
+
+func (*GetNearbyDormitoriesRequest) FromUrlQuery(query url.Values) (*GetNearbyDormitoriesRequest, error) {
+	res := &GetNearbyDormitoriesRequest{
+		RadiusKm: constants.DefaultNearbyRadiusKm,
+	}
+
+	lat, err := parseRequiredFloat64(query, "lat")
+	if err != nil {
+		return nil, err
+	}
+
+	lon, err := parseRequiredFloat64(query, "lon")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateCoordinates(&lat, &lon); err != nil {
+		return nil, err
+	}
+
+	res.Latitude = lat
+	res.Longitude = lon
+
+	if val, ok := query["radius_km"]; ok {
+		floatVal, err := parseFloat64(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid radius_km param: %w", err)
+		}
+
+		if floatVal <= 0 || floatVal > constants.MaxNearbyRadiusKm {
+			return nil, fmt.Errorf("invalid radius_km param: must be in (0, %g]", constants.MaxNearbyRadiusKm)
+		}
+
+		res.RadiusKm = floatVal
+	}
+
+	return res, nil
+}
+
+func (*GetNearbyDormitoriesResponse) From(msg *dbtypes.GetNearbyDormitoriesResponse) *GetNearbyDormitoriesResponse {
+	if msg == nil {
+		return nil
+	}
+
+	res := &GetNearbyDormitoriesResponse{
+		Dormitories: make([]NearbyDormitory, 0),
+	}
+
+	for _, val := range msg.Dormitories {
+		res.Dormitories = append(res.Dormitories, NearbyDormitory{
+			Dormitory:  *new(Dormitory).From(&val.Dormitory),
+			DistanceKm: val.DistanceKm,
+		})
+	}
+
+	return res
+}
+
+// ValidateCoordinates проверяет, что широта и долгота заданы вместе и лежат в допустимых границах
+func ValidateCoordinates(latitude, longitude *float64) error {
+	if (latitude == nil) != (longitude == nil) {
+		return fmt.Errorf("latitude and longitude must be set together")
+	}
+
+	if latitude == nil {
+		return nil
+	}
+
+	if *latitude < -90 || *latitude > 90 {
+		return fmt.Errorf("invalid latitude: must be between -90 and 90")
+	}
+
+	if *longitude < -180 || *longitude > 180 {
+		return fmt.Errorf("invalid longitude: must be between -180 and 180")
+	}
+
+	return nil
+}
+
+func parseRequiredFloat64(query url.Values, name string) (float64, error) {
+	val, ok := query[name]
+	if !ok || val[0] == "" {
+		return 0, fmt.Errorf("missing %s param", name)
+	}
+
+	floatVal, err := parseFloat64(val[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s param: %w", name, err)
+	}
+
+	return floatVal, nil
+}
+
+func parseFloat64(val string) (float64, error) {
+	floatVal, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if math.IsNaN(floatVal) || math.IsInf(floatVal, 0) {
+		return 0, fmt.Errorf("value must be a finite number")
+	}
+
+	return floatVal, nil
+}
@@ -0,0 +1,149 @@
+package requestmodels
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+type RsvpCounts struct {
+	Going      int `json:"going"`
+	Maybe      int `json:"maybe"`
+	NotGoing   int `json:"not_going"`
+	Waitlisted int `json:"waitlisted"`
+}
+
+func (r *RsvpCounts) From(msg *dbtypes.EventRsvpCounts) *RsvpCounts {
+	if msg == nil {
+		return nil
+	}
+
+	return &RsvpCounts{
+		Going:      msg.Going,
+		Maybe:      msg.Maybe,
+		NotGoing:   msg.NotGoing,
+		Waitlisted: msg.Waitlisted,
+	}
+}
+
+type (
+	RsvpEventRequest struct {
+		DormitoryId string
+		EventId     string
+		// Status - going, maybe или not_going. Если мест нет, going ставит в лист ожидания
+		Status string `json:"status"`
+	}
+
+	RsvpEventResponse struct {
+		EventId string `json:"event_id"`
+		// Status - итоговый статус: going, maybe, not_going, waitlisted или пусто, если ответ снят
+		Status string     `json:"status"`
+		Counts RsvpCounts `json:"counts"`
+	}
+)
+
+func (r *RsvpEventRequest) Validate() error {
+	switch r.Status {
+	case dbtypes.RsvpStatusGoing, dbtypes.RsvpStatusMaybe, dbtypes.RsvpStatusNotGoing:
+		return nil
+	default:
+		return fmt.Errorf("invalid status: %q", r.Status)
+	}
+}
+
+func (r *RsvpEventResponse) From(msg *dbtypes.RsvpEventResponse) *RsvpEventResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &RsvpEventResponse{
+		EventId: msg.EventId,
+		Status:  msg.Status,
+		Counts:  *new(RsvpCounts).From(&msg.Counts),
+	}
+}
+
+type DeleteEventRsvpRequest struct {
+	DormitoryId string
+	EventId     string
+}
+
+type EventAttendee struct {
+	UserId string `json:"user_id"`
+	Email  string `json:"email"`
+	Status string `json:"status"`
+	// WaitlistPosition - место в листе ожидания, начиная с 1
+	WaitlistPosition int       `json:"waitlist_position,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type (
+	GetEventAttendeesRequest struct {
+		DormitoryId string
+		EventId     string
+		Status      string
+	}
+
+	GetEventAttendeesResponse struct {
+		EventId   string          `json:"event_id"`
+		Capacity  *int            `json:"capacity,omitempty"`
+		Counts    RsvpCounts      `json:"counts"`
+		Attendees []EventAttendee `json:"attendees"`
+	}
+)
+
+func (*GetEventAttendeesRequest) FromUrlQuery(query url.Values) (*GetEventAttendeesRequest, error) {
+	res := &GetEventAttendeesRequest{}
+
+	if val, ok := query["status"]; ok {
+		switch val[0] {
+		case dbtypes.RsvpStatusGoing,
+			dbtypes.RsvpStatusMaybe,
+			dbtypes.RsvpStatusNotGoing,
+			dbtypes.RsvpStatusWaitlisted:
+			res.Status = val[0]
+		default:
+			return nil, fmt.Errorf("invalid status param: %s", val[0])
+		}
+	}
+
+	return res, nil
+}
+
+func (r *GetEventAttendeesResponse) From(eventId string, msg *dbtypes.GetEventAttendeesResponse) *GetEventAttendeesResponse {
+	if msg == nil {
+		return nil
+	}
+
+	res := &GetEventAttendeesResponse{
+		EventId:   eventId,
+		Capacity:  msg.Capacity,
+		Counts:    *new(RsvpCounts).From(&msg.Counts),
+		Attendees: make([]EventAttendee, 0, len(msg.Attendees)),
+	}
+
+	// лист ожидания приходит в порядке очереди
+	var waitlistPosition int
+
+	for _, val := range msg.Attendees {
+		attendee := EventAttendee{
+			UserId:    val.UserId,
+			Email:     val.Email,
+			Status:    val.Status,
+			CreatedAt: val.CreatedAt,
+			UpdatedAt: val.UpdatedAt,
+		}
+
+		if val.Status == dbtypes.RsvpStatusWaitlisted {
+			waitlistPosition++
+			attendee.WaitlistPosition = waitlistPosition
+		}
+
+		res.Attendees = append(res.Attendees, attendee)
+	}
+
+	return res
+}
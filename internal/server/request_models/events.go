@@ -4,11 +4,96 @@ import (
 	"fmt"
 	"mime/multipart"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	dbtypes "github.com/dormitory-life/core/internal/database/types"
 )
 
+const (
+	maxEventLocationLength = 200
+	maxRecurrenceCount     = 366
+)
+
+// Recurrence - правило повторения события: частота, шаг, окончание и исключенные даты
+type Recurrence struct {
+	// Freq - daily, weekly или monthly
+	Freq     string     `json:"freq"`
+	Interval int        `json:"interval"`
+	Until    *time.Time `json:"until,omitempty"`
+	Count    *int       `json:"count,omitempty"`
+	// ExDates - начала повторений, которые не состоятся
+	ExDates []time.Time `json:"exdates"`
+}
+
+func (r *Recurrence) From(msg *dbtypes.Recurrence) *Recurrence {
+	if msg == nil {
+		return nil
+	}
+
+	res := &Recurrence{
+		Freq:     msg.Freq,
+		Interval: msg.Interval,
+		Until:    msg.Until,
+		Count:    msg.Count,
+		ExDates:  make([]time.Time, 0, len(msg.ExDates)),
+	}
+
+	res.ExDates = append(res.ExDates, msg.ExDates...)
+
+	return res
+}
+
+func (r *Recurrence) ToDB() *dbtypes.Recurrence {
+	if r == nil {
+		return nil
+	}
+
+	return &dbtypes.Recurrence{
+		Freq:     r.Freq,
+		Interval: r.Interval,
+		Until:    r.Until,
+		Count:    r.Count,
+		ExDates:  r.ExDates,
+	}
+}
+
+func (r *Recurrence) Validate(startsAt *time.Time) error {
+	if startsAt == nil {
+		return fmt.Errorf("recurrence requires starts_at")
+	}
+
+	switch r.Freq {
+	case dbtypes.RecurrenceFreqDaily, dbtypes.RecurrenceFreqWeekly, dbtypes.RecurrenceFreqMonthly:
+	default:
+		return fmt.Errorf("invalid recurrence freq: %q", r.Freq)
+	}
+
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+
+	if r.Interval < 0 {
+		return fmt.Errorf("recurrence interval must be positive")
+	}
+
+	if r.Until != nil && r.Count != nil {
+		return fmt.Errorf("recurrence until and count are mutually exclusive")
+	}
+
+	if r.Until != nil && r.Until.Before(*startsAt) {
+		return fmt.Errorf("recurrence until must not be before starts_at")
+	}
+
+	if r.Count != nil && (*r.Count <= 0 || *r.Count > maxRecurrenceCount) {
+		return fmt.Errorf("recurrence count must be between 1 and %d", maxRecurrenceCount)
+	}
+
+	return nil
+}
+
 type Event struct {
 	EventId     string     `json:"event_id"`
 	DormitoryId string     `json:"dormitory_id"`
@@ -16,12 +101,39 @@ type Event struct {
 	Description string     `json:"description"`
 	EventPhotos []FileInfo `json:"event_photos"`
 	CreatedAt   time.Time  `json:"created_at"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	// RoomId - комната общежития, Location - название общей зоны или уточнение места
+	RoomId   *string `json:"room_id,omitempty"`
+	Location string  `json:"location"`
+	Category string  `json:"category"`
+	// Capacity - максимальное число участников, nil - без ограничений
+	Capacity *int       `json:"capacity,omitempty"`
+	Rsvp     RsvpCounts `json:"rsvp"`
+	// MyRsvp - ответ текущего пользователя, пусто, если он не отвечал или не авторизован
+	MyRsvp     string      `json:"my_rsvp,omitempty"`
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+	// OccurrenceStart - исходное начало повторения серии, по нему повторение меняют и отменяют
+	OccurrenceStart *time.Time `json:"occurrence_start,omitempty"`
+	Cancelled       bool       `json:"cancelled,omitempty"`
+	Pinned          bool       `json:"pinned"`
+	PinnedAt        *time.Time `json:"pinned_at,omitempty"`
+	// ExpiresAt - после этого времени пост пропадает из ленты по умолчанию
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Urgent    bool       `json:"urgent"`
+	UpdatedAt time.Time  `json:"updated_at"`
 }
 
 type (
 	GetDormitoryEventsRequest struct {
 		DormitoryId string
 		Page        uint64
+		// Period - upcoming или past, пусто - вся лента
+		Period string
+		From   *time.Time
+		To     *time.Time
+		// IncludeExpired - вернуть и посты с истекшим сроком показа
+		IncludeExpired bool
 	}
 
 	GetDormitoryEventsResponse struct {
@@ -51,20 +163,95 @@ func (*GetDormitoryEventsRequest) FromUrlQuery(query url.Values) (*GetDormitoryE
 		res.Page = 1
 	}
 
+	if val, ok := query["period"]; ok {
+		switch val[0] {
+		case dbtypes.EventsPeriodUpcoming, dbtypes.EventsPeriodPast:
+			res.Period = val[0]
+		default:
+			return nil, fmt.Errorf("invalid period param: %s", val[0])
+		}
+	}
+
+	if val, ok := query["from"]; ok {
+		from, err := parseEventTime(val[0], false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from param: %w", err)
+		}
+
+		res.From = &from
+	}
+
+	if val, ok := query["to"]; ok {
+		to, err := parseEventTime(val[0], true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to param: %w", err)
+		}
+
+		res.To = &to
+	}
+
+	if res.From != nil && res.To != nil && !res.From.Before(*res.To) {
+		return nil, fmt.Errorf("from must be before to")
+	}
+
+	if val, ok := query["include_expired"]; ok {
+		includeExpired, err := strconv.ParseBool(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid include_expired param: %w", err)
+		}
+
+		res.IncludeExpired = includeExpired
+	}
+
 	return res, nil
 }
 
+// parseEventTime разбирает время в RFC 3339 или дату YYYY-MM-DD.
+// Дата в конце интервала включается в него целиком
+func parseEventTime(val string, endOfRange bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, val)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC 3339 time or YYYY-MM-DD, got %q", val)
+	}
+
+	if endOfRange {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t, nil
+}
+
 func (r *Event) From(msg *dbtypes.Event) *Event {
 	if msg == nil {
 		return nil
 	}
 
 	return &Event{
-		EventId:     msg.EventId,
-		DormitoryId: msg.DormitoryId,
-		Title:       msg.Title,
-		Description: msg.Description,
-		CreatedAt:   msg.CreatedAt,
+		EventId:         msg.EventId,
+		DormitoryId:     msg.DormitoryId,
+		Title:           msg.Title,
+		Description:     msg.Description,
+		CreatedAt:       msg.CreatedAt,
+		StartsAt:        msg.StartsAt,
+		EndsAt:          msg.EndsAt,
+		RoomId:          msg.RoomId,
+		Location:        msg.Location,
+		Category:        msg.Category,
+		Capacity:        msg.Capacity,
+		Rsvp:            *new(RsvpCounts).From(&msg.RsvpCounts),
+		MyRsvp:          msg.ViewerRsvp,
+		Recurrence:      new(Recurrence).From(msg.Recurrence),
+		OccurrenceStart: msg.OccurrenceStart,
+		Cancelled:       msg.Cancelled,
+		Pinned:          msg.PinnedAt != nil,
+		PinnedAt:        msg.PinnedAt,
+		ExpiresAt:       msg.ExpiresAt,
+		Urgent:          msg.Urgent,
+		UpdatedAt:       msg.UpdatedAt,
 	}
 }
 
@@ -91,6 +278,17 @@ type (
 		PhotoFilesHeaders []*multipart.FileHeader
 		Title             string
 		Description       string
+		StartsAt          *time.Time
+		EndsAt            *time.Time
+		RoomId            *string
+		Location          string
+		Category          string
+		Capacity          *int
+		Recurrence        *Recurrence
+		Pinned            bool
+		ExpiresAt         *time.Time
+		// Urgent - разослать пост письмом всем жильцам общежития
+		Urgent bool
 	}
 
 	CreateDormitoryEventResponse struct {
@@ -99,9 +297,144 @@ type (
 		CreatePhotoResponses []CreatePhotoResponse `json:"photos"`
 		Title                string                `json:"title"`
 		Description          string                `json:"description"`
+		StartsAt             *time.Time            `json:"starts_at,omitempty"`
+		EndsAt               *time.Time            `json:"ends_at,omitempty"`
+		RoomId               *string               `json:"room_id,omitempty"`
+		Location             string                `json:"location"`
+		Category             string                `json:"category"`
+		Capacity             *int                  `json:"capacity,omitempty"`
+		Recurrence           *Recurrence           `json:"recurrence,omitempty"`
+		Pinned               bool                  `json:"pinned"`
+		ExpiresAt            *time.Time            `json:"expires_at,omitempty"`
+		Urgent               bool                  `json:"urgent"`
 	}
 )
 
+func (r *CreateDormitoryEventRequest) Validate() error {
+	if r.Category == "" {
+		r.Category = dbtypes.EventCategoryOther
+	}
+
+	if err := validateEventCategory(r.Category); err != nil {
+		return err
+	}
+
+	if r.EndsAt != nil {
+		if r.StartsAt == nil {
+			return fmt.Errorf("ends_at requires starts_at")
+		}
+
+		if r.EndsAt.Before(*r.StartsAt) {
+			return fmt.Errorf("ends_at must not be before starts_at")
+		}
+	}
+
+	if r.Capacity != nil && *r.Capacity <= 0 {
+		return fmt.Errorf("capacity must be positive")
+	}
+
+	if utf8.RuneCountInString(r.Location) > maxEventLocationLength {
+		return fmt.Errorf("location is longer than %d characters", maxEventLocationLength)
+	}
+
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("expires_at must be in the future")
+	}
+
+	if r.Recurrence != nil {
+		return r.Recurrence.Validate(r.StartsAt)
+	}
+
+	return nil
+}
+
+func validateEventCategory(category string) error {
+	switch category {
+	case dbtypes.EventCategoryMeeting,
+		dbtypes.EventCategoryCleaning,
+		dbtypes.EventCategorySport,
+		dbtypes.EventCategoryCulture,
+		dbtypes.EventCategoryEducation,
+		dbtypes.EventCategoryParty,
+		dbtypes.EventCategoryOther:
+		return nil
+	default:
+		return fmt.Errorf("invalid category: %q", category)
+	}
+}
+
+type (
+	// UpdateDormitoryEventRequest меняет только переданные поля события и его фото.
+	// Clear* поля снимают время окончания, комнату и ограничение числа участников
+	UpdateDormitoryEventRequest struct {
+		DormitoryId       string
+		EventId           string
+		Title             *string
+		Description       *string
+		StartsAt          *time.Time
+		EndsAt            *time.Time
+		ClearEndsAt       bool
+		RoomId            *string
+		ClearRoomId       bool
+		Location          *string
+		Category          *string
+		Capacity          *int
+		ClearCapacity     bool
+		PhotoFilesHeaders []*multipart.FileHeader
+		// RemovePhotos - пути или имена файлов удаляемых фото
+		RemovePhotos []string
+	}
+
+	UpdateDormitoryEventResponse struct {
+		Event
+		// PromotedUserIds - участники из листа ожидания, получившие место после увеличения вместимости
+		PromotedUserIds []string `json:"promoted_user_ids,omitempty"`
+	}
+)
+
+func (r *UpdateDormitoryEventRequest) Validate() error {
+	if r.Title != nil && len(strings.TrimSpace(*r.Title)) == 0 {
+		return fmt.Errorf("title must not be empty")
+	}
+
+	if r.Category != nil {
+		if err := validateEventCategory(*r.Category); err != nil {
+			return err
+		}
+	}
+
+	if r.StartsAt != nil && r.EndsAt != nil && r.EndsAt.Before(*r.StartsAt) {
+		return fmt.Errorf("ends_at must not be before starts_at")
+	}
+
+	if r.Capacity != nil && *r.Capacity <= 0 {
+		return fmt.Errorf("capacity must be positive")
+	}
+
+	if r.Location != nil && utf8.RuneCountInString(*r.Location) > maxEventLocationLength {
+		return fmt.Errorf("location is longer than %d characters", maxEventLocationLength)
+	}
+
+	if r.Title == nil && r.Description == nil && r.StartsAt == nil && r.EndsAt == nil && !r.ClearEndsAt &&
+		r.RoomId == nil && !r.ClearRoomId && r.Location == nil && r.Category == nil &&
+		r.Capacity == nil && !r.ClearCapacity && len(r.PhotoFilesHeaders) == 0 && len(r.RemovePhotos) == 0 {
+		return fmt.Errorf("nothing to update")
+	}
+
+	return nil
+}
+
+func (r *UpdateDormitoryEventResponse) From(msg *dbtypes.UpdateDormitoryEventResponse) *UpdateDormitoryEventResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &UpdateDormitoryEventResponse{
+		Event:           *new(Event).From(&msg.Event),
+		PromotedUserIds: msg.PromotedUserIds,
+	}
+}
+
 type (
 	DeleteDormitoryEventRequest struct {
 		DormitoryId string
@@ -111,3 +444,144 @@ type (
 	DeleteDormitoryEventResponse struct {
 	}
 )
+
+type EventOccurrence struct {
+	EventId         string     `json:"event_id"`
+	OccurrenceStart time.Time  `json:"occurrence_start"`
+	Cancelled       bool       `json:"cancelled"`
+	Title           *string    `json:"title,omitempty"`
+	Description     *string    `json:"description,omitempty"`
+	StartsAt        *time.Time `json:"starts_at,omitempty"`
+	EndsAt          *time.Time `json:"ends_at,omitempty"`
+	Location        *string    `json:"location,omitempty"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type (
+	// UpdateEventOccurrenceRequest меняет одно повторение серии, переданы могут быть не все поля
+	UpdateEventOccurrenceRequest struct {
+		DormitoryId     string
+		EventId         string
+		OccurrenceStart time.Time
+		Title           *string    `json:"title"`
+		Description     *string    `json:"description"`
+		StartsAt        *time.Time `json:"starts_at"`
+		EndsAt          *time.Time `json:"ends_at"`
+		Location        *string    `json:"location"`
+		// Cancelled - true отменяет повторение, false возвращает его
+		Cancelled *bool `json:"cancelled"`
+	}
+
+	UpdateEventOccurrenceResponse struct {
+		Occurrence EventOccurrence `json:"occurrence"`
+	}
+)
+
+func (r *UpdateEventOccurrenceRequest) Validate() error {
+	if r.Title != nil && len(*r.Title) == 0 {
+		return fmt.Errorf("empty title")
+	}
+
+	if r.Location != nil && utf8.RuneCountInString(*r.Location) > maxEventLocationLength {
+		return fmt.Errorf("location is longer than %d characters", maxEventLocationLength)
+	}
+
+	if r.StartsAt != nil && r.EndsAt != nil && r.EndsAt.Before(*r.StartsAt) {
+		return fmt.Errorf("ends_at must not be before starts_at")
+	}
+
+	return nil
+}
+
+func (r *UpdateEventOccurrenceResponse) From(msg *dbtypes.UpdateEventOccurrenceResponse) *UpdateEventOccurrenceResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &UpdateEventOccurrenceResponse{
+		Occurrence: EventOccurrence{
+			EventId:         msg.Occurrence.EventId,
+			OccurrenceStart: msg.Occurrence.OccurrenceStart,
+			Cancelled:       msg.Occurrence.Cancelled,
+			Title:           msg.Occurrence.Title,
+			Description:     msg.Occurrence.Description,
+			StartsAt:        msg.Occurrence.StartsAt,
+			EndsAt:          msg.Occurrence.EndsAt,
+			Location:        msg.Occurrence.Location,
+			UpdatedAt:       msg.Occurrence.UpdatedAt,
+		},
+	}
+}
+
+type CancelEventOccurrenceRequest struct {
+	DormitoryId     string
+	EventId         string
+	OccurrenceStart time.Time
+}
+
+// ParseOccurrenceStart разбирает начало повторения из пути запроса в формате RFC 3339
+func ParseOccurrenceStart(val string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid occurrence start, expected RFC 3339: %v", err)
+	}
+
+	return t, nil
+}
+
+type (
+	// UpdateEventAnnouncementRequest закрепляет пост, меняет срок показа или делает пост срочным.
+	// Переданы могут быть не все поля
+	UpdateEventAnnouncementRequest struct {
+		DormitoryId string
+		EventId     string
+		Pinned      *bool      `json:"pinned"`
+		ExpiresAt   *time.Time `json:"expires_at"`
+		// NoExpiry - снять срок показа, пост остается в ленте бессрочно
+		NoExpiry bool `json:"no_expiry"`
+		// Urgent - true рассылает пост жильцам письмом, если он не был срочным
+		Urgent *bool `json:"urgent"`
+	}
+
+	UpdateEventAnnouncementResponse struct {
+		EventId     string     `json:"event_id"`
+		Title       string     `json:"title"`
+		Description string     `json:"description"`
+		Pinned      bool       `json:"pinned"`
+		PinnedAt    *time.Time `json:"pinned_at,omitempty"`
+		ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+		Urgent      bool       `json:"urgent"`
+	}
+)
+
+func (r *UpdateEventAnnouncementRequest) Validate() error {
+	if r.Pinned == nil && r.ExpiresAt == nil && !r.NoExpiry && r.Urgent == nil {
+		return fmt.Errorf("nothing to update")
+	}
+
+	if r.NoExpiry && r.ExpiresAt != nil {
+		return fmt.Errorf("expires_at and no_expiry are mutually exclusive")
+	}
+
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("expires_at must be in the future")
+	}
+
+	return nil
+}
+
+func (r *UpdateEventAnnouncementResponse) From(msg *dbtypes.UpdateEventAnnouncementResponse) *UpdateEventAnnouncementResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &UpdateEventAnnouncementResponse{
+		EventId:     msg.EventId,
+		Title:       msg.Title,
+		Description: msg.Description,
+		Pinned:      msg.PinnedAt != nil,
+		PinnedAt:    msg.PinnedAt,
+		ExpiresAt:   msg.ExpiresAt,
+		Urgent:      msg.Urgent,
+	}
+}
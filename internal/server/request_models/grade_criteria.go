@@ -0,0 +1,184 @@
+package requestmodels
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+const DefaultCriterionLang = "ru"
+
+var (
+	criterionCodeRegexp = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+	criterionLangRegexp = regexp.MustCompile(`^[a-z]{2}$`)
+)
+
+type GradeCriterion struct {
+	Code string `json:"code"`
+	// Label - метка на языке запроса, при отсутствии перевода - на русском
+	Label    string            `json:"label"`
+	Labels   map[string]string `json:"labels"`
+	Weight   float64           `json:"weight"`
+	Position int               `json:"position"`
+	Active   bool              `json:"active"`
+}
+
+type (
+	GetGradeCriteriaRequest struct {
+		Lang string
+		// All - включая отключенные критерии
+		All bool
+	}
+
+	GetGradeCriteriaResponse struct {
+		Criteria []GradeCriterion `json:"criteria"`
+	}
+)
+
+func (*GetGradeCriteriaRequest) FromUrlQuery(query url.Values) (*GetGradeCriteriaRequest, error) {
+	res := &GetGradeCriteriaRequest{
+		Lang: DefaultCriterionLang,
+	}
+
+	if val, ok := query["lang"]; ok {
+		if !criterionLangRegexp.MatchString(val[0]) {
+			return nil, fmt.Errorf("invalid lang param: %s", val[0])
+		}
+
+		res.Lang = val[0]
+	}
+
+	if val, ok := query["all"]; ok {
+		res.All = val[0] == "true"
+	}
+
+	return res, nil
+}
+
+func (r *GetGradeCriteriaResponse) From(lang string, msg *dbtypes.GetGradeCriteriaResponse) *GetGradeCriteriaResponse {
+	if msg == nil {
+		return nil
+	}
+
+	res := &GetGradeCriteriaResponse{
+		Criteria: make([]GradeCriterion, 0, len(msg.Criteria)),
+	}
+
+	for _, val := range msg.Criteria {
+		label, ok := val.Labels[lang]
+		if !ok {
+			label = val.Labels[DefaultCriterionLang]
+		}
+
+		res.Criteria = append(res.Criteria, GradeCriterion{
+			Code:     val.Code,
+			Label:    label,
+			Labels:   val.Labels,
+			Weight:   val.Weight,
+			Position: val.Position,
+			Active:   val.Active,
+		})
+	}
+
+	return res
+}
+
+type (
+	CreateGradeCriterionRequest struct {
+		Code     string            `json:"code"`
+		Labels   map[string]string `json:"labels"`
+		Weight   *float64          `json:"weight"`
+		Position int               `json:"position"`
+	}
+
+	CreateGradeCriterionResponse struct {
+		Code string `json:"code"`
+	}
+)
+
+func (r *CreateGradeCriterionRequest) Validate() error {
+	if !criterionCodeRegexp.MatchString(r.Code) {
+		return fmt.Errorf("invalid criterion code: %q", r.Code)
+	}
+
+	if strings.TrimSpace(r.Labels[DefaultCriterionLang]) == "" {
+		return fmt.Errorf("label for %q is required", DefaultCriterionLang)
+	}
+
+	if err := validateCriterionLabels(r.Labels); err != nil {
+		return err
+	}
+
+	if r.Weight != nil && *r.Weight <= 0 {
+		return fmt.Errorf("weight must be positive")
+	}
+
+	return nil
+}
+
+func (r *CreateGradeCriterionResponse) From(msg *dbtypes.CreateGradeCriterionResponse) *CreateGradeCriterionResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &CreateGradeCriterionResponse{
+		Code: msg.Code,
+	}
+}
+
+type (
+	UpdateGradeCriterionRequest struct {
+		Code     string
+		Labels   map[string]string `json:"labels"`
+		Weight   *float64          `json:"weight"`
+		Position *int              `json:"position"`
+		Active   *bool             `json:"active"`
+	}
+
+	UpdateGradeCriterionResponse struct {
+		Code string `json:"code"`
+	}
+)
+
+func (r *UpdateGradeCriterionRequest) Validate() error {
+	if len(r.Labels) == 0 && r.Weight == nil && r.Position == nil && r.Active == nil {
+		return fmt.Errorf("nothing to update")
+	}
+
+	if err := validateCriterionLabels(r.Labels); err != nil {
+		return err
+	}
+
+	if r.Weight != nil && *r.Weight <= 0 {
+		return fmt.Errorf("weight must be positive")
+	}
+
+	return nil
+}
+
+func (r *UpdateGradeCriterionResponse) From(msg *dbtypes.UpdateGradeCriterionResponse) *UpdateGradeCriterionResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &UpdateGradeCriterionResponse{
+		Code: msg.Code,
+	}
+}
+
+func validateCriterionLabels(labels map[string]string) error {
+	for lang, label := range labels {
+		if !criterionLangRegexp.MatchString(lang) {
+			return fmt.Errorf("invalid label language: %q", lang)
+		}
+
+		if strings.TrimSpace(label) == "" {
+			return fmt.Errorf("empty label for %q", lang)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package requestmodels
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+type ModerationGrade struct {
+	GradeId     string         `json:"grade_id"`
+	UserId      string         `json:"user_id"`
+	Scores      map[string]int `json:"scores"`
+	Status      string         `json:"status"`
+	FlagReasons []string       `json:"flag_reasons"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type (
+	GetModerationGradesRequest struct {
+		DormitoryId string
+		Status      string
+		Page        uint64
+	}
+
+	GetModerationGradesResponse struct {
+		Grades []ModerationGrade `json:"grades"`
+		Total  int               `json:"total"`
+		Page   uint64            `json:"page"`
+	}
+)
+
+func (*GetModerationGradesRequest) FromUrlQuery(query url.Values) (*GetModerationGradesRequest, error) {
+	res := &GetModerationGradesRequest{
+		Status: dbtypes.GradeStatusFlagged,
+		Page:   1,
+	}
+
+	if val, ok := query["status"]; ok {
+		switch val[0] {
+		case dbtypes.GradeStatusFlagged, dbtypes.GradeStatusRejected:
+			res.Status = val[0]
+		default:
+			return nil, fmt.Errorf("invalid status param: %s", val[0])
+		}
+	}
+
+	if val, ok := query["page"]; ok {
+		intVal, err := parseUint64(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid page param: %w", err)
+		}
+
+		res.Page = intVal
+	}
+
+	if res.Page == 0 {
+		res.Page = 1
+	}
+
+	return res, nil
+}
+
+func (r *GetModerationGradesResponse) From(page uint64, msg *dbtypes.GetModerationGradesResponse) *GetModerationGradesResponse {
+	if msg == nil {
+		return nil
+	}
+
+	res := &GetModerationGradesResponse{
+		Grades: make([]ModerationGrade, 0, len(msg.Grades)),
+		Total:  msg.Total,
+		Page:   page,
+	}
+
+	for _, val := range msg.Grades {
+		res.Grades = append(res.Grades, ModerationGrade{
+			GradeId:     val.Id,
+			UserId:      val.UserId,
+			Scores:      val.Scores,
+			Status:      val.Status,
+			FlagReasons: val.FlagReasons,
+			CreatedAt:   val.CreatedAt,
+			UpdatedAt:   val.UpdatedAt,
+		})
+	}
+
+	return res
+}
+
+type (
+	ModerateGradeRequest struct {
+		DormitoryId string
+		GradeId     string
+		// Status - approved, чтобы учесть оценку в средних, или rejected
+		Status string `json:"status"`
+	}
+
+	ModerateGradeResponse struct {
+		GradeId string `json:"grade_id"`
+		Status  string `json:"status"`
+	}
+)
+
+func (r *ModerateGradeRequest) Validate() error {
+	switch r.Status {
+	case dbtypes.GradeStatusApproved, dbtypes.GradeStatusRejected:
+		return nil
+	default:
+		return fmt.Errorf("invalid status: %q", r.Status)
+	}
+}
+
+func (r *ModerateGradeResponse) From(msg *dbtypes.ModerateGradeResponse) *ModerateGradeResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &ModerateGradeResponse{
+		GradeId: msg.GradeId,
+		Status:  msg.Status,
+	}
+}
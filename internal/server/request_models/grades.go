@@ -1,6 +1,11 @@
 package requestmodels
 
 import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	dbtypes "github.com/dormitory-life/core/internal/database/types"
@@ -11,52 +16,92 @@ type Grade struct {
 	DormitoryId string `json:"dormitory_id"`
 	UserId      string `json:"user_id"`
 
-	BathroomCleanliness       int `json:"bathroom_cleanliness"`
-	CorridorCleanliness       int `json:"corridor_cleanliness"`
-	KitchenCleanliness        int `json:"kitchen_cleanliness"`
-	CleaningFrequency         int `json:"cleaning_frequency"`
-	RoomSpaciousness          int `json:"room_spaciousness"`
-	CorridorSpaciousness      int `json:"corridor_spaciousness"`
-	KitchenSpaciousness       int `json:"kitchen_spaciousness"`
-	ShowerLocationConvenience int `json:"shower_location_convenience"`
-	EquipmentMaintenance      int `json:"equipment_maintenance"`
-	WindowCondition           int `json:"window_condition"`
-	NoiseIsolation            int `json:"noise_isolation"`
-	CommonAreasEquipment      int `json:"common_areas_equipment"`
-	TransportAccessibility    int `json:"transport_accessibility"`
-	AdministrationQuality     int `json:"administration_quality"`
-	ResidentsCultureLevel     int `json:"residents_culture_level"`
+	Scores map[string]int `json:"scores"`
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// LegacyGradeScores - прежний формат оценки с фиксированным набором из 15 критериев.
+// Поддерживается для старых клиентов, новые критерии передаются только через scores
+type LegacyGradeScores struct {
+	BathroomCleanliness       int `json:"bathroom_cleanliness,omitempty"`
+	CorridorCleanliness       int `json:"corridor_cleanliness,omitempty"`
+	KitchenCleanliness        int `json:"kitchen_cleanliness,omitempty"`
+	CleaningFrequency         int `json:"cleaning_frequency,omitempty"`
+	RoomSpaciousness          int `json:"room_spaciousness,omitempty"`
+	CorridorSpaciousness      int `json:"corridor_spaciousness,omitempty"`
+	KitchenSpaciousness       int `json:"kitchen_spaciousness,omitempty"`
+	ShowerLocationConvenience int `json:"shower_location_convenience,omitempty"`
+	EquipmentMaintenance      int `json:"equipment_maintenance,omitempty"`
+	WindowCondition           int `json:"window_condition,omitempty"`
+	NoiseIsolation            int `json:"noise_isolation,omitempty"`
+	CommonAreasEquipment      int `json:"common_areas_equipment,omitempty"`
+	TransportAccessibility    int `json:"transport_accessibility,omitempty"`
+	AdministrationQuality     int `json:"administration_quality,omitempty"`
+	ResidentsCultureLevel     int `json:"residents_culture_level,omitempty"`
+}
+
+func (l *LegacyGradeScores) scores() map[string]int {
+	legacy := map[string]int{
+		"bathroom_cleanliness":        l.BathroomCleanliness,
+		"corridor_cleanliness":        l.CorridorCleanliness,
+		"kitchen_cleanliness":         l.KitchenCleanliness,
+		"cleaning_frequency":          l.CleaningFrequency,
+		"room_spaciousness":           l.RoomSpaciousness,
+		"corridor_spaciousness":       l.CorridorSpaciousness,
+		"kitchen_spaciousness":        l.KitchenSpaciousness,
+		"shower_location_convenience": l.ShowerLocationConvenience,
+		"equipment_maintenance":       l.EquipmentMaintenance,
+		"window_condition":            l.WindowCondition,
+		"noise_isolation":             l.NoiseIsolation,
+		"common_areas_equipment":      l.CommonAreasEquipment,
+		"transport_accessibility":     l.TransportAccessibility,
+		"administration_quality":      l.AdministrationQuality,
+		"residents_culture_level":     l.ResidentsCultureLevel,
+	}
+
+	res := make(map[string]int, len(legacy))
+	for code, score := range legacy {
+		// zero means the legacy field was not sent
+		if score != 0 {
+			res[code] = score
+		}
+	}
+
+	return res
+}
+
+// mergeGradeScores объединяет оценки из legacy-полей и scores, при совпадении кода приоритет у scores
+func mergeGradeScores(legacy *LegacyGradeScores, scores map[string]int) map[string]int {
+	res := legacy.scores()
+	for code, score := range scores {
+		res[code] = score
+	}
+
+	return res
+}
+
 type (
 	CreateDormitoryGradeRequest struct {
 		DormitoryId string
 
-		BathroomCleanliness       int `json:"bathroom_cleanliness"`
-		CorridorCleanliness       int `json:"corridor_cleanliness"`
-		KitchenCleanliness        int `json:"kitchen_cleanliness"`
-		CleaningFrequency         int `json:"cleaning_frequency"`
-		RoomSpaciousness          int `json:"room_spaciousness"`
-		CorridorSpaciousness      int `json:"corridor_spaciousness"`
-		KitchenSpaciousness       int `json:"kitchen_spaciousness"`
-		ShowerLocationConvenience int `json:"shower_location_convenience"`
-		EquipmentMaintenance      int `json:"equipment_maintenance"`
-		WindowCondition           int `json:"window_condition"`
-		NoiseIsolation            int `json:"noise_isolation"`
-		CommonAreasEquipment      int `json:"common_areas_equipment"`
-		TransportAccessibility    int `json:"transport_accessibility"`
-		AdministrationQuality     int `json:"administration_quality"`
-		ResidentsCultureLevel     int `json:"residents_culture_level"`
+		LegacyGradeScores
+		Scores map[string]int `json:"scores"`
 	}
 
 	CreateDormitoryGradeResponse struct {
 		GradeId string `json:"grade_id"`
+		// Status - flagged, если оценка ждет проверки администратором
+		Status string `json:"status"`
 	}
 )
 
+// AllScores возвращает оценки по кодам критериев из обоих форматов запроса
+func (r *CreateDormitoryGradeRequest) AllScores() map[string]int {
+	return mergeGradeScores(&r.LegacyGradeScores, r.Scores)
+}
+
 func (r *CreateDormitoryGradeResponse) From(msg *dbtypes.CreateDormitoryGradeResponse) *CreateDormitoryGradeResponse {
 	if msg == nil {
 		return nil
@@ -64,16 +109,67 @@ func (r *CreateDormitoryGradeResponse) From(msg *dbtypes.CreateDormitoryGradeRes
 
 	res := &CreateDormitoryGradeResponse{
 		GradeId: msg.GradeId,
+		Status:  msg.Status,
 	}
 
 	return res
 }
 
+type (
+	UpdateDormitoryGradeRequest struct {
+		DormitoryId string
+
+		LegacyGradeScores
+		Scores map[string]int `json:"scores"`
+	}
+
+	UpdateDormitoryGradeResponse struct {
+		GradeId string `json:"grade_id"`
+		Status  string `json:"status"`
+	}
+)
+
+func (r *UpdateDormitoryGradeRequest) AllScores() map[string]int {
+	return mergeGradeScores(&r.LegacyGradeScores, r.Scores)
+}
+
+func (r *UpdateDormitoryGradeResponse) From(msg *dbtypes.UpdateDormitoryGradeResponse) *UpdateDormitoryGradeResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &UpdateDormitoryGradeResponse{
+		GradeId: msg.GradeId,
+		Status:  msg.Status,
+	}
+}
+
+type (
+	DeleteDormitoryGradeRequest struct {
+		DormitoryId string `json:"dormitory_id"`
+	}
+
+	DeleteDormitoryGradeResponse struct {
+		GradeId string `json:"grade_id"`
+	}
+)
+
+func (r *DeleteDormitoryGradeResponse) From(msg *dbtypes.DeleteDormitoryGradeResponse) *DeleteDormitoryGradeResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &DeleteDormitoryGradeResponse{
+		GradeId: msg.GradeId,
+	}
+}
+
 type AvgGrade struct {
 	Id          string    `json:"id"`
 	DormitoryId string    `json:"dormitory_id"`
 	PeriodDate  time.Time `json:"period_date"`
 
+	// legacy fields are kept for clients built before the criteria registry
 	AvgBathroomCleanliness       float64 `json:"avg_bathroom_cleanliness"`
 	AvgCorridorCleanliness       float64 `json:"avg_corridor_cleanliness"`
 	AvgKitchenCleanliness        float64 `json:"avg_kitchen_cleanliness"`
@@ -90,6 +186,9 @@ type AvgGrade struct {
 	AvgAdministrationQuality     float64 `json:"avg_administration_quality"`
 	AvgResidentsCultureLevel     float64 `json:"avg_residents_culture_level"`
 
+	// Criteria - средние по всем критериям реестра, включая добавленные позже
+	Criteria map[string]float64 `json:"criteria"`
+
 	OverallAverage float64 `json:"overall_average"`
 	TotalRatings   int     `json:"total_ratings"`
 
@@ -97,44 +196,72 @@ type AvgGrade struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
-type (
-	GetDormitoriesAvgGradesRequest  struct{}
-	GetDormitoriesAvgGradesResponse struct {
-		Grades []AvgGrade `json:"avg_grades"`
-	}
-)
-
 func (r *AvgGrade) From(msg *dbtypes.AvgGrade) *AvgGrade {
 	if msg == nil {
 		return nil
 	}
 
-	return &AvgGrade{
-		Id:                           msg.Id,
-		DormitoryId:                  msg.DormitoryId,
-		PeriodDate:                   msg.PeriodDate,
-		AvgBathroomCleanliness:       msg.AvgBathroomCleanliness,
-		AvgCorridorCleanliness:       msg.AvgCorridorCleanliness,
-		AvgKitchenCleanliness:        msg.AvgKitchenCleanliness,
-		AvgCleaningFrequency:         msg.AvgCleaningFrequency,
-		AvgRoomSpaciousness:          msg.AvgRoomSpaciousness,
-		AvgCorridorSpaciousness:      msg.AvgCorridorSpaciousness,
-		AvgKitchenSpaciousness:       msg.AvgKitchenSpaciousness,
-		AvgShowerLocationConvenience: msg.AvgShowerLocationConvenience,
-		AvgEquipmentMaintenance:      msg.AvgEquipmentMaintenance,
-		AvgWindowCondition:           msg.AvgWindowCondition,
-		AvgNoiseIsolation:            msg.AvgNoiseIsolation,
-		AvgCommonAreasEquipment:      msg.AvgCommonAreasEquipment,
-		AvgTransportAccessibility:    msg.AvgTransportAccessibility,
-		AvgAdministrationQuality:     msg.AvgAdministrationQuality,
-		AvgResidentsCultureLevel:     msg.AvgResidentsCultureLevel,
-		OverallAverage:               msg.OverallAverage,
-		TotalRatings:                 msg.TotalRatings,
-		CreatedAt:                    msg.CreatedAt,
-		UpdatedAt:                    msg.UpdatedAt,
+	res := &AvgGrade{
+		Id:             msg.Id,
+		DormitoryId:    msg.DormitoryId,
+		PeriodDate:     msg.PeriodDate,
+		Criteria:       make(map[string]float64, len(msg.Criteria)),
+		OverallAverage: msg.OverallAverage,
+		TotalRatings:   msg.TotalRatings,
+		CreatedAt:      msg.CreatedAt,
+		UpdatedAt:      msg.UpdatedAt,
 	}
+
+	for _, val := range msg.Criteria {
+		res.Criteria[val.Code] = val.Average
+		res.setLegacyAverage(val.Code, val.Average)
+	}
+
+	return res
 }
 
+func (r *AvgGrade) setLegacyAverage(code string, val float64) {
+	switch code {
+	case "bathroom_cleanliness":
+		r.AvgBathroomCleanliness = val
+	case "corridor_cleanliness":
+		r.AvgCorridorCleanliness = val
+	case "kitchen_cleanliness":
+		r.AvgKitchenCleanliness = val
+	case "cleaning_frequency":
+		r.AvgCleaningFrequency = val
+	case "room_spaciousness":
+		r.AvgRoomSpaciousness = val
+	case "corridor_spaciousness":
+		r.AvgCorridorSpaciousness = val
+	case "kitchen_spaciousness":
+		r.AvgKitchenSpaciousness = val
+	case "shower_location_convenience":
+		r.AvgShowerLocationConvenience = val
+	case "equipment_maintenance":
+		r.AvgEquipmentMaintenance = val
+	case "window_condition":
+		r.AvgWindowCondition = val
+	case "noise_isolation":
+		r.AvgNoiseIsolation = val
+	case "common_areas_equipment":
+		r.AvgCommonAreasEquipment = val
+	case "transport_accessibility":
+		r.AvgTransportAccessibility = val
+	case "administration_quality":
+		r.AvgAdministrationQuality = val
+	case "residents_culture_level":
+		r.AvgResidentsCultureLevel = val
+	}
+}
+
+type (
+	GetDormitoriesAvgGradesRequest  struct{}
+	GetDormitoriesAvgGradesResponse struct {
+		Grades []AvgGrade `json:"avg_grades"`
+	}
+)
+
 func (r *GetDormitoriesAvgGradesResponse) From(msg *dbtypes.GetDormitoriesAvgGradesResponse) *GetDormitoriesAvgGradesResponse {
 	if msg == nil {
 		return nil
@@ -154,7 +281,9 @@ func (r *GetDormitoriesAvgGradesResponse) From(msg *dbtypes.GetDormitoriesAvgGra
 
 type (
 	GetDormitoryAvgGradesRequest struct {
-		DormitoryId string `json:"dormitory_id"`
+		DormitoryId string     `json:"dormitory_id"`
+		From        *time.Time `json:"-"`
+		To          *time.Time `json:"-"`
 	}
 	GetDormitoryAvgGradesResponse struct {
 		Grades []AvgGrade `json:"avg_grades"`
@@ -177,3 +306,295 @@ func (r *GetDormitoryAvgGradesResponse) From(msg *dbtypes.GetDormitoryAvgGradesR
 
 	return res
 }
+
+func (*GetDormitoryAvgGradesRequest) FromUrlQuery(query url.Values) (*GetDormitoryAvgGradesRequest, error) {
+	from, to, err := parsePeriodRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetDormitoryAvgGradesRequest{
+		From: from,
+		To:   to,
+	}, nil
+}
+
+type GradesGranularity = string
+
+const (
+	GradesGranularityMonth    GradesGranularity = "month"
+	GradesGranularitySemester GradesGranularity = "semester"
+	GradesGranularityYear     GradesGranularity = "year"
+)
+
+type GradesSeries struct {
+	Criterion string    `json:"criterion"`
+	Values    []float64 `json:"values"`
+}
+
+type (
+	GetDormitoryGradesHistoryRequest struct {
+		DormitoryId string
+		From        *time.Time
+		To          *time.Time
+		Granularity GradesGranularity
+	}
+
+	// GetDormitoryGradesHistoryResponse - значения i-го элемента каждой серии
+	// относятся к периоду Periods[i]
+	GetDormitoryGradesHistoryResponse struct {
+		DormitoryId    string            `json:"dormitory_id"`
+		Granularity    GradesGranularity `json:"granularity"`
+		Periods        []time.Time       `json:"periods"`
+		TotalRatings   []int             `json:"total_ratings"`
+		OverallAverage []float64         `json:"overall_average"`
+		Series         []GradesSeries    `json:"series"`
+	}
+)
+
+func (*GetDormitoryGradesHistoryRequest) FromUrlQuery(query url.Values) (*GetDormitoryGradesHistoryRequest, error) {
+	from, to, err := parsePeriodRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &GetDormitoryGradesHistoryRequest{
+		From:        from,
+		To:          to,
+		Granularity: GradesGranularityMonth,
+	}
+
+	if val, ok := query["granularity"]; ok {
+		switch val[0] {
+		case GradesGranularityMonth, GradesGranularitySemester, GradesGranularityYear:
+			res.Granularity = val[0]
+		default:
+			return nil, fmt.Errorf("invalid granularity param: %s", val[0])
+		}
+	}
+
+	return res, nil
+}
+
+type CriterionDistribution struct {
+	Criterion string `json:"criterion"`
+	// Counts[i] - количество оценок i+1
+	Counts [5]int `json:"counts"`
+}
+
+type (
+	GetGradesDistributionRequest struct {
+		DormitoryId string
+		Period      *time.Time
+	}
+
+	GetGradesDistributionResponse struct {
+		DormitoryId  string                  `json:"dormitory_id"`
+		Period       *time.Time              `json:"period"`
+		TotalRatings int                     `json:"total_ratings"`
+		Criteria     []CriterionDistribution `json:"criteria"`
+	}
+)
+
+func (*GetGradesDistributionRequest) FromUrlQuery(query url.Values) (*GetGradesDistributionRequest, error) {
+	res := &GetGradesDistributionRequest{}
+
+	if val, ok := query["period"]; ok {
+		period, err := parsePeriod(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid period param: %w", err)
+		}
+
+		res.Period = &period
+	}
+
+	return res, nil
+}
+
+// CacheKey - ключ кэша распределения, без периода распределение считается за все время
+func (r *GetGradesDistributionRequest) CacheKey() string {
+	return GradesDistributionCacheKey(r.DormitoryId, r.Period)
+}
+
+func GradesDistributionCacheKey(dormitoryId string, period *time.Time) string {
+	if period == nil {
+		return fmt.Sprintf("%s:all", dormitoryId)
+	}
+
+	return fmt.Sprintf("%s:%s", dormitoryId, period.Format("2006-01"))
+}
+
+// From заполняет распределение для всех переданных критериев, в том числе без оценок
+func (r *GetGradesDistributionResponse) From(
+	request *GetGradesDistributionRequest,
+	criteria []string,
+	msg *dbtypes.GetGradesDistributionResponse,
+) *GetGradesDistributionResponse {
+	if msg == nil {
+		return nil
+	}
+
+	res := &GetGradesDistributionResponse{
+		DormitoryId:  request.DormitoryId,
+		Period:       request.Period,
+		TotalRatings: msg.TotalRatings,
+		Criteria:     make([]CriterionDistribution, 0, len(criteria)),
+	}
+
+	criterionIdx := make(map[string]int, len(criteria))
+	for i, criterion := range criteria {
+		criterionIdx[criterion] = i
+		res.Criteria = append(res.Criteria, CriterionDistribution{
+			Criterion: criterion,
+		})
+	}
+
+	for _, val := range msg.Counts {
+		idx, ok := criterionIdx[val.Criterion]
+		if !ok || val.Score < 1 || val.Score > len(res.Criteria[idx].Counts) {
+			continue
+		}
+
+		res.Criteria[idx].Counts[val.Score-1] = val.Count
+	}
+
+	return res
+}
+
+// parsePeriodRange разбирает границы from/to в формате YYYY-MM или YYYY-MM-DD,
+// обе границы приводятся к первому числу месяца и включаются в диапазон
+func parsePeriodRange(query url.Values) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+
+	if val, ok := query["from"]; ok {
+		period, err := parsePeriod(val[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid from param: %w", err)
+		}
+
+		from = &period
+	}
+
+	if val, ok := query["to"]; ok {
+		period, err := parsePeriod(val[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid to param: %w", err)
+		}
+
+		to = &period
+	}
+
+	if from != nil && to != nil && from.After(*to) {
+		return nil, nil, fmt.Errorf("from must not be after to")
+	}
+
+	return from, to, nil
+}
+
+func parsePeriod(val string) (time.Time, error) {
+	for _, layout := range []string{"2006-01", time.DateOnly} {
+		if t, err := time.Parse(layout, val); err == nil {
+			return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("expected YYYY-MM or YYYY-MM-DD, got %q", val)
+}
+
+type LeaderboardEntry struct {
+	Rank int `json:"rank"`
+	// PreviousRank - место в предыдущем периоде, nil - общежитие тогда не оценивали
+	PreviousRank *int `json:"previous_rank"`
+	// RankChange - на сколько мест общежитие поднялось (положительное) или опустилось (отрицательное)
+	RankChange   *int    `json:"rank_change"`
+	DormitoryId  string  `json:"dormitory_id"`
+	Name         string  `json:"name"`
+	Score        float64 `json:"score"`
+	Average      float64 `json:"average"`
+	TotalRatings int     `json:"total_ratings"`
+}
+
+type (
+	GetGradesLeaderboardRequest struct {
+		Period      *time.Time
+		Granularity GradesGranularity
+		// Weights - веса критериев, не указанные критерии не учитываются
+		Weights    map[string]float64
+		MinRatings *int
+	}
+
+	GetGradesLeaderboardResponse struct {
+		Granularity    GradesGranularity  `json:"granularity"`
+		Period         time.Time          `json:"period"`
+		PreviousPeriod time.Time          `json:"previous_period"`
+		Weights        map[string]float64 `json:"weights"`
+		MinRatings     int                `json:"min_ratings"`
+		GlobalAverage  float64            `json:"global_average"`
+		Entries        []LeaderboardEntry `json:"entries"`
+	}
+)
+
+func (*GetGradesLeaderboardRequest) FromUrlQuery(query url.Values) (*GetGradesLeaderboardRequest, error) {
+	res := &GetGradesLeaderboardRequest{
+		Granularity: GradesGranularityMonth,
+	}
+
+	if val, ok := query["period"]; ok {
+		period, err := parsePeriod(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid period param: %w", err)
+		}
+
+		res.Period = &period
+	}
+
+	if val, ok := query["granularity"]; ok {
+		switch val[0] {
+		case GradesGranularityMonth, GradesGranularitySemester, GradesGranularityYear:
+			res.Granularity = val[0]
+		default:
+			return nil, fmt.Errorf("invalid granularity param: %s", val[0])
+		}
+	}
+
+	if val, ok := query["weights"]; ok {
+		weights, err := parseCriteriaWeights(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid weights param: %w", err)
+		}
+
+		res.Weights = weights
+	}
+
+	if val, ok := query["min_ratings"]; ok {
+		minRatings, err := strconv.Atoi(val[0])
+		if err != nil || minRatings < 0 {
+			return nil, fmt.Errorf("invalid min_ratings param: %s", val[0])
+		}
+
+		res.MinRatings = &minRatings
+	}
+
+	return res, nil
+}
+
+// parseCriteriaWeights разбирает веса вида code:weight через запятую
+func parseCriteriaWeights(val string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+
+	for _, pair := range strings.Split(val, ",") {
+		code, weightStr, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || code == "" {
+			return nil, fmt.Errorf("expected code:weight, got %q", pair)
+		}
+
+		weight, err := strconv.ParseFloat(weightStr, 64)
+		if err != nil || weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return nil, fmt.Errorf("invalid weight for %s: %q", code, weightStr)
+		}
+
+		weights[code] = weight
+	}
+
+	return weights, nil
+}
@@ -5,7 +5,9 @@ import (
 	"mime/multipart"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	dbtypes "github.com/dormitory-life/core/internal/database/types"
 )
@@ -17,13 +19,77 @@ type Review struct {
 	ReviewPhotos []FileInfo `json:"review_photos"`
 	Title        string     `json:"title"`
 	Description  string     `json:"description"`
+	Status       string     `json:"status"`
 	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+	// Replies - официальные ответы администрации
+	Replies        []ReviewReply `json:"replies"`
+	HelpfulCount   int           `json:"helpful_count"`
+	UnhelpfulCount int           `json:"unhelpful_count"`
+	// AuthorGrade - оценка общежития автором за месяц отзыва
+	AuthorGrade *ReviewAuthorGrade `json:"author_grade,omitempty"`
+}
+
+type ReviewAuthorGrade struct {
+	GradeId string         `json:"grade_id"`
+	Overall float64        `json:"overall"`
+	Lowest  CriterionScore `json:"lowest"`
+	Highest CriterionScore `json:"highest"`
+}
+
+type CriterionScore struct {
+	Code  string `json:"code"`
+	Score int    `json:"score"`
+}
+
+func (r *ReviewAuthorGrade) From(msg *dbtypes.ReviewAuthorGrade) *ReviewAuthorGrade {
+	if msg == nil {
+		return nil
+	}
+
+	return &ReviewAuthorGrade{
+		GradeId: msg.GradeId,
+		Overall: msg.Overall,
+		Lowest: CriterionScore{
+			Code:  msg.LowestCode,
+			Score: msg.LowestScore,
+		},
+		Highest: CriterionScore{
+			Code:  msg.HighestCode,
+			Score: msg.HighestScore,
+		},
+	}
+}
+
+type ReviewReply struct {
+	ReplyId   string    `json:"reply_id"`
+	AuthorId  *string   `json:"author_id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (r *ReviewReply) From(msg *dbtypes.ReviewReply) *ReviewReply {
+	if msg == nil {
+		return nil
+	}
+
+	return &ReviewReply{
+		ReplyId:   msg.ReplyId,
+		AuthorId:  msg.AuthorId,
+		Text:      msg.Text,
+		CreatedAt: msg.CreatedAt,
+	}
 }
 
 type (
 	GetDormitoryReviewsRequest struct {
 		DormitoryId string
 		Page        uint64
+		// Sort - newest (по умолчанию), oldest, most_helpful или controversial
+		Sort string
+		// MinGrade и MaxGrade - диапазон общего балла, который поставил автор отзыва
+		MinGrade *float64
+		MaxGrade *float64
 	}
 
 	GetDormitoryReviewsResponse struct {
@@ -34,12 +100,25 @@ type (
 func (*GetDormitoryReviewsRequest) FromUrlQuery(query url.Values) (*GetDormitoryReviewsRequest, error) {
 	res := &GetDormitoryReviewsRequest{
 		Page: 1,
+		Sort: dbtypes.ReviewSortNewest,
 	}
 
 	if query == nil {
 		return res, nil
 	}
 
+	if val, ok := query["sort"]; ok {
+		switch val[0] {
+		case dbtypes.ReviewSortNewest,
+			dbtypes.ReviewSortOldest,
+			dbtypes.ReviewSortMostHelpful,
+			dbtypes.ReviewSortControversial:
+			res.Sort = val[0]
+		default:
+			return nil, fmt.Errorf("invalid sort param: %s", val[0])
+		}
+	}
+
 	if val, ok := query["page"]; ok {
 		intVal, err := parseUint64(val[0])
 		if err != nil {
@@ -53,6 +132,31 @@ func (*GetDormitoryReviewsRequest) FromUrlQuery(query url.Values) (*GetDormitory
 		res.Page = 1
 	}
 
+	for param, target := range map[string]**float64{
+		"min_grade": &res.MinGrade,
+		"max_grade": &res.MaxGrade,
+	} {
+		val, ok := query[param]
+		if !ok {
+			continue
+		}
+
+		grade, err := strconv.ParseFloat(val[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s param: %w", param, err)
+		}
+
+		if grade < 1 || grade > 5 {
+			return nil, fmt.Errorf("%s param must be between 1 and 5", param)
+		}
+
+		*target = &grade
+	}
+
+	if res.MinGrade != nil && res.MaxGrade != nil && *res.MinGrade > *res.MaxGrade {
+		return nil, fmt.Errorf("min_grade must not be greater than max_grade")
+	}
+
 	return res, nil
 }
 
@@ -61,14 +165,27 @@ func (r *Review) From(msg *dbtypes.Review) *Review {
 		return nil
 	}
 
-	return &Review{
+	res := &Review{
 		ReviewId:    msg.ReviewId,
 		OwnerId:     msg.OwnerId,
 		DormitoryId: msg.DormitoryId,
 		Title:       msg.Title,
 		Description: msg.Description,
+		Status:      msg.Status,
 		CreatedAt:   msg.CreatedAt,
+		UpdatedAt:   msg.UpdatedAt,
+		Replies:     make([]ReviewReply, 0, len(msg.Replies)),
+
+		HelpfulCount:   msg.HelpfulCount,
+		UnhelpfulCount: msg.UnhelpfulCount,
+		AuthorGrade:    new(ReviewAuthorGrade).From(msg.AuthorGrade),
 	}
+
+	for _, reply := range msg.Replies {
+		res.Replies = append(res.Replies, *new(ReviewReply).From(&reply))
+	}
+
+	return res
 }
 
 func (*GetDormitoryReviewsResponse) From(msg *dbtypes.GetDormitoryReviewsResponse) *GetDormitoryReviewsResponse {
@@ -117,6 +234,171 @@ type (
 	}
 )
 
+type (
+	UpdateReviewRequest struct {
+		DormitoryId       string
+		ReviewId          string
+		Title             *string
+		Description       *string
+		PhotoFilesHeaders []*multipart.FileHeader
+		RemovePhotos      []string
+	}
+
+	UpdateReviewResponse struct {
+		Review
+	}
+)
+
+func (r *UpdateReviewRequest) Validate() error {
+	if r.Title != nil && len(strings.TrimSpace(*r.Title)) == 0 {
+		return fmt.Errorf("title must not be empty")
+	}
+
+	if r.Description != nil && len(strings.TrimSpace(*r.Description)) == 0 {
+		return fmt.Errorf("description must not be empty")
+	}
+
+	if r.Title == nil && r.Description == nil && len(r.PhotoFilesHeaders) == 0 && len(r.RemovePhotos) == 0 {
+		return fmt.Errorf("nothing to update")
+	}
+
+	return nil
+}
+
+type ReviewEdit struct {
+	EditId         string    `json:"edit_id"`
+	EditorId       *string   `json:"editor_id"`
+	OldTitle       string    `json:"old_title"`
+	NewTitle       string    `json:"new_title"`
+	OldDescription string    `json:"old_description"`
+	NewDescription string    `json:"new_description"`
+	AddedPhotos    []string  `json:"added_photos"`
+	RemovedPhotos  []string  `json:"removed_photos"`
+	EditedAt       time.Time `json:"edited_at"`
+}
+
+type (
+	GetReviewEditsRequest struct {
+		DormitoryId string
+		ReviewId    string
+	}
+
+	GetReviewEditsResponse struct {
+		ReviewId string       `json:"review_id"`
+		Edits    []ReviewEdit `json:"edits"`
+	}
+)
+
+func (*GetReviewEditsResponse) From(reviewId string, msg *dbtypes.GetReviewEditsResponse) *GetReviewEditsResponse {
+	if msg == nil {
+		return nil
+	}
+
+	res := &GetReviewEditsResponse{
+		ReviewId: reviewId,
+		Edits:    make([]ReviewEdit, 0, len(msg.Edits)),
+	}
+
+	for _, edit := range msg.Edits {
+		res.Edits = append(res.Edits, ReviewEdit{
+			EditId:         edit.EditId,
+			EditorId:       edit.EditorId,
+			OldTitle:       edit.OldTitle,
+			NewTitle:       edit.NewTitle,
+			OldDescription: edit.OldDescription,
+			NewDescription: edit.NewDescription,
+			AddedPhotos:    edit.AddedPhotos,
+			RemovedPhotos:  edit.RemovedPhotos,
+			EditedAt:       edit.EditedAt,
+		})
+	}
+
+	return res
+}
+
+const maxReviewReplyLength = 2000
+
+type (
+	CreateReviewReplyRequest struct {
+		DormitoryId string
+		ReviewId    string
+		Text        string `json:"text"`
+	}
+
+	CreateReviewReplyResponse struct {
+		ReviewReply
+	}
+)
+
+func (r *CreateReviewReplyRequest) Validate() error {
+	if len(strings.TrimSpace(r.Text)) == 0 {
+		return fmt.Errorf("text must not be empty")
+	}
+
+	if utf8.RuneCountInString(r.Text) > maxReviewReplyLength {
+		return fmt.Errorf("text is longer than %d characters", maxReviewReplyLength)
+	}
+
+	return nil
+}
+
+type (
+	DeleteReviewReplyRequest struct {
+		DormitoryId string
+		ReviewId    string
+		ReplyId     string
+	}
+
+	DeleteReviewReplyResponse struct {
+	}
+)
+
+const (
+	ReviewVoteHelpful   = "helpful"
+	ReviewVoteUnhelpful = "unhelpful"
+)
+
+type (
+	VoteReviewRequest struct {
+		DormitoryId string
+		ReviewId    string
+		// Vote - helpful или unhelpful
+		Vote string `json:"vote"`
+	}
+
+	VoteReviewResponse struct {
+		ReviewId       string `json:"review_id"`
+		HelpfulCount   int    `json:"helpful_count"`
+		UnhelpfulCount int    `json:"unhelpful_count"`
+	}
+)
+
+func (r *VoteReviewRequest) Validate() error {
+	switch r.Vote {
+	case ReviewVoteHelpful, ReviewVoteUnhelpful:
+		return nil
+	default:
+		return fmt.Errorf("invalid vote: %q", r.Vote)
+	}
+}
+
+func (r *VoteReviewResponse) From(msg *dbtypes.VoteReviewResponse) *VoteReviewResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &VoteReviewResponse{
+		ReviewId:       msg.ReviewId,
+		HelpfulCount:   msg.HelpfulCount,
+		UnhelpfulCount: msg.UnhelpfulCount,
+	}
+}
+
+type DeleteReviewVoteRequest struct {
+	DormitoryId string
+	ReviewId    string
+}
+
 func parseUint64(val string) (uint64, error) {
 	uintVal, err := strconv.ParseUint(val, 10, 64)
 	if err != nil {
@@ -0,0 +1,199 @@
+package requestmodels
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+	"unicode/utf8"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+const (
+	ReviewReportReasonSpam           = "spam"
+	ReviewReportReasonAbuse          = "abuse"
+	ReviewReportReasonPersonalData   = "personal_data"
+	ReviewReportReasonMisinformation = "misinformation"
+	ReviewReportReasonOther          = "other"
+
+	maxReviewReportCommentLength = 1000
+)
+
+type ReviewReport struct {
+	ReportId   string     `json:"report_id"`
+	ReporterId string     `json:"reporter_id"`
+	Reason     string     `json:"reason"`
+	Comment    string     `json:"comment"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+type (
+	CreateReviewReportRequest struct {
+		DormitoryId string
+		ReviewId    string
+		Reason      string `json:"reason"`
+		Comment     string `json:"comment"`
+	}
+
+	CreateReviewReportResponse struct {
+		ReportId     string `json:"report_id"`
+		ReviewStatus string `json:"review_status"`
+	}
+)
+
+func (r *CreateReviewReportRequest) Validate() error {
+	switch r.Reason {
+	case ReviewReportReasonSpam,
+		ReviewReportReasonAbuse,
+		ReviewReportReasonPersonalData,
+		ReviewReportReasonMisinformation:
+	case ReviewReportReasonOther:
+		if len(r.Comment) == 0 {
+			return fmt.Errorf("comment is required for reason %q", r.Reason)
+		}
+	default:
+		return fmt.Errorf("invalid reason: %q", r.Reason)
+	}
+
+	if utf8.RuneCountInString(r.Comment) > maxReviewReportCommentLength {
+		return fmt.Errorf("comment is longer than %d characters", maxReviewReportCommentLength)
+	}
+
+	return nil
+}
+
+func (r *CreateReviewReportResponse) From(msg *dbtypes.CreateReviewReportResponse) *CreateReviewReportResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &CreateReviewReportResponse{
+		ReportId:     msg.ReportId,
+		ReviewStatus: msg.ReviewStatus,
+	}
+}
+
+type ModerationReview struct {
+	Review
+	// OpenReports - жалобы, которые еще не рассмотрены
+	OpenReports int            `json:"open_reports"`
+	Reports     []ReviewReport `json:"reports"`
+}
+
+type (
+	GetModerationReviewsRequest struct {
+		DormitoryId string
+		Status      string
+		Page        uint64
+	}
+
+	GetModerationReviewsResponse struct {
+		Reviews []ModerationReview `json:"reviews"`
+		Total   int                `json:"total"`
+		Page    uint64             `json:"page"`
+	}
+)
+
+func (*GetModerationReviewsRequest) FromUrlQuery(query url.Values) (*GetModerationReviewsRequest, error) {
+	res := &GetModerationReviewsRequest{
+		Status: dbtypes.ReviewStatusPending,
+		Page:   1,
+	}
+
+	if val, ok := query["status"]; ok {
+		switch val[0] {
+		case dbtypes.ReviewStatusPending, dbtypes.ReviewStatusHidden:
+			res.Status = val[0]
+		default:
+			return nil, fmt.Errorf("invalid status param: %s", val[0])
+		}
+	}
+
+	if val, ok := query["page"]; ok {
+		intVal, err := parseUint64(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid page param: %w", err)
+		}
+
+		res.Page = intVal
+	}
+
+	if res.Page == 0 {
+		res.Page = 1
+	}
+
+	return res, nil
+}
+
+func (r *GetModerationReviewsResponse) From(page uint64, msg *dbtypes.GetModerationReviewsResponse) *GetModerationReviewsResponse {
+	if msg == nil {
+		return nil
+	}
+
+	res := &GetModerationReviewsResponse{
+		Reviews: make([]ModerationReview, 0, len(msg.Reviews)),
+		Total:   msg.Total,
+		Page:    page,
+	}
+
+	for _, val := range msg.Reviews {
+		review := ModerationReview{
+			Review:  *new(Review).From(&val.Review),
+			Reports: make([]ReviewReport, 0, len(val.Reports)),
+		}
+
+		for _, report := range val.Reports {
+			if report.ResolvedAt == nil {
+				review.OpenReports++
+			}
+
+			review.Reports = append(review.Reports, ReviewReport{
+				ReportId:   report.ReportId,
+				ReporterId: report.ReporterId,
+				Reason:     report.Reason,
+				Comment:    report.Comment,
+				CreatedAt:  report.CreatedAt,
+				ResolvedAt: report.ResolvedAt,
+			})
+		}
+
+		res.Reviews = append(res.Reviews, review)
+	}
+
+	return res
+}
+
+type (
+	ModerateReviewRequest struct {
+		DormitoryId string
+		ReviewId    string
+		// Status - hidden, чтобы скрыть отзыв, или published, чтобы вернуть его
+		Status string `json:"status"`
+	}
+
+	ModerateReviewResponse struct {
+		ReviewId string `json:"review_id"`
+		Status   string `json:"status"`
+	}
+)
+
+func (r *ModerateReviewRequest) Validate() error {
+	switch r.Status {
+	case dbtypes.ReviewStatusPublished, dbtypes.ReviewStatusHidden:
+		return nil
+	default:
+		return fmt.Errorf("invalid status: %q", r.Status)
+	}
+}
+
+func (r *ModerateReviewResponse) From(msg *dbtypes.ModerateReviewResponse) *ModerateReviewResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &ModerateReviewResponse{
+		ReviewId: msg.ReviewId,
+		Status:   msg.Status,
+	}
+}
@@ -0,0 +1,401 @@
+package requestmodels
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+type Room struct {
+	Id       string `json:"id"`
+	Number   string `json:"number"`
+	Type     string `json:"type"`
+	Capacity int    `json:"capacity"`
+	Occupied int    `json:"occupied"`
+}
+
+type Floor struct {
+	Id     string `json:"id"`
+	Number int    `json:"number"`
+	Rooms  []Room `json:"rooms"`
+}
+
+type Building struct {
+	Id     string  `json:"id"`
+	Name   string  `json:"name"`
+	Floors []Floor `json:"floors"`
+}
+
+type (
+	GetDormitoryRoomsRequest struct {
+		DormitoryId string
+	}
+
+	GetDormitoryRoomsResponse struct {
+		Buildings []Building `json:"buildings"`
+	}
+)
+
+// From собирает плоские списки корпусов, этажей и комнат в дерево
+func (r *GetDormitoryRoomsResponse) From(msg *dbtypes.GetDormitoryRoomsResponse) *GetDormitoryRoomsResponse {
+	if msg == nil {
+		return nil
+	}
+
+	roomsByFloor := make(map[string][]Room)
+	for _, room := range msg.Rooms {
+		roomsByFloor[room.FloorId] = append(roomsByFloor[room.FloorId], Room{
+			Id:       room.Id,
+			Number:   room.Number,
+			Type:     room.Type,
+			Capacity: room.Capacity,
+			Occupied: room.Occupied,
+		})
+	}
+
+	floorsByBuilding := make(map[string][]Floor)
+	for _, floor := range msg.Floors {
+		rooms := roomsByFloor[floor.Id]
+		if rooms == nil {
+			rooms = make([]Room, 0)
+		}
+
+		floorsByBuilding[floor.BuildingId] = append(floorsByBuilding[floor.BuildingId], Floor{
+			Id:     floor.Id,
+			Number: floor.Number,
+			Rooms:  rooms,
+		})
+	}
+
+	res := &GetDormitoryRoomsResponse{
+		Buildings: make([]Building, 0, len(msg.Buildings)),
+	}
+
+	for _, building := range msg.Buildings {
+		floors := floorsByBuilding[building.Id]
+		if floors == nil {
+			floors = make([]Floor, 0)
+		}
+
+		res.Buildings = append(res.Buildings, Building{
+			Id:     building.Id,
+			Name:   building.Name,
+			Floors: floors,
+		})
+	}
+
+	return res
+}
+
+type (
+	CreateBuildingRequest struct {
+		DormitoryId string
+		Name        string `json:"name"`
+	}
+
+	CreateBuildingResponse struct {
+		BuildingId string `json:"building_id"`
+	}
+)
+
+func (r *CreateBuildingRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("empty building name")
+	}
+
+	return nil
+}
+
+func (r *CreateBuildingResponse) From(msg *dbtypes.CreateBuildingResponse) *CreateBuildingResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &CreateBuildingResponse{
+		BuildingId: msg.BuildingId,
+	}
+}
+
+type (
+	DeleteBuildingRequest struct {
+		DormitoryId string
+		BuildingId  string
+	}
+
+	DeleteBuildingResponse struct {
+		BuildingId string `json:"building_id"`
+	}
+)
+
+func (r *DeleteBuildingResponse) From(msg *dbtypes.DeleteBuildingResponse) *DeleteBuildingResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &DeleteBuildingResponse{
+		BuildingId: msg.BuildingId,
+	}
+}
+
+type (
+	CreateFloorRequest struct {
+		DormitoryId string
+		BuildingId  string
+		Number      int `json:"number"`
+	}
+
+	CreateFloorResponse struct {
+		FloorId string `json:"floor_id"`
+	}
+)
+
+func (r *CreateFloorResponse) From(msg *dbtypes.CreateFloorResponse) *CreateFloorResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &CreateFloorResponse{
+		FloorId: msg.FloorId,
+	}
+}
+
+type (
+	DeleteFloorRequest struct {
+		DormitoryId string
+		FloorId     string
+	}
+
+	DeleteFloorResponse struct {
+		FloorId string `json:"floor_id"`
+	}
+)
+
+func (r *DeleteFloorResponse) From(msg *dbtypes.DeleteFloorResponse) *DeleteFloorResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &DeleteFloorResponse{
+		FloorId: msg.FloorId,
+	}
+}
+
+type (
+	CreateRoomRequest struct {
+		DormitoryId string
+		FloorId     string `json:"floor_id"`
+		Number      string `json:"number"`
+		Type        string `json:"type"`
+		Capacity    int    `json:"capacity"`
+		Occupied    int    `json:"occupied"`
+	}
+
+	CreateRoomResponse struct {
+		RoomId string `json:"room_id"`
+	}
+)
+
+func (r *CreateRoomRequest) Validate() error {
+	if r.FloorId == "" {
+		return fmt.Errorf("empty floor id")
+	}
+
+	if strings.TrimSpace(r.Number) == "" {
+		return fmt.Errorf("empty room number")
+	}
+
+	if err := validateRoomType(r.Type); err != nil {
+		return err
+	}
+
+	return validateRoomOccupancy(r.Capacity, r.Occupied)
+}
+
+func (r *CreateRoomResponse) From(msg *dbtypes.CreateRoomResponse) *CreateRoomResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &CreateRoomResponse{
+		RoomId: msg.RoomId,
+	}
+}
+
+type (
+	UpdateRoomRequest struct {
+		DormitoryId string
+		RoomId      string
+		Number      *string `json:"number"`
+		Type        *string `json:"type"`
+		Capacity    *int    `json:"capacity"`
+		Occupied    *int    `json:"occupied"`
+	}
+
+	UpdateRoomResponse struct {
+		RoomId string `json:"room_id"`
+	}
+)
+
+// Validate проверяет только переданные поля, соотношение вместимости
+// и числа жильцов для частичного обновления проверяет ограничение в таблице
+func (r *UpdateRoomRequest) Validate() error {
+	if r.Number != nil && strings.TrimSpace(*r.Number) == "" {
+		return fmt.Errorf("empty room number")
+	}
+
+	if r.Type != nil {
+		if err := validateRoomType(*r.Type); err != nil {
+			return err
+		}
+	}
+
+	if r.Capacity != nil && *r.Capacity <= 0 {
+		return fmt.Errorf("capacity must be positive")
+	}
+
+	if r.Occupied != nil && *r.Occupied < 0 {
+		return fmt.Errorf("occupied must not be negative")
+	}
+
+	if r.Capacity != nil && r.Occupied != nil {
+		return validateRoomOccupancy(*r.Capacity, *r.Occupied)
+	}
+
+	return nil
+}
+
+func (r *UpdateRoomResponse) From(msg *dbtypes.UpdateRoomResponse) *UpdateRoomResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &UpdateRoomResponse{
+		RoomId: msg.RoomId,
+	}
+}
+
+type (
+	DeleteRoomRequest struct {
+		DormitoryId string
+		RoomId      string
+	}
+
+	DeleteRoomResponse struct {
+		RoomId string `json:"room_id"`
+	}
+)
+
+func (r *DeleteRoomResponse) From(msg *dbtypes.DeleteRoomResponse) *DeleteRoomResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &DeleteRoomResponse{
+		RoomId: msg.RoomId,
+	}
+}
+
+type OccupancyStats struct {
+	Rooms         int     `json:"rooms"`
+	Capacity      int     `json:"capacity"`
+	Occupied      int     `json:"occupied"`
+	Free          int     `json:"free"`
+	OccupancyRate float64 `json:"occupancy_rate"`
+}
+
+type BuildingOccupancy struct {
+	BuildingId   string                    `json:"building_id"`
+	BuildingName string                    `json:"building_name"`
+	Total        OccupancyStats            `json:"total"`
+	ByRoomType   map[string]OccupancyStats `json:"by_room_type"`
+}
+
+type (
+	GetDormitoryOccupancyRequest struct {
+		DormitoryId string
+	}
+
+	GetDormitoryOccupancyResponse struct {
+		DormitoryId string                    `json:"dormitory_id"`
+		Total       OccupancyStats            `json:"total"`
+		ByRoomType  map[string]OccupancyStats `json:"by_room_type"`
+		Buildings   []BuildingOccupancy       `json:"buildings"`
+	}
+)
+
+func (r *GetDormitoryOccupancyResponse) From(
+	dormitoryId string,
+	msg *dbtypes.GetDormitoryOccupancyResponse,
+) *GetDormitoryOccupancyResponse {
+	if msg == nil {
+		return nil
+	}
+
+	res := &GetDormitoryOccupancyResponse{
+		DormitoryId: dormitoryId,
+		ByRoomType:  make(map[string]OccupancyStats),
+		Buildings:   make([]BuildingOccupancy, 0),
+	}
+
+	buildingIdx := make(map[string]int)
+
+	for _, val := range msg.Buildings {
+		idx, ok := buildingIdx[val.BuildingId]
+		if !ok {
+			idx = len(res.Buildings)
+			buildingIdx[val.BuildingId] = idx
+
+			res.Buildings = append(res.Buildings, BuildingOccupancy{
+				BuildingId:   val.BuildingId,
+				BuildingName: val.BuildingName,
+				ByRoomType:   make(map[string]OccupancyStats),
+			})
+		}
+
+		building := &res.Buildings[idx]
+
+		building.ByRoomType[val.RoomType] = building.ByRoomType[val.RoomType].add(val.OccupancyStats)
+		building.Total = building.Total.add(val.OccupancyStats)
+
+		res.ByRoomType[val.RoomType] = res.ByRoomType[val.RoomType].add(val.OccupancyStats)
+		res.Total = res.Total.add(val.OccupancyStats)
+	}
+
+	return res
+}
+
+func (s OccupancyStats) add(msg dbtypes.OccupancyStats) OccupancyStats {
+	s.Rooms += msg.Rooms
+	s.Capacity += msg.Capacity
+	s.Occupied += msg.Occupied
+	s.Free = s.Capacity - s.Occupied
+
+	if s.Capacity > 0 {
+		s.OccupancyRate = math.Round(float64(s.Occupied)/float64(s.Capacity)*10000) / 10000
+	}
+
+	return s
+}
+
+func validateRoomType(roomType string) error {
+	switch roomType {
+	case dbtypes.RoomTypeCorridor, dbtypes.RoomTypeBlock:
+		return nil
+	default:
+		return fmt.Errorf("invalid room type: %q", roomType)
+	}
+}
+
+func validateRoomOccupancy(capacity, occupied int) error {
+	if capacity <= 0 {
+		return fmt.Errorf("capacity must be positive")
+	}
+
+	if occupied < 0 || occupied > capacity {
+		return fmt.Errorf("occupied must be between 0 and capacity")
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package requestmodels
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dormitory-life/core/internal/constants"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+var searchHighlightReplacer = strings.NewReplacer(
+	constants.SearchHighlightStart, "<mark>",
+	constants.SearchHighlightStop, "</mark>",
+)
+
+type SearchResult struct {
+	Id       string  `json:"id"`
+	AuthorId *string `json:"author_id,omitempty"`
+	// Title и Snippet - экранированный HTML, совпадения обернуты в <mark>
+	Title     string    `json:"title,omitempty"`
+	Snippet   string    `json:"snippet"`
+	Rank      float64   `json:"rank"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type (
+	SearchDormitoryRequest struct {
+		DormitoryId string
+		Query       string
+		Limit       uint64
+	}
+
+	SearchDormitoryResponse struct {
+		Query        string         `json:"query"`
+		Reviews      []SearchResult `json:"reviews"`
+		Events       []SearchResult `json:"events"`
+		ChatMessages []SearchResult `json:"chat_messages"`
+	}
+)
+
+func (*SearchDormitoryRequest) FromUrlQuery(query url.Values) (*SearchDormitoryRequest, error) {
+	res := &SearchDormitoryRequest{
+		Query: strings.TrimSpace(query.Get("q")),
+		Limit: constants.DefaultSearchGroupLimit,
+	}
+
+	if len(res.Query) == 0 {
+		return nil, fmt.Errorf("q param is required")
+	}
+
+	if utf8.RuneCountInString(res.Query) > constants.MaxSearchQueryLength {
+		return nil, fmt.Errorf("q param is longer than %d characters", constants.MaxSearchQueryLength)
+	}
+
+	if val, ok := query["limit"]; ok {
+		intVal, err := parseUint64(val[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit param: %w", err)
+		}
+
+		if intVal == 0 || intVal > constants.MaxSearchGroupLimit {
+			return nil, fmt.Errorf("limit param must be between 1 and %d", constants.MaxSearchGroupLimit)
+		}
+
+		res.Limit = intVal
+	}
+
+	return res, nil
+}
+
+func (r *SearchDormitoryResponse) From(query string, msg *dbtypes.SearchDormitoryResponse) *SearchDormitoryResponse {
+	if msg == nil {
+		return nil
+	}
+
+	return &SearchDormitoryResponse{
+		Query:        query,
+		Reviews:      convertSearchHits(msg.Reviews),
+		Events:       convertSearchHits(msg.Events),
+		ChatMessages: convertSearchHits(msg.ChatMessages),
+	}
+}
+
+func convertSearchHits(hits []dbtypes.SearchHit) []SearchResult {
+	results := make([]SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, SearchResult{
+			Id:        hit.Id,
+			AuthorId:  hit.AuthorId,
+			Title:     highlightSearchText(hit.Title),
+			Snippet:   highlightSearchText(hit.Snippet),
+			Rank:      hit.Rank,
+			CreatedAt: hit.CreatedAt,
+		})
+	}
+
+	return results
+}
+
+// highlightSearchText экранирует текст и только потом превращает маркеры ts_headline в теги,
+// чтобы HTML из пользовательского текста не попал клиенту
+func highlightSearchText(text string) string {
+	return searchHighlightReplacer.Replace(html.EscapeString(text))
+}
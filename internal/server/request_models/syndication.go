@@ -0,0 +1,327 @@
+package requestmodels
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/dormitory-life/core/internal/constants"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+)
+
+const (
+	atomNamespace   = "http://www.w3.org/2005/Atom"
+	atomContentType = "application/atom+xml; charset=utf-8"
+	rssContentType  = "application/rss+xml; charset=utf-8"
+)
+
+type (
+	GetEventsSyndicationRequest struct {
+		DormitoryId string
+		// Format - atom или rss
+		Format string
+		// SelfURL - адрес, по которому запрошена лента, агрегаторы сверяют его с подпиской
+		SelfURL     string
+		IfNoneMatch string
+	}
+
+	GetEventsSyndicationResponse struct {
+		Feed        []byte
+		ContentType string
+		// ETag - сильный валидатор ленты
+		ETag string
+		// Updated - время последнего изменения постов ленты
+		Updated time.Time
+		// LastModified - изменение ленты целиком, включая переподпись ссылок на фото
+		LastModified time.Time
+		// MaxAge - сколько копию можно держать без проверки, не дольше текущего окна ссылок
+		MaxAge time.Duration
+		// NotModified - лента совпала с If-None-Match, Feed не заполнен
+		NotModified bool
+	}
+
+	// SyndicationEnclosure - первое фото поста, прикладывается к записи ленты
+	SyndicationEnclosure struct {
+		URL      string
+		Size     int64
+		MimeType string
+	}
+)
+
+type (
+	atomFeed struct {
+		XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+		Id        string      `xml:"id"`
+		Title     string      `xml:"title"`
+		Updated   string      `xml:"updated"`
+		Link      atomLink    `xml:"link"`
+		Author    atomAuthor  `xml:"author"`
+		Generator string      `xml:"generator"`
+		Entries   []atomEntry `xml:"entry"`
+	}
+
+	atomLink struct {
+		Rel    string `xml:"rel,attr"`
+		Href   string `xml:"href,attr"`
+		Type   string `xml:"type,attr,omitempty"`
+		Length int64  `xml:"length,attr,omitempty"`
+	}
+
+	atomAuthor struct {
+		Name string `xml:"name"`
+	}
+
+	atomEntry struct {
+		Id        string        `xml:"id"`
+		Title     string        `xml:"title"`
+		Published string        `xml:"published"`
+		Updated   string        `xml:"updated"`
+		Content   atomContent   `xml:"content"`
+		Category  *atomCategory `xml:"category"`
+		Links     []atomLink    `xml:"link"`
+	}
+
+	atomContent struct {
+		Type string `xml:"type,attr"`
+		Text string `xml:",chardata"`
+	}
+
+	atomCategory struct {
+		Term string `xml:"term,attr"`
+	}
+)
+
+type (
+	rssFeed struct {
+		XMLName xml.Name   `xml:"rss"`
+		Version string     `xml:"version,attr"`
+		AtomNs  string     `xml:"xmlns:atom,attr"`
+		Channel rssChannel `xml:"channel"`
+	}
+
+	rssChannel struct {
+		Title       string `xml:"title"`
+		Link        string `xml:"link"`
+		Description string `xml:"description"`
+		// SelfLink - atom:link rel="self", рекомендован валидаторами RSS
+		SelfLink      atomLink  `xml:"atom:link"`
+		LastBuildDate string    `xml:"lastBuildDate"`
+		Generator     string    `xml:"generator"`
+		Ttl           int       `xml:"ttl"`
+		Items         []rssItem `xml:"item"`
+	}
+
+	rssItem struct {
+		Title       string        `xml:"title"`
+		Description string        `xml:"description"`
+		Guid        rssGuid       `xml:"guid"`
+		PubDate     string        `xml:"pubDate"`
+		Category    string        `xml:"category,omitempty"`
+		Enclosure   *rssEnclosure `xml:"enclosure"`
+	}
+
+	rssGuid struct {
+		IsPermaLink bool   `xml:"isPermaLink,attr"`
+		Id          string `xml:",chardata"`
+	}
+
+	rssEnclosure struct {
+		URL    string `xml:"url,attr"`
+		Length int64  `xml:"length,attr"`
+		Type   string `xml:"type,attr"`
+	}
+)
+
+// NewSyndicationValidators считает валидаторы и время кеширования ленты без ее рендера,
+// чтобы ответить 304 до обращения к хранилищу
+func NewSyndicationValidators(
+	request *GetEventsSyndicationRequest,
+	dormitoryName string,
+	events []dbtypes.Event,
+	now time.Time,
+) *GetEventsSyndicationResponse {
+	var (
+		linksSignedAt = now.UTC().Truncate(constants.SyndicationLinksWindow)
+		updated       = SyndicationUpdated(events)
+		lastModified  = updated
+	)
+
+	if linksSignedAt.After(lastModified) {
+		lastModified = linksSignedAt
+	}
+
+	return &GetEventsSyndicationResponse{
+		ETag:         SyndicationETag(request, dormitoryName, events, linksSignedAt),
+		Updated:      updated,
+		LastModified: lastModified,
+		MaxAge:       min(constants.SyndicationMaxAge, linksSignedAt.Add(constants.SyndicationLinksWindow).Sub(now)),
+	}
+}
+
+// From рендерит посты в ленту Atom (RFC 4287) или RSS 2.0.
+// enclosures - первое фото поста по id поста, у поста без фото записи нет
+func (r *GetEventsSyndicationResponse) From(
+	request *GetEventsSyndicationRequest,
+	dormitoryName string,
+	msg *dbtypes.GetEventsSyndicationResponse,
+	enclosures map[string]*SyndicationEnclosure,
+	now time.Time,
+) (*GetEventsSyndicationResponse, error) {
+	if request == nil || msg == nil {
+		return nil, nil
+	}
+
+	resp := NewSyndicationValidators(request, dormitoryName, msg.Events, now)
+
+	var feed any
+
+	switch request.Format {
+	case constants.SyndicationFormatAtom:
+		resp.ContentType = atomContentType
+		feed = newAtomFeed(request, dormitoryName, resp.Updated, msg.Events, enclosures)
+	case constants.SyndicationFormatRss:
+		resp.ContentType = rssContentType
+		feed = newRssFeed(request, dormitoryName, resp.Updated, msg.Events, enclosures)
+	default:
+		return nil, fmt.Errorf("unknown feed format: %s", request.Format)
+	}
+
+	body, err := xml.Marshal(feed)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling feed: %w", err)
+	}
+
+	resp.Feed = append([]byte(xml.Header), body...)
+
+	return resp, nil
+}
+
+func newAtomFeed(
+	request *GetEventsSyndicationRequest,
+	dormitoryName string,
+	updated time.Time,
+	events []dbtypes.Event,
+	enclosures map[string]*SyndicationEnclosure,
+) *atomFeed {
+	feed := atomFeed{
+		Id:        constants.SyndicationIdPrefix + "dormitory:" + request.DormitoryId,
+		Title:     dormitoryName,
+		Updated:   updated.UTC().Format(time.RFC3339),
+		Link:      atomLink{Rel: "self", Href: request.SelfURL, Type: "application/atom+xml"},
+		Author:    atomAuthor{Name: dormitoryName},
+		Generator: constants.SyndicationGenerator,
+		Entries:   make([]atomEntry, 0, len(events)),
+	}
+
+	for _, event := range events {
+		entry := atomEntry{
+			Id:        constants.SyndicationIdPrefix + "post:" + event.EventId,
+			Title:     event.Title,
+			Published: event.CreatedAt.UTC().Format(time.RFC3339),
+			Updated:   event.UpdatedAt.UTC().Format(time.RFC3339),
+			Content:   atomContent{Type: "text", Text: event.Description},
+		}
+
+		if len(event.Category) != 0 {
+			entry.Category = &atomCategory{Term: event.Category}
+		}
+
+		if enclosure := enclosures[event.EventId]; enclosure != nil {
+			entry.Links = append(entry.Links, atomLink{
+				Rel:    "enclosure",
+				Href:   enclosure.URL,
+				Type:   enclosure.MimeType,
+				Length: enclosure.Size,
+			})
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return &feed
+}
+
+func newRssFeed(
+	request *GetEventsSyndicationRequest,
+	dormitoryName string,
+	updated time.Time,
+	events []dbtypes.Event,
+	enclosures map[string]*SyndicationEnclosure,
+) *rssFeed {
+	feed := rssFeed{
+		Version: "2.0",
+		AtomNs:  atomNamespace,
+		Channel: rssChannel{
+			Title:         dormitoryName,
+			Link:          request.SelfURL,
+			Description:   fmt.Sprintf("Лента общежития %s", dormitoryName),
+			SelfLink:      atomLink{Rel: "self", Href: request.SelfURL, Type: "application/rss+xml"},
+			LastBuildDate: updated.UTC().Format(time.RFC1123Z),
+			Generator:     constants.SyndicationGenerator,
+			Ttl:           int(constants.SyndicationMaxAge / time.Minute),
+			Items:         make([]rssItem, 0, len(events)),
+		},
+	}
+
+	for _, event := range events {
+		item := rssItem{
+			Title:       event.Title,
+			Description: event.Description,
+			Guid:        rssGuid{IsPermaLink: false, Id: constants.SyndicationIdPrefix + "post:" + event.EventId},
+			PubDate:     event.CreatedAt.UTC().Format(time.RFC1123Z),
+			Category:    event.Category,
+		}
+
+		if enclosure := enclosures[event.EventId]; enclosure != nil {
+			item.Enclosure = &rssEnclosure{
+				URL:    enclosure.URL,
+				Length: enclosure.Size,
+				Type:   enclosure.MimeType,
+			}
+		}
+
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+
+	return &feed
+}
+
+// SyndicationUpdated - самое позднее изменение поста. Пустая лента считается
+// измененной в момент запроса, иначе у Atom не будет обязательного updated
+func SyndicationUpdated(events []dbtypes.Event) time.Time {
+	var updated time.Time
+
+	for _, event := range events {
+		if event.UpdatedAt.After(updated) {
+			updated = event.UpdatedAt
+		}
+	}
+
+	if updated.IsZero() {
+		return time.Now().UTC().Truncate(time.Second)
+	}
+
+	return updated.UTC().Truncate(time.Second)
+}
+
+// SyndicationETag считается по строкам ленты, а не по ее телу: ссылки на фото подписаны
+// и меняются при каждом запросе. Смена фото сдвигает updated_at поста, а окно подписи
+// linksSignedAt меняет валидатор раньше, чем истекут ссылки в копии агрегатора
+func SyndicationETag(
+	request *GetEventsSyndicationRequest,
+	dormitoryName string,
+	events []dbtypes.Event,
+	linksSignedAt time.Time,
+) string {
+	hash := sha256.New()
+
+	fmt.Fprintf(hash, "%s\n%s\n%s\n%d\n", request.Format, request.SelfURL, dormitoryName, linksSignedAt.Unix())
+
+	for _, event := range events {
+		fmt.Fprintf(hash, "%s %d\n", event.EventId, event.UpdatedAt.UnixNano())
+	}
+
+	return fmt.Sprintf("%q", hex.EncodeToString(hash.Sum(nil)[:16]))
+}
@@ -15,6 +15,9 @@ import (
 // @Description Получение отзывов общежития
 // @Tags Reviews
 // @Produce json
+// @Params sort query string false "newest (по умолчанию), oldest, most_helpful или controversial"
+// @Params min_grade query number false "Минимальный общий балл, который автор поставил общежитию в месяц отзыва"
+// @Params max_grade query number false "Максимальный общий балл автора"
 // @Success 200 {object} rmodel.GetDormitoryReviewsResponse "Отзывы"
 // @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
 // @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
@@ -148,6 +151,103 @@ func (s *Server) deleteReviewHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// @Summary Изменить отзыв
+// @Description Изменение заголовка, описания и фотографий отзыва. Доступно только автору
+// @Tags Reviews
+// @Accept multipart/form-data
+// @Produce json
+// @Param dormitory_id path string true "ID общежития"
+// @Param review_id path string true "ID отзыва"
+// @Param title formData string false "Новый заголовок отзыва"
+// @Param description formData string false "Новое описание отзыва"
+// @Param photos formData []file false "Добавляемые фотографии" collectionFormat(multi)
+// @Param remove_photos formData []string false "Пути или имена удаляемых фотографий" collectionFormat(multi)
+// @Success 200 {object} rmodel.UpdateReviewResponse "Отзыв изменен"
+// @Failure 400 {object} rmodel.ErrorResponse "Некорректные данные формы"
+// @Failure 401 {object} rmodel.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Отзыв не найден"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/reviews/{review_id} [put]
+func (s *Server) updateReviewHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "updateReviewHandler"
+
+	req, err := s.parseUpdateReviewRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	resp, err := s.coreService.UpdateReview(r.Context(), req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary История правок отзыва
+// @Description Список правок отзыва (старые и новые значения, добавленные и удаленные фото) для модераторов
+// @Tags Reviews
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params review_id path string true "ID отзыва"
+// @Success 200 {object} rmodel.GetReviewEditsResponse "История правок"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Отзыв не найден"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/reviews/{review_id}/edits [get]
+func (s *Server) getReviewEditsHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getReviewEditsHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		reviewId    = vars["review_id"]
+	)
+
+	resp, err := s.coreService.GetReviewEdits(r.Context(), &rmodel.GetReviewEditsRequest{
+		DormitoryId: dormitoryId,
+		ReviewId:    reviewId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
 func (s *Server) parseCreateReviewRequest(w http.ResponseWriter, r *http.Request) (*rmodel.CreateReviewRequest, error) {
 	var (
 		vars        = mux.Vars(r)
@@ -190,3 +290,42 @@ func (s *Server) parseCreateReviewRequest(w http.ResponseWriter, r *http.Request
 
 	return req, nil
 }
+
+// parseUpdateReviewRequest - поля формы необязательные, отсутствующее поле не меняется
+func (s *Server) parseUpdateReviewRequest(w http.ResponseWriter, r *http.Request) (*rmodel.UpdateReviewRequest, error) {
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		reviewId    = vars["review_id"]
+	)
+
+	req := &rmodel.UpdateReviewRequest{
+		DormitoryId: dormitoryId,
+		ReviewId:    reviewId,
+	}
+
+	err := r.ParseMultipartForm(50 << 20)
+	if err != nil {
+		writeErrorResponse(w, fmt.Errorf("failed to parse form: %v", err), http.StatusBadRequest)
+		s.logger.Error("parse form error", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if val, ok := r.MultipartForm.Value["title"]; ok && len(val) > 0 {
+		req.Title = &val[0]
+	}
+
+	if val, ok := r.MultipartForm.Value["description"]; ok && len(val) > 0 {
+		req.Description = &val[0]
+	}
+
+	req.PhotoFilesHeaders = r.MultipartForm.File["photos"]
+	req.RemovePhotos = r.MultipartForm.Value["remove_photos"]
+
+	if err := req.Validate(); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		return nil, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Пожаловаться на отзыв
+// @Description Жалоба пользователя на отзыв. Опубликованный отзыв уходит на проверку администратору
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params review_id path string true "ID отзыва"
+// @Params request body rmodel.CreateReviewReportRequest true "Причина: spam, abuse, personal_data, misinformation или other"
+// @Success 201 {object} rmodel.CreateReviewReportResponse "Жалоба принята"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Отзыв не найден"
+// @Failure 409 {object} rmodel.ErrorResponse "Жалоба уже отправлена"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/reviews/{review_id}/reports [post]
+func (s *Server) createReviewReportHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "createReviewReportHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		reviewId    = vars["review_id"]
+	)
+
+	var req rmodel.CreateReviewReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+	req.ReviewId = reviewId
+
+	resp, err := s.coreService.CreateReviewReport(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Отзывы на модерации
+// @Description Очередь отзывов, на которые пожаловались (pending), или скрытых отзывов вместе с жалобами
+// @Tags Reviews
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params status query string false "pending (по умолчанию) или hidden"
+// @Params page query int false "Номер страницы"
+// @Success 200 {object} rmodel.GetModerationReviewsResponse "Отзывы"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/reviews/moderation [get]
+func (s *Server) getModerationReviewsHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getModerationReviewsHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	req, err := new(rmodel.GetModerationReviewsRequest).FromUrlQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing query",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+
+	resp, err := s.coreService.GetModerationReviews(r.Context(), req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Скрыть или вернуть отзыв
+// @Description Решение администратора по отзыву: hidden скрывает его, published возвращает. Открытые жалобы закрываются
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params review_id path string true "ID отзыва"
+// @Params request body rmodel.ModerateReviewRequest true "Решение"
+// @Success 200 {object} rmodel.ModerateReviewResponse "Решение сохранено"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Отзыв не найден"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/reviews/{review_id}/moderation [put]
+func (s *Server) moderateReviewHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "moderateReviewHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		reviewId    = vars["review_id"]
+	)
+
+	var req rmodel.ModerateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+	req.ReviewId = reviewId
+
+	resp, err := s.coreService.ModerateReview(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
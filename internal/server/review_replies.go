@@ -0,0 +1,111 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Ответить на отзыв
+// @Description Официальный ответ администрации общежития на отзыв. Автору отзыва приходит письмо
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params review_id path string true "ID отзыва"
+// @Params request body rmodel.CreateReviewReplyRequest true "Текст ответа"
+// @Success 201 {object} rmodel.CreateReviewReplyResponse "Ответ опубликован"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Отзыв не найден"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/reviews/{review_id}/replies [post]
+func (s *Server) createReviewReplyHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "createReviewReplyHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		reviewId    = vars["review_id"]
+	)
+
+	var req rmodel.CreateReviewReplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+	req.ReviewId = reviewId
+
+	resp, err := s.coreService.CreateReviewReply(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Удалить ответ на отзыв
+// @Description Удаляет ответ администрации на отзыв
+// @Tags Reviews
+// @Params dormitory_id path string true "ID общежития"
+// @Params review_id path string true "ID отзыва"
+// @Params reply_id path string true "ID ответа"
+// @Success 204
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Ответ не найден"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/reviews/{review_id}/replies/{reply_id} [delete]
+func (s *Server) deleteReviewReplyHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "deleteReviewReplyHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		reviewId    = vars["review_id"]
+		replyId     = vars["reply_id"]
+	)
+
+	_, err := s.coreService.DeleteReviewReply(r.Context(), &rmodel.DeleteReviewReplyRequest{
+		DormitoryId: dormitoryId,
+		ReviewId:    reviewId,
+		ReplyId:     replyId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
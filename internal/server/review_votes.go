@@ -0,0 +1,118 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Оценить полезность отзыва
+// @Description Голос helpful или unhelpful. Повторный голос пользователя заменяет предыдущий
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params review_id path string true "ID отзыва"
+// @Params request body rmodel.VoteReviewRequest true "Голос"
+// @Success 200 {object} rmodel.VoteReviewResponse "Счетчики голосов"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Отзыв не найден"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/reviews/{review_id}/vote [put]
+func (s *Server) voteReviewHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "voteReviewHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		reviewId    = vars["review_id"]
+	)
+
+	var req rmodel.VoteReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+	req.ReviewId = reviewId
+
+	resp, err := s.coreService.VoteReview(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Снять голос
+// @Description Удаляет голос пользователя за полезность отзыва
+// @Tags Reviews
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params review_id path string true "ID отзыва"
+// @Success 200 {object} rmodel.VoteReviewResponse "Счетчики голосов"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Отзыв не найден"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /core/dormitories/{dormitory_id}/reviews/{review_id}/vote [delete]
+func (s *Server) deleteReviewVoteHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "deleteReviewVoteHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		reviewId    = vars["review_id"]
+	)
+
+	resp, err := s.coreService.DeleteReviewVote(r.Context(), &rmodel.DeleteReviewVoteRequest{
+		DormitoryId: dormitoryId,
+		ReviewId:    reviewId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
@@ -0,0 +1,466 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Получение комнат общежития
+// @Description Получение корпусов, этажей и комнат общежития в виде дерева
+// @Tags Rooms
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Success 200 {object} rmodel.GetDormitoryRoomsResponse "Корпуса, этажи и комнаты"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/rooms [get]
+func (s *Server) getDormitoryRoomsHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getDormitoryRoomsHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	resp, err := s.coreService.GetDormitoryRooms(r.Context(), &rmodel.GetDormitoryRoomsRequest{
+		DormitoryId: dormitoryId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Заполненность общежития
+// @Description Статистика вместимости и заселенности по корпусам и типам комнат
+// @Tags Rooms
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Success 200 {object} rmodel.GetDormitoryOccupancyResponse "Статистика заполненности"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/rooms/occupancy [get]
+func (s *Server) getDormitoryOccupancyHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "getDormitoryOccupancyHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	resp, err := s.coreService.GetDormitoryOccupancy(r.Context(), &rmodel.GetDormitoryOccupancyRequest{
+		DormitoryId: dormitoryId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Создание корпуса
+// @Description Добавляет корпус (блок) в общежитие
+// @Tags Rooms
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params request body rmodel.CreateBuildingRequest true "Корпус"
+// @Success 201 {object} rmodel.CreateBuildingResponse "Корпус создан"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 409 {object} rmodel.ErrorResponse "Корпус уже существует"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/rooms/buildings [post]
+func (s *Server) createBuildingHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "createBuildingHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	var req rmodel.CreateBuildingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+
+	resp, err := s.coreService.CreateBuilding(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Удаление корпуса
+// @Description Удаляет корпус вместе с его этажами и комнатами
+// @Tags Rooms
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params building_id path string true "ID корпуса"
+// @Success 200 {object} rmodel.DeleteBuildingResponse "Корпус удален"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Корпус не найден"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/rooms/buildings/{building_id} [delete]
+func (s *Server) deleteBuildingHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "deleteBuildingHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		buildingId  = vars["building_id"]
+	)
+
+	resp, err := s.coreService.DeleteBuilding(r.Context(), &rmodel.DeleteBuildingRequest{
+		DormitoryId: dormitoryId,
+		BuildingId:  buildingId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Создание этажа
+// @Description Добавляет этаж в корпус общежития
+// @Tags Rooms
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params building_id path string true "ID корпуса"
+// @Params request body rmodel.CreateFloorRequest true "Этаж"
+// @Success 201 {object} rmodel.CreateFloorResponse "Этаж создан"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Корпус не найден"
+// @Failure 409 {object} rmodel.ErrorResponse "Этаж уже существует"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/rooms/buildings/{building_id}/floors [post]
+func (s *Server) createFloorHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "createFloorHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		buildingId  = vars["building_id"]
+	)
+
+	var req rmodel.CreateFloorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+	req.BuildingId = buildingId
+
+	resp, err := s.coreService.CreateFloor(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Удаление этажа
+// @Description Удаляет этаж вместе с его комнатами
+// @Tags Rooms
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params floor_id path string true "ID этажа"
+// @Success 200 {object} rmodel.DeleteFloorResponse "Этаж удален"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Этаж не найден"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/rooms/floors/{floor_id} [delete]
+func (s *Server) deleteFloorHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "deleteFloorHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		floorId     = vars["floor_id"]
+	)
+
+	resp, err := s.coreService.DeleteFloor(r.Context(), &rmodel.DeleteFloorRequest{
+		DormitoryId: dormitoryId,
+		FloorId:     floorId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Создание комнаты
+// @Description Добавляет комнату на этаж общежития
+// @Tags Rooms
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params request body rmodel.CreateRoomRequest true "Комната"
+// @Success 201 {object} rmodel.CreateRoomResponse "Комната создана"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Этаж не найден"
+// @Failure 409 {object} rmodel.ErrorResponse "Комната уже существует"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/rooms [post]
+func (s *Server) createRoomHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "createRoomHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	var req rmodel.CreateRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+
+	resp, err := s.coreService.CreateRoom(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Обновление комнаты
+// @Description Частично обновляет номер, тип, вместимость или число жильцов комнаты
+// @Tags Rooms
+// @Accept json
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params room_id path string true "ID комнаты"
+// @Params request body rmodel.UpdateRoomRequest true "Информация для обновления"
+// @Success 200 {object} rmodel.UpdateRoomResponse "Комната обновлена"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные данные / параметры запроса"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Комната не найдена"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/rooms/{room_id} [put]
+func (s *Server) updateRoomHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "updateRoomHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		roomId      = vars["room_id"]
+	)
+
+	var req rmodel.UpdateRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error decoding request",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+	req.RoomId = roomId
+
+	resp, err := s.coreService.UpdateRoom(r.Context(), &req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// @Summary Удаление комнаты
+// @Description Удаляет комнату общежития
+// @Tags Rooms
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params room_id path string true "ID комнаты"
+// @Success 200 {object} rmodel.DeleteRoomResponse "Комната удалена"
+// @Failure 403 {object} rmodel.ErrorResponse "Нет прав на действие"
+// @Failure 404 {object} rmodel.ErrorResponse "Комната не найдена"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/rooms/{room_id} [delete]
+func (s *Server) deleteRoomHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "deleteRoomHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+		roomId      = vars["room_id"]
+	)
+
+	resp, err := s.coreService.DeleteRoom(r.Context(), &rmodel.DeleteRoomRequest{
+		DormitoryId: dormitoryId,
+		RoomId:      roomId,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
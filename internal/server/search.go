@@ -0,0 +1,66 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Поиск по общежитию
+// @Description Полнотекстовый поиск по отзывам, событиям и чату с учетом русской морфологии.
+// @Description Результаты сгруппированы по типу и отсортированы по релевантности, совпадения обернуты в <mark>
+// @Tags Search
+// @Produce json
+// @Params dormitory_id path string true "ID общежития"
+// @Params q query string true "Поисковый запрос, поддерживает синтаксис websearch: \"фразы\", OR, -исключения"
+// @Params limit query int false "Сколько результатов каждого типа вернуть, по умолчанию 10"
+// @Success 200 {object} rmodel.SearchDormitoryResponse "Результаты поиска"
+// @Failure 400 {object} rmodel.ErrorResponse "Неверные параметры запроса"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/search [get]
+func (s *Server) searchDormitoryHandler(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "searchDormitoryHandler"
+
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	req, err := new(rmodel.SearchDormitoryRequest).FromUrlQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err, http.StatusBadRequest)
+		s.logger.Error("error parsing query",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	req.DormitoryId = dormitoryId
+
+	resp, err := s.coreService.SearchDormitory(r.Context(), req)
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		writeErrorResponse(w, err, http.StatusInternalServerError)
+		s.logger.Error("error encoding response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
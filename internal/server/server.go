@@ -38,27 +38,75 @@ func (s *Server) setupRouter() http.Handler {
 	router.HandleFunc("/core/ping", s.pingHandler).Methods("GET")
 
 	router.HandleFunc("/core/dormitories/grades", s.getDormitoriesAvgGradesHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/grades/leaderboard", s.getGradesLeaderboardHandler).Methods("GET")
 	router.HandleFunc("/core/dormitories/{dormitory_id}/grades", s.getDormitoryAvgGradesHandler).Methods("GET")
 	router.HandleFunc("/core/dormitories/{dormitory_id}/grades", s.createDormitoryGradeHandler).Methods("POST")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/grades/history", s.getDormitoryGradesHistoryHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/grades/distribution", s.getGradesDistributionHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/grades/mine", s.updateDormitoryGradeHandler).Methods("PUT")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/grades/mine", s.deleteDormitoryGradeHandler).Methods("DELETE")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/grades/moderation", s.getModerationGradesHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/grades/{grade_id}/moderation", s.moderateGradeHandler).Methods("PUT")
+	router.HandleFunc("/core/dormitories/grades/criteria", s.getGradeCriteriaHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/grades/criteria", s.createGradeCriterionHandler).Methods("POST")
+	router.HandleFunc("/core/dormitories/grades/criteria/{code}", s.updateGradeCriterionHandler).Methods("PUT")
+
+	router.HandleFunc("/core/dormitories/amenities", s.getAmenitiesHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/amenities", s.createAmenityHandler).Methods("POST")
+	router.HandleFunc("/core/dormitories/amenities/{amenity_id}", s.updateAmenityHandler).Methods("PUT")
+	router.HandleFunc("/core/dormitories/amenities/{amenity_id}", s.deleteAmenityHandler).Methods("DELETE")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/amenities", s.setDormitoryAmenitiesHandler).Methods("PUT")
 
 	router.HandleFunc("/core/dormitories", s.getDormitoriesHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/nearby", s.getNearbyDormitoriesHandler).Methods("GET")
 	router.HandleFunc("/core/dormitories/{dormitory_id}", s.getDormitoryByIdHandler).Methods("GET")
 	router.HandleFunc("/core/dormitories", s.createDormitoryHandler).Methods("POST")
 	router.HandleFunc("/core/dormitories/{dormitory_id}", s.updateDormitoryHandler).Methods("PUT")
 	router.HandleFunc("/core/dormitories/{dormitory_id}", s.deleteDormitoryHandler).Methods("DELETE")
 
+	router.HandleFunc("/core/dormitories/{dormitory_id}/rooms", s.getDormitoryRoomsHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/rooms/occupancy", s.getDormitoryOccupancyHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/rooms/buildings", s.createBuildingHandler).Methods("POST")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/rooms/buildings/{building_id}", s.deleteBuildingHandler).Methods("DELETE")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/rooms/buildings/{building_id}/floors", s.createFloorHandler).Methods("POST")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/rooms/floors/{floor_id}", s.deleteFloorHandler).Methods("DELETE")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/rooms", s.createRoomHandler).Methods("POST")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/rooms/{room_id}", s.updateRoomHandler).Methods("PUT")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/rooms/{room_id}", s.deleteRoomHandler).Methods("DELETE")
+
 	router.HandleFunc("/core/dormitories/{dormitory_id}/photos", s.createDormitoryPhotosHandler).Methods("POST")
 	router.HandleFunc("/core/dormitories/{dormitory_id}/photos", s.deleteDormitoryPhotosHandler).Methods("DELETE")
 
 	router.HandleFunc("/core/dormitories/support", s.createSupportRequestHandler).Methods("POST")
 
+	router.HandleFunc("/core/dormitories/{dormitory_id}/search", s.searchDormitoryHandler).Methods("GET")
+
 	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews", s.getReviewsHandler).Methods("GET")
 	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews", s.createReviewHandler).Methods("POST")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews/{review_id}", s.updateReviewHandler).Methods("PUT")
 	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews/{review_id}", s.deleteReviewHandler).Methods("DELETE")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews/{review_id}/edits", s.getReviewEditsHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews/moderation", s.getModerationReviewsHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews/{review_id}/reports", s.createReviewReportHandler).Methods("POST")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews/{review_id}/moderation", s.moderateReviewHandler).Methods("PUT")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews/{review_id}/replies", s.createReviewReplyHandler).Methods("POST")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews/{review_id}/replies/{reply_id}", s.deleteReviewReplyHandler).Methods("DELETE")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews/{review_id}/vote", s.voteReviewHandler).Methods("PUT")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/reviews/{review_id}/vote", s.deleteReviewVoteHandler).Methods("DELETE")
 
 	router.HandleFunc("/core/dormitories/{dormitory_id}/events", s.getDormitoryEventsHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/events.ics", s.getEventsCalendarHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/feed.atom", s.getEventsAtomHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/feed.rss", s.getEventsRssHandler).Methods("GET")
 	router.HandleFunc("/core/dormitories/{dormitory_id}/events", s.createDormitoryEventHandler).Methods("POST")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/events/{event_id}", s.updateDormitoryEventHandler).Methods("PUT")
 	router.HandleFunc("/core/dormitories/{dormitory_id}/events/{event_id}", s.deleteDormitoryEventHandler).Methods("DELETE")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/events/{event_id}/rsvp", s.rsvpEventHandler).Methods("PUT")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/events/{event_id}/rsvp", s.deleteEventRsvpHandler).Methods("DELETE")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/events/{event_id}/attendees", s.getEventAttendeesHandler).Methods("GET")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/events/{event_id}/occurrences/{occurrence_start}", s.updateEventOccurrenceHandler).Methods("PUT")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/events/{event_id}/occurrences/{occurrence_start}", s.cancelEventOccurrenceHandler).Methods("DELETE")
+	router.HandleFunc("/core/dormitories/{dormitory_id}/events/{event_id}/announcement", s.updateEventAnnouncementHandler).Methods("PUT")
 
 	router.HandleFunc("/core/dormitories/{dormitory_id}/chat", s.getDormitoryChatHandler).Methods("GET")
 	router.HandleFunc("/core/dormitories/{dormitory_id}/chat", s.createChatMessageHandler).Methods("POST")
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/dormitory-life/core/internal/constants"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/gorilla/mux"
+)
+
+// @Summary Лента общежития в формате Atom
+// @Description Последние посты ленты в формате Atom (RFC 4287) для агрегаторов.
+// @Description Первое фото поста прикладывается к записи как enclosure.
+// @Description Поддерживает условный запрос с If-None-Match
+// @Tags Feed
+// @Produce application/atom+xml
+// @Params dormitory_id path string true "ID общежития"
+// @Success 200 {string} string "Лента Atom"
+// @Success 304 "Лента не изменилась"
+// @Failure 404 {object} rmodel.ErrorResponse "Общежитие не найдено"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/feed.atom [get]
+func (s *Server) getEventsAtomHandler(w http.ResponseWriter, r *http.Request) {
+	s.writeEventsSyndication(w, r, constants.SyndicationFormatAtom, "getEventsAtomHandler")
+}
+
+// @Summary Лента общежития в формате RSS 2.0
+// @Description Последние посты ленты в формате RSS 2.0 для агрегаторов.
+// @Description Первое фото поста прикладывается к записи как enclosure.
+// @Description Поддерживает условный запрос с If-None-Match
+// @Tags Feed
+// @Produce application/rss+xml
+// @Params dormitory_id path string true "ID общежития"
+// @Success 200 {string} string "Лента RSS"
+// @Success 304 "Лента не изменилась"
+// @Failure 404 {object} rmodel.ErrorResponse "Общежитие не найдено"
+// @Failure 500 {object} rmodel.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /core/dormitories/{dormitory_id}/feed.rss [get]
+func (s *Server) getEventsRssHandler(w http.ResponseWriter, r *http.Request) {
+	s.writeEventsSyndication(w, r, constants.SyndicationFormatRss, "getEventsRssHandler")
+}
+
+func (s *Server) writeEventsSyndication(w http.ResponseWriter, r *http.Request, format, handlerName string) {
+	var (
+		vars        = mux.Vars(r)
+		dormitoryId = vars["dormitory_id"]
+	)
+
+	resp, err := s.coreService.GetEventsSyndication(r.Context(), &rmodel.GetEventsSyndicationRequest{
+		DormitoryId: dormitoryId,
+		Format:      format,
+		SelfURL:     requestURL(r),
+		IfNoneMatch: r.Header.Get("If-None-Match"),
+	})
+	if err != nil {
+		s.handleError(w, err)
+		s.logger.Error("error",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+
+		return
+	}
+
+	w.Header().Set("ETag", resp.ETag)
+	w.Header().Set("Last-Modified", resp.LastModified.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(resp.MaxAge.Seconds())))
+
+	// If-Modified-Since не проверяем: удаленный или истекший пост не сдвигает
+	// время изменения ленты, и по нему клиент получил бы устаревшую копию
+	if resp.NotModified {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", resp.ContentType)
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(resp.Feed); err != nil {
+		s.logger.Error("error writing response",
+			slog.String("error", err.Error()),
+			slog.String("handler", handlerName),
+		)
+	}
+}
+
+// requestURL восстанавливает внешний адрес запроса с учетом прокси перед сервисом
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if proto := r.Header.Get("X-Forwarded-Proto"); len(proto) != 0 {
+		scheme = proto
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, r.Host, r.URL.Path)
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+func (s *CoreService) GetAmenities(
+	ctx context.Context,
+	request *rmodel.GetAmenitiesRequest,
+) (*rmodel.GetAmenitiesResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	resp, err := s.repository.GetAmenities(ctx, &dbtypes.GetAmenitiesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting amenities: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.GetAmenitiesResponse).From(resp), nil
+}
+
+func (s *CoreService) CreateAmenity(
+	ctx context.Context,
+	request *rmodel.CreateAmenityRequest,
+) (*rmodel.CreateAmenityResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkCatalogAdminAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	resp, err := s.repository.CreateAmenity(ctx, &dbtypes.CreateAmenityRequest{
+		Code: request.Code,
+		Name: request.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error creating amenity: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.CreateAmenityResponse).From(resp), nil
+}
+
+func (s *CoreService) UpdateAmenity(
+	ctx context.Context,
+	request *rmodel.UpdateAmenityRequest,
+) (*rmodel.UpdateAmenityResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkCatalogAdminAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	resp, err := s.repository.UpdateAmenity(ctx, &dbtypes.UpdateAmenityRequest{
+		AmenityId: request.AmenityId,
+		Code:      request.Code,
+		Name:      request.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error updating amenity: %v", s.handleDBError(err), err)
+	}
+
+	res := new(rmodel.UpdateAmenityResponse).From(resp)
+
+	dormitories, err := s.repository.GetAmenityDormitories(ctx, &dbtypes.GetAmenityDormitoriesRequest{
+		AmenityId: request.AmenityId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: amenity updated, error getting its dormitories: %v", s.handleDBError(err), err)
+	}
+
+	go s.invalidateAmenityDormitoriesCache(ctx, dormitories.DormitoryIds)
+
+	return res, nil
+}
+
+func (s *CoreService) DeleteAmenity(
+	ctx context.Context,
+	request *rmodel.DeleteAmenityRequest,
+) (*rmodel.DeleteAmenityResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkCatalogAdminAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	// links are dropped by cascade, so affected dormitories are collected beforehand
+	dormitories, err := s.repository.GetAmenityDormitories(ctx, &dbtypes.GetAmenityDormitoriesRequest{
+		AmenityId: request.AmenityId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting amenity dormitories: %v", s.handleDBError(err), err)
+	}
+
+	resp, err := s.repository.DeleteAmenity(ctx, &dbtypes.DeleteAmenityRequest{
+		AmenityId: request.AmenityId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error deleting amenity: %v", s.handleDBError(err), err)
+	}
+
+	res := new(rmodel.DeleteAmenityResponse).From(resp)
+
+	go s.invalidateAmenityDormitoriesCache(ctx, dormitories.DormitoryIds)
+
+	return res, nil
+}
+
+func (s *CoreService) SetDormitoryAmenities(
+	ctx context.Context,
+	request *rmodel.SetDormitoryAmenitiesRequest,
+) (*rmodel.SetDormitoryAmenitiesResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  request.DormitoryId,
+			RoleRequired: true,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	if err := request.Normalize(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	resp, err := s.repository.SetDormitoryAmenities(ctx, &dbtypes.SetDormitoryAmenitiesRequest{
+		DormitoryId:  request.DormitoryId,
+		AmenityCodes: request.AmenityCodes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error setting dormitory amenities: %v", s.handleDBError(err), err)
+	}
+
+	res := new(rmodel.SetDormitoryAmenitiesResponse).From(resp)
+
+	go s.invalidateDormitoryCache(ctx, request.DormitoryId)
+	go s.invalidateDormitoryListCache(ctx)
+
+	return res, nil
+}
+
+func (s *CoreService) invalidateAmenityDormitoriesCache(
+	ctx context.Context,
+	dormitoryIds []string,
+) {
+	for _, dormitoryId := range dormitoryIds {
+		s.invalidateDormitoryCache(ctx, dormitoryId)
+	}
+
+	s.invalidateDormitoryListCache(ctx)
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dormitory-life/core/internal/constants"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+func (s *CoreService) GetEventsCalendar(
+	ctx context.Context,
+	request *rmodel.GetEventsCalendarRequest,
+) (*rmodel.GetEventsCalendarResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	dormitory, err := s.repository.GetDormitoryById(ctx, &dbtypes.GetDormitoryByIdRequest{
+		DormitoryId: request.DormitoryId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting dormitory: %v", s.handleDBError(err), err)
+	}
+
+	resp, err := s.repository.GetEventsCalendar(ctx, &dbtypes.GetEventsCalendarRequest{
+		DormitoryId: request.DormitoryId,
+		Since:       time.Now().Add(-constants.CalendarPastWindow),
+		Limit:       constants.MaxCalendarEvents,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting events calendar: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.GetEventsCalendarResponse).From(dormitory.Dormitory.Name, resp), nil
+}
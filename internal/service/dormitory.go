@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -11,6 +12,7 @@ import (
 	dbtypes "github.com/dormitory-life/core/internal/database/types"
 	rmodel "github.com/dormitory-life/core/internal/server/request_models"
 	"github.com/dormitory-life/core/internal/storage"
+	"github.com/google/uuid"
 )
 
 func (s *CoreService) GetDormitories(
@@ -21,18 +23,29 @@ func (s *CoreService) GetDormitories(
 		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
 	}
 
-	if res, err := s.getDormitoriesFromCache(ctx); err == nil {
-		return res, nil
+	cacheKey, cacheErr := s.getDormitoriesCacheKey(ctx, request)
+	if cacheErr == nil {
+		if res, err := s.getDormitoriesFromCache(ctx, cacheKey); err == nil {
+			return res, nil
+		}
 	}
 
 	s.logger.Debug("dormitories cache miss")
 
-	resp, err := s.repository.GetDormitories(ctx, &dbtypes.GetDormitoriesRequest{})
+	resp, err := s.repository.GetDormitories(ctx, &dbtypes.GetDormitoriesRequest{
+		Search:    request.Search,
+		MinRating: request.MinRating,
+		Amenities: request.Amenities,
+		SortBy:    request.SortBy,
+		SortDesc:  request.SortDesc,
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: error getting dormitories: %v", s.handleDBError(err), err)
 	}
 
-	res := new(rmodel.GetDormitoriesResponse).From(resp)
+	res := new(rmodel.GetDormitoriesResponse).From(resp).WithPagination(request.Page, request.PageSize)
 
 	for i, dorm := range res.Dormitories {
 		photos, err := s.s3Client.GetEntityFiles(ctx, &storage.GetEntityFilesRequest{
@@ -49,7 +62,9 @@ func (s *CoreService) GetDormitories(
 		res.Dormitories[i].Photos = dormPhotos
 	}
 
-	s.setDormitoriesToCache(ctx, res)
+	if cacheErr == nil {
+		s.setDormitoriesToCache(ctx, cacheKey, res)
+	}
 
 	return res, nil
 }
@@ -77,6 +92,15 @@ func (s *CoreService) GetDormitoryById(
 
 	res := new(rmodel.GetDormitoryByIdResponse).From(resp)
 
+	amenities, err := s.repository.GetDormitoryAmenities(ctx, &dbtypes.GetDormitoryAmenitiesRequest{
+		DormitoryId: request.DormitoryId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting dormitory amenities: %v", s.handleDBError(err), err)
+	}
+
+	res.Dormitory.Amenities = rmodel.ConvertAmenities(amenities.Amenities)
+
 	photos, err := s.s3Client.GetEntityFiles(ctx, &storage.GetEntityFilesRequest{
 		Category: constants.CategoryDormitoryPhotos,
 		EntityId: res.Dormitory.Id,
@@ -92,6 +116,42 @@ func (s *CoreService) GetDormitoryById(
 	return res, nil
 }
 
+func (s *CoreService) GetNearbyDormitories(
+	ctx context.Context,
+	request *rmodel.GetNearbyDormitoriesRequest,
+) (*rmodel.GetNearbyDormitoriesResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	resp, err := s.repository.GetNearbyDormitories(ctx, &dbtypes.GetNearbyDormitoriesRequest{
+		Latitude:  request.Latitude,
+		Longitude: request.Longitude,
+		RadiusKm:  request.RadiusKm,
+		Limit:     constants.MaxDormitoriesPageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting nearby dormitories: %v", s.handleDBError(err), err)
+	}
+
+	res := new(rmodel.GetNearbyDormitoriesResponse).From(resp)
+
+	for i, dorm := range res.Dormitories {
+		photos, err := s.s3Client.GetEntityFiles(ctx, &storage.GetEntityFilesRequest{
+			Category: constants.CategoryDormitoryPhotos,
+			EntityId: dorm.Id,
+			Amount:   constants.GetDormitoriesDefaultAmount,
+		})
+		if err != nil {
+			s.logger.Warn("error getting dormitory photos", slog.String("error", err.Error()), slog.String("dormId", dorm.Id))
+		}
+
+		res.Dormitories[i].Photos = rmodel.ConvertFileInfos(photos)
+	}
+
+	return res, nil
+}
+
 func (s *CoreService) CreateDormitory(
 	ctx context.Context,
 	request *rmodel.CreateDormitoryRequest,
@@ -116,12 +176,18 @@ func (s *CoreService) CreateDormitory(
 		return nil, err
 	}
 
+	if err := rmodel.ValidateCoordinates(request.Latitude, request.Longitude); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
 	resp, err := s.repository.CreateDormitory(ctx, &dbtypes.CreateDormitoryRequest{
 		DormitoryId:  request.DormitoryId,
 		Name:         request.Name,
 		Address:      request.Address,
 		SupportEmail: request.SupportEmail,
 		Description:  request.Description,
+		Latitude:     request.Latitude,
+		Longitude:    request.Longitude,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: error creating dormitory: %v", s.handleDBError(err), err)
@@ -158,12 +224,18 @@ func (s *CoreService) UpdateDormitory(
 		return nil, err
 	}
 
+	if err := rmodel.ValidateCoordinates(request.Latitude, request.Longitude); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
 	resp, err := s.repository.UpdateDormitory(ctx, &dbtypes.UpdateDormitoryRequest{
 		DormitoryId:  request.DormitoryId,
 		Name:         request.Name,
 		Address:      request.Address,
 		SupportEmail: request.SupportEmail,
 		Description:  request.Description,
+		Latitude:     request.Latitude,
+		Longitude:    request.Longitude,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: error updating dormitory: %v", s.handleDBError(err), err)
@@ -181,16 +253,103 @@ func (s *CoreService) DeleteDormitory(
 	ctx context.Context,
 	request *rmodel.DeleteDormitoryRequest,
 ) (*rmodel.DeleteDormitoryResponse, error) {
-	return nil, ErrUnimplemented
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if request.DormitoryId == "" {
+		return nil, fmt.Errorf("%w: empty dormitory id", ErrBadRequest)
+	}
+
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  request.DormitoryId,
+			RoleRequired: true,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repository.GetDormitoryById(ctx, &dbtypes.GetDormitoryByIdRequest{
+		DormitoryId: request.DormitoryId,
+	}); err != nil {
+		return nil, fmt.Errorf("%w: error getting dormitory: %v", s.handleDBError(err), err)
+	}
+
+	// files are removed before the row: if cleanup fails the dormitory still exists
+	// and the whole request can be retried, deleting an empty prefix is a no-op
+	if err := s.s3Client.DeleteAll(ctx, &storage.DeleteAllRequest{
+		Category: constants.CategoryDormitoryFiles,
+		EntityId: request.DormitoryId,
+	}); err != nil {
+		return nil, fmt.Errorf("%w: error deleting dormitory files: %v", ErrInternal, err)
+	}
+
+	resp, err := s.repository.DeleteDormitory(ctx, &dbtypes.DeleteDormitoryRequest{
+		DormitoryId: request.DormitoryId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error deleting dormitory: %v", s.handleDBError(err), err)
+	}
+
+	if err := errors.Join(
+		s.invalidateDormitoryCache(ctx, request.DormitoryId),
+		s.invalidateDormitoryListCache(ctx),
+	); err != nil {
+		return nil, fmt.Errorf("%w: dormitory deleted, error invalidating cache: %v", ErrInternal, err)
+	}
+
+	res := new(rmodel.DeleteDormitoryResponse).From(resp)
+
+	return res, nil
+}
+
+// getDormitoriesCacheKey - ключ кэша списка общежитий для конкретного запроса.
+// Ключ содержит текущую версию списка, поэтому инвалидация сводится к смене версии,
+// а устаревшие записи истекают по TTL
+func (s *CoreService) getDormitoriesCacheKey(
+	ctx context.Context,
+	request *rmodel.GetDormitoriesRequest,
+) (string, error) {
+	version, err := s.cacheClient.Get(
+		ctx,
+		constants.CacheDormitoriesVersionKey,
+		cache.CategoryDormitoryList,
+	)
+	if errors.Is(err, cache.ErrNotFound) {
+		version = uuid.New().String()
+
+		err = s.cacheClient.Set(
+			ctx,
+			constants.CacheDormitoriesVersionKey,
+			cache.CategoryDormitoryList,
+			version,
+			constants.DefaultDormitoryListVersionTTL,
+		)
+	}
+	if err != nil {
+		s.logger.Warn("error getting dormitory list cache version", slog.String("error", err.Error()))
+		return "", fmt.Errorf("%w: error getting dormitory list cache version: %v", ErrInternal, err)
+	}
+
+	return fmt.Sprintf("%s:%s:%s", constants.CacheDormitoriesKey, version, request.CacheKey()), nil
 }
 
 func (s *CoreService) getDormitoriesFromCache(
 	ctx context.Context,
+	key string,
 ) (*rmodel.GetDormitoriesResponse, error) {
 
 	res, err := s.cacheClient.Get(
 		ctx,
-		constants.CacheDormitoriesKey,
+		key,
 		cache.CategoryDormitoryList,
 	)
 	if err == nil {
@@ -201,7 +360,7 @@ func (s *CoreService) getDormitoriesFromCache(
 		if err := json.Unmarshal([]byte(res), &resp); err != nil {
 			s.logger.Warn("error unmarshalling cache response", slog.String("error", err.Error()))
 
-			if err := s.cacheClient.Delete(ctx, constants.CacheDormitoriesKey, cache.CategoryDormitoryList); err != nil {
+			if err := s.cacheClient.Delete(ctx, key, cache.CategoryDormitoryList); err != nil {
 				s.logger.Warn("error invalidating dormitory list cache", slog.String("error", err.Error()))
 			}
 
@@ -218,6 +377,7 @@ func (s *CoreService) getDormitoriesFromCache(
 
 func (s *CoreService) setDormitoriesToCache(
 	ctx context.Context,
+	key string,
 	resp *rmodel.GetDormitoriesResponse,
 ) error {
 	respBytes, err := json.Marshal(resp)
@@ -229,7 +389,7 @@ func (s *CoreService) setDormitoriesToCache(
 
 	if err := s.cacheClient.Set(
 		ctx,
-		constants.CacheDormitoriesKey,
+		key,
 		cache.CategoryDormitoryList,
 		string(respBytes),
 		constants.DefaultDormitoryListTTL,
@@ -333,10 +493,13 @@ func (s *CoreService) invalidateDormitoryListCache(ctx context.Context) error {
 
 	s.logger.Debug("invalidating dormitory list cache")
 
-	if err := s.cacheClient.Delete(
+	// every cached page is keyed by the list version, a new version makes them unreachable
+	if err := s.cacheClient.Set(
 		ctxBg,
-		constants.CacheDormitoriesKey,
-		cache.CategoryDormitoryList); err != nil {
+		constants.CacheDormitoriesVersionKey,
+		cache.CategoryDormitoryList,
+		uuid.New().String(),
+		constants.DefaultDormitoryListVersionTTL); err != nil {
 		s.logger.Warn("error invalidating dormitory list cache", slog.String("error", err.Error()))
 		return fmt.Errorf("%w: error invalidating dormitory list cache: %v", ErrInternal, err)
 	}
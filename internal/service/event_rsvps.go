@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+func (s *CoreService) RsvpEvent(
+	ctx context.Context,
+	request *rmodel.RsvpEventRequest,
+) (*rmodel.RsvpEventResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	return s.rsvpEvent(ctx, request.DormitoryId, request.EventId, &request.Status)
+}
+
+func (s *CoreService) DeleteEventRsvp(
+	ctx context.Context,
+	request *rmodel.DeleteEventRsvpRequest,
+) (*rmodel.RsvpEventResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	return s.rsvpEvent(ctx, request.DormitoryId, request.EventId, nil)
+}
+
+// rsvpEvent - отвечать на события могут жители общежития
+func (s *CoreService) rsvpEvent(
+	ctx context.Context,
+	dormitoryId string,
+	eventId string,
+	status *string,
+) (*rmodel.RsvpEventResponse, error) {
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  dormitoryId,
+			RoleRequired: false,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.RsvpEvent(ctx, &dbtypes.RsvpEventRequest{
+		DormitoryId: dormitoryId,
+		EventId:     eventId,
+		UserId:      userId,
+		Status:      status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error saving event rsvp: %v", s.handleDBError(err), err)
+	}
+
+	if len(resp.PromotedUserIds) != 0 {
+		s.logger.Info("event waitlist promoted",
+			slog.String("eventId", eventId),
+			slog.String("userIds", strings.Join(resp.PromotedUserIds, ",")))
+	}
+
+	return new(rmodel.RsvpEventResponse).From(resp), nil
+}
+
+func (s *CoreService) GetEventAttendees(
+	ctx context.Context,
+	request *rmodel.GetEventAttendeesRequest,
+) (*rmodel.GetEventAttendeesResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.GetEventAttendees(ctx, &dbtypes.GetEventAttendeesRequest{
+		DormitoryId: request.DormitoryId,
+		EventId:     request.EventId,
+		Status:      request.Status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting event attendees: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.GetEventAttendeesResponse).From(request.EventId, resp), nil
+}
@@ -22,8 +22,13 @@ func (s *CoreService) GetDormitoryEvents(
 	}
 
 	resp, err := s.repository.GetDormitoryEvents(ctx, &dbtypes.GetDormitoryEventsRequest{
-		DormitoryId: request.DormitoryId,
-		Page:        request.Page,
+		DormitoryId:    request.DormitoryId,
+		Page:           request.Page,
+		ViewerId:       s.extractViewerIdFromRequestContext(ctx),
+		Period:         request.Period,
+		From:           request.From,
+		To:             request.To,
+		IncludeExpired: request.IncludeExpired,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: error getting events: %v", s.handleDBError(err), err)
@@ -57,6 +62,10 @@ func (s *CoreService) CreateDormitoryEvent(
 		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
 	}
 
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
 	userId, _, err := s.extractIdsFromRequestContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
@@ -77,6 +86,16 @@ func (s *CoreService) CreateDormitoryEvent(
 		DormitoryId: request.DormitoryId,
 		Title:       request.Title,
 		Description: request.Description,
+		StartsAt:    request.StartsAt,
+		EndsAt:      request.EndsAt,
+		RoomId:      request.RoomId,
+		Location:    request.Location,
+		Category:    request.Category,
+		Capacity:    request.Capacity,
+		Recurrence:  request.Recurrence.ToDB(),
+		Pinned:      request.Pinned,
+		ExpiresAt:   request.ExpiresAt,
+		Urgent:      request.Urgent,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: error creating event: %v", s.handleDBError(err), err)
@@ -130,15 +149,124 @@ func (s *CoreService) CreateDormitoryEvent(
 		})
 	}
 
+	if request.Urgent {
+		go s.notifyUrgentEvent(context.WithoutCancel(ctx), request.DormitoryId, request.Title, request.Description)
+	}
+
 	return &rmodel.CreateDormitoryEventResponse{
 		EventId:              createResp.EventId,
 		DormitoryId:          request.DormitoryId,
 		CreatePhotoResponses: uploadedPhotos,
 		Title:                request.Title,
 		Description:          request.Description,
+		StartsAt:             request.StartsAt,
+		EndsAt:               request.EndsAt,
+		RoomId:               request.RoomId,
+		Location:             request.Location,
+		Category:             request.Category,
+		Capacity:             request.Capacity,
+		Recurrence:           request.Recurrence,
+		Pinned:               request.Pinned,
+		ExpiresAt:            request.ExpiresAt,
+		Urgent:               request.Urgent,
 	}, nil
 }
 
+func (s *CoreService) UpdateDormitoryEvent(
+	ctx context.Context,
+	request *rmodel.UpdateDormitoryEventRequest,
+) (*rmodel.UpdateDormitoryEventResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  request.DormitoryId,
+			RoleRequired: true,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	removePaths, err := s.entityPhotoPaths(
+		ctx,
+		constants.CategoryEventPhotos,
+		request.DormitoryId,
+		request.EventId,
+		fmt.Sprintf(constants.PathFeedPhotos, request.DormitoryId, request.EventId),
+		request.RemovePhotos,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var addedPaths []string
+
+	for _, photoFileHeader := range request.PhotoFilesHeaders {
+		uploadResult, err := s.uploadEntityPhoto(ctx, constants.CategoryEventPhotos, request.DormitoryId, request.EventId, photoFileHeader)
+		if err != nil {
+			s.deletePhotos(ctx, addedPaths)
+
+			return nil, fmt.Errorf("%w: upload failed: %v", ErrInternal, err)
+		}
+
+		addedPaths = append(addedPaths, uploadResult.FilePath)
+	}
+
+	updateResp, err := s.repository.UpdateDormitoryEvent(ctx, &dbtypes.UpdateDormitoryEventRequest{
+		DormitoryId:   request.DormitoryId,
+		EventId:       request.EventId,
+		Title:         request.Title,
+		Description:   request.Description,
+		StartsAt:      request.StartsAt,
+		EndsAt:        request.EndsAt,
+		ClearEndsAt:   request.ClearEndsAt,
+		RoomId:        request.RoomId,
+		ClearRoomId:   request.ClearRoomId,
+		Location:      request.Location,
+		Category:      request.Category,
+		Capacity:      request.Capacity,
+		ClearCapacity: request.ClearCapacity,
+	})
+	if err != nil {
+		s.deletePhotos(ctx, addedPaths)
+
+		return nil, fmt.Errorf("%w: error updating event: %v", s.handleDBError(err), err)
+	}
+
+	// событие уже сохранено, поэтому неудачное удаление фото только логируем
+	s.deletePhotos(ctx, removePaths)
+
+	res := new(rmodel.UpdateDormitoryEventResponse).From(updateResp)
+
+	eventPhotos, err := s.s3Client.GetEntityFiles(ctx, &storage.GetEntityFilesRequest{
+		Category:    constants.CategoryEventPhotos,
+		EntityId:    request.DormitoryId,
+		SubEntityId: request.EventId,
+	})
+	if err != nil {
+		s.logger.Warn("error getting dormitory event photos",
+			slog.String("error", err.Error()),
+			slog.String("dormId", request.DormitoryId),
+			slog.String("eventId", request.EventId))
+	}
+	res.EventPhotos = rmodel.ConvertFileInfos(eventPhotos)
+
+	return res, nil
+}
+
 func (s *CoreService) DeleteDormitoryEvent(
 	ctx context.Context,
 	request *rmodel.DeleteDormitoryEventRequest,
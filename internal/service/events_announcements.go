@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dormitory-life/core/internal/announcements"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+func (s *CoreService) UpdateEventAnnouncement(
+	ctx context.Context,
+	request *rmodel.UpdateEventAnnouncementRequest,
+) (*rmodel.UpdateEventAnnouncementResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  request.DormitoryId,
+			RoleRequired: true,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.UpdateEventAnnouncement(ctx, &dbtypes.UpdateEventAnnouncementRequest{
+		DormitoryId:    request.DormitoryId,
+		EventId:        request.EventId,
+		Pinned:         request.Pinned,
+		ExpiresAt:      request.ExpiresAt,
+		ClearExpiresAt: request.NoExpiry,
+		Urgent:         request.Urgent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error updating event announcement: %v", s.handleDBError(err), err)
+	}
+
+	if resp.BecameUrgent {
+		go s.notifyUrgentEvent(context.WithoutCancel(ctx), request.DormitoryId, resp.Title, resp.Description)
+	}
+
+	return new(rmodel.UpdateEventAnnouncementResponse).From(resp), nil
+}
+
+// notifyUrgentEvent ставит в очередь одно сообщение о срочном посте со всеми жильцами общежития,
+// письма по адресам рассылает consumer. Ошибки только логируем: пост уже сохранен
+func (s *CoreService) notifyUrgentEvent(ctx context.Context, dormitoryId, title, description string) {
+	if s.announcementClient == nil {
+		return
+	}
+
+	dormitory, err := s.repository.GetDormitoryById(ctx, &dbtypes.GetDormitoryByIdRequest{
+		DormitoryId: dormitoryId,
+	})
+	if err != nil {
+		s.logger.Warn("error getting dormitory for urgent announcement",
+			slog.String("error", err.Error()),
+			slog.String("dormId", dormitoryId))
+
+		return
+	}
+
+	residents, err := s.repository.GetDormitoryResidentsEmails(ctx, &dbtypes.GetDormitoryResidentsEmailsRequest{
+		DormitoryId: dormitoryId,
+	})
+	if err != nil {
+		s.logger.Warn("error getting dormitory residents emails",
+			slog.String("error", err.Error()),
+			slog.String("dormId", dormitoryId))
+
+		return
+	}
+
+	if len(residents.Emails) == 0 {
+		return
+	}
+
+	if err := s.announcementClient.PublishAnnouncementMessage(ctx, &announcements.AnnouncementMessage{
+		UserEmails:    residents.Emails,
+		DormitoryName: dormitory.Dormitory.Name,
+		Title:         title,
+		Description:   description,
+	}); err != nil {
+		s.logger.Warn("error publishing urgent announcement",
+			slog.String("error", err.Error()),
+			slog.String("dormId", dormitoryId))
+	}
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+func (s *CoreService) UpdateEventOccurrence(
+	ctx context.Context,
+	request *rmodel.UpdateEventOccurrenceRequest,
+) (*rmodel.UpdateEventOccurrenceResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	return s.updateEventOccurrence(ctx, &dbtypes.UpdateEventOccurrenceRequest{
+		DormitoryId:     request.DormitoryId,
+		EventId:         request.EventId,
+		OccurrenceStart: request.OccurrenceStart,
+		Title:           request.Title,
+		Description:     request.Description,
+		StartsAt:        request.StartsAt,
+		EndsAt:          request.EndsAt,
+		Location:        request.Location,
+		Cancelled:       request.Cancelled,
+	})
+}
+
+func (s *CoreService) CancelEventOccurrence(
+	ctx context.Context,
+	request *rmodel.CancelEventOccurrenceRequest,
+) (*rmodel.UpdateEventOccurrenceResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	cancelled := true
+
+	return s.updateEventOccurrence(ctx, &dbtypes.UpdateEventOccurrenceRequest{
+		DormitoryId:     request.DormitoryId,
+		EventId:         request.EventId,
+		OccurrenceStart: request.OccurrenceStart,
+		Cancelled:       &cancelled,
+	})
+}
+
+// updateEventOccurrence - повторения, как и сами события, меняет администрация общежития
+func (s *CoreService) updateEventOccurrence(
+	ctx context.Context,
+	request *dbtypes.UpdateEventOccurrenceRequest,
+) (*rmodel.UpdateEventOccurrenceResponse, error) {
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  request.DormitoryId,
+			RoleRequired: true,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.UpdateEventOccurrence(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error updating event occurrence: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.UpdateEventOccurrenceResponse).From(resp), nil
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+func (s *CoreService) GetGradeCriteria(
+	ctx context.Context,
+	request *rmodel.GetGradeCriteriaRequest,
+) (*rmodel.GetGradeCriteriaResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	resp, err := s.repository.GetGradeCriteria(ctx, &dbtypes.GetGradeCriteriaRequest{
+		ActiveOnly: !request.All,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting grade criteria: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.GetGradeCriteriaResponse).From(request.Lang, resp), nil
+}
+
+func (s *CoreService) CreateGradeCriterion(
+	ctx context.Context,
+	request *rmodel.CreateGradeCriterionRequest,
+) (*rmodel.CreateGradeCriterionResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkCatalogAdminAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	weight := 1.0
+	if request.Weight != nil {
+		weight = *request.Weight
+	}
+
+	resp, err := s.repository.CreateGradeCriterion(ctx, &dbtypes.CreateGradeCriterionRequest{
+		Code:     request.Code,
+		Labels:   request.Labels,
+		Weight:   weight,
+		Position: request.Position,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error creating grade criterion: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.CreateGradeCriterionResponse).From(resp), nil
+}
+
+// UpdateGradeCriterion меняет метки, вес, порядок или активность критерия.
+// Новый вес применяется к средним при следующем пересчете периода
+func (s *CoreService) UpdateGradeCriterion(
+	ctx context.Context,
+	request *rmodel.UpdateGradeCriterionRequest,
+) (*rmodel.UpdateGradeCriterionResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkCatalogAdminAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	resp, err := s.repository.UpdateGradeCriterion(ctx, &dbtypes.UpdateGradeCriterionRequest{
+		Code:     request.Code,
+		Labels:   request.Labels,
+		Weight:   request.Weight,
+		Position: request.Position,
+		Active:   request.Active,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error updating grade criterion: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.UpdateGradeCriterionResponse).From(resp), nil
+}
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dormitory-life/core/internal/constants"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+// Причины, по которым оценка уходит на проверку
+const (
+	GradeFlagZeroVariance = "zero_variance"
+	GradeFlagNewAccount   = "new_account"
+	GradeFlagSpike        = "activity_spike"
+)
+
+func (s *CoreService) GetModerationGrades(
+	ctx context.Context,
+	request *rmodel.GetModerationGradesRequest,
+) (*rmodel.GetModerationGradesResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.GetModerationGrades(ctx, &dbtypes.GetModerationGradesRequest{
+		DormitoryId: request.DormitoryId,
+		Status:      request.Status,
+		Page:        request.Page,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting moderation grades: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.GetModerationGradesResponse).From(request.Page, resp), nil
+}
+
+// ModerateGrade одобряет оценку, возвращая ее в средние, или отклоняет ее
+func (s *CoreService) ModerateGrade(
+	ctx context.Context,
+	request *rmodel.ModerateGradeRequest,
+) (*rmodel.ModerateGradeResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.ModerateGrade(ctx, &dbtypes.ModerateGradeRequest{
+		DormitoryId: request.DormitoryId,
+		GradeId:     request.GradeId,
+		Status:      request.Status,
+		ReviewerId:  userId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error moderating grade: %v", s.handleDBError(err), err)
+	}
+
+	go s.invalidateDormitoryListCache(ctx)
+	go s.invalidateGradesDistributionCache(ctx, request.DormitoryId, resp.CreatedAt)
+
+	return new(rmodel.ModerateGradeResponse).From(resp), nil
+}
+
+// detectGradeAnomalies возвращает причины, по которым оценка выглядит накруткой:
+// одинаковые баллы по всем критериям, новый аккаунт или всплеск оценок общежития
+func (s *CoreService) detectGradeAnomalies(
+	ctx context.Context,
+	dormitoryId string,
+	userId string,
+	scores map[string]int,
+) ([]string, error) {
+	var reasons []string
+
+	if isZeroVariance(scores) {
+		reasons = append(reasons, GradeFlagZeroVariance)
+	}
+
+	now := time.Now()
+	activitySince := now.Add(-constants.GradeActivityWindow)
+
+	signals, err := s.repository.GetGradeSignals(ctx, &dbtypes.GetGradeSignalsRequest{
+		DormitoryId:   dormitoryId,
+		UserId:        userId,
+		ActivitySince: activitySince,
+		BaselineSince: activitySince.Add(-constants.GradeBaselineWindow),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting grade signals: %v", s.handleDBError(err), err)
+	}
+
+	if now.Sub(signals.AccountCreatedAt) < constants.GradeNewAccountAge {
+		reasons = append(reasons, GradeFlagNewAccount)
+	}
+
+	if isActivitySpike(signals.RecentGrades+1, signals.BaselineGrades) {
+		reasons = append(reasons, GradeFlagSpike)
+	}
+
+	return reasons, nil
+}
+
+func isZeroVariance(scores map[string]int) bool {
+	if len(scores) < constants.GradeZeroVarianceMinScores {
+		return false
+	}
+
+	first := -1
+	for _, score := range scores {
+		if first == -1 {
+			first = score
+		}
+
+		if score != first {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isActivitySpike сравнивает число оценок в окне с ожидаемым по базовому периоду
+func isActivitySpike(recentGrades, baselineGrades int) bool {
+	if recentGrades < constants.GradeSpikeMinGrades {
+		return false
+	}
+
+	expected := float64(baselineGrades) * float64(constants.GradeActivityWindow) / float64(constants.GradeBaselineWindow)
+
+	return float64(recentGrades) > expected*constants.GradeSpikeFactor
+}
@@ -2,8 +2,16 @@ package core
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
 
+	"github.com/dormitory-life/core/internal/cache"
+	"github.com/dormitory-life/core/internal/constants"
 	dbtypes "github.com/dormitory-life/core/internal/database/types"
 	rmodel "github.com/dormitory-life/core/internal/server/request_models"
 )
@@ -36,6 +44,8 @@ func (s *CoreService) GetDormitoryAvgGrades(
 
 	resp, err := s.repository.GetDormitoryAvgGrades(ctx, &dbtypes.GetDormitoryAvgGradesRequest{
 		DormitoryId: request.DormitoryId,
+		From:        request.From,
+		To:          request.To,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: error getting dormitories avg grades: %v", ErrInternal, err)
@@ -46,6 +56,38 @@ func (s *CoreService) GetDormitoryAvgGrades(
 	return res, nil
 }
 
+func (s *CoreService) GetDormitoryGradesHistory(
+	ctx context.Context,
+	request *rmodel.GetDormitoryGradesHistoryRequest,
+) (*rmodel.GetDormitoryGradesHistoryResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	from := request.From
+	if from != nil {
+		// a semester or a year may start before the requested month
+		periodStart := gradesPeriodStart(*from, request.Granularity)
+		from = &periodStart
+	}
+
+	resp, err := s.repository.GetDormitoryAvgGrades(ctx, &dbtypes.GetDormitoryAvgGradesRequest{
+		DormitoryId: request.DormitoryId,
+		From:        from,
+		To:          request.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting dormitory avg grades: %v", s.handleDBError(err), err)
+	}
+
+	criteria, err := s.gradeCriteriaCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregateGradesHistory(request.DormitoryId, request.Granularity, criteria, resp.Grades), nil
+}
+
 func (s *CoreService) CreateDormitoryGrade(
 	ctx context.Context,
 	request *rmodel.CreateDormitoryGradeRequest,
@@ -82,24 +124,21 @@ func (s *CoreService) CreateDormitoryGrade(
 		return nil, err
 	}
 
+	scores, err := s.validateGradeScores(ctx, request.AllScores())
+	if err != nil {
+		return nil, err
+	}
+
+	flagReasons, err := s.detectGradeAnomalies(ctx, dormitoryId, userId, scores)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := s.repository.CreateDormitoryGrade(ctx, &dbtypes.CreateDormitoryGradeRequest{
-		DormitoryId:               dormitoryId,
-		UserId:                    userId,
-		BathroomCleanliness:       request.BathroomCleanliness,
-		CorridorCleanliness:       request.CorridorCleanliness,
-		KitchenCleanliness:        request.KitchenCleanliness,
-		CleaningFrequency:         request.CleaningFrequency,
-		RoomSpaciousness:          request.RoomSpaciousness,
-		CorridorSpaciousness:      request.CorridorSpaciousness,
-		KitchenSpaciousness:       request.KitchenSpaciousness,
-		ShowerLocationConvenience: request.ShowerLocationConvenience,
-		EquipmentMaintenance:      request.EquipmentMaintenance,
-		WindowCondition:           request.WindowCondition,
-		NoiseIsolation:            request.NoiseIsolation,
-		CommonAreasEquipment:      request.CommonAreasEquipment,
-		TransportAccessibility:    request.TransportAccessibility,
-		AdministrationQuality:     request.AdministrationQuality,
-		ResidentsCultureLevel:     request.ResidentsCultureLevel,
+		DormitoryId: dormitoryId,
+		UserId:      userId,
+		Scores:      scores,
+		FlagReasons: flagReasons,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: error creating grade: %v", s.handleDBError(err), err)
@@ -107,5 +146,394 @@ func (s *CoreService) CreateDormitoryGrade(
 
 	res := new(rmodel.CreateDormitoryGradeResponse).From(resp)
 
+	// the list shows the latest overall average
+	go s.invalidateDormitoryListCache(ctx)
+	go s.invalidateGradesDistributionCache(ctx, dormitoryId, time.Now())
+
+	return res, nil
+}
+
+func (s *CoreService) UpdateDormitoryGrade(
+	ctx context.Context,
+	request *rmodel.UpdateDormitoryGradeRequest,
+) (*rmodel.UpdateDormitoryGradeResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  request.DormitoryId,
+			RoleRequired: false,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	scores, err := s.validateGradeScores(ctx, request.AllScores())
+	if err != nil {
+		return nil, err
+	}
+
+	flagReasons, err := s.detectGradeAnomalies(ctx, request.DormitoryId, userId, scores)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.UpdateDormitoryGrade(ctx, &dbtypes.UpdateDormitoryGradeRequest{
+		DormitoryId: request.DormitoryId,
+		UserId:      userId,
+		Scores:      scores,
+		FlagReasons: flagReasons,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error updating grade: %v", s.handleDBError(err), err)
+	}
+
+	res := new(rmodel.UpdateDormitoryGradeResponse).From(resp)
+
+	go s.invalidateDormitoryListCache(ctx)
+	go s.invalidateGradesDistributionCache(ctx, request.DormitoryId, time.Now())
+
+	return res, nil
+}
+
+func (s *CoreService) DeleteDormitoryGrade(
+	ctx context.Context,
+	request *rmodel.DeleteDormitoryGradeRequest,
+) (*rmodel.DeleteDormitoryGradeResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  request.DormitoryId,
+			RoleRequired: false,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.DeleteDormitoryGrade(ctx, &dbtypes.DeleteDormitoryGradeRequest{
+		DormitoryId: request.DormitoryId,
+		UserId:      userId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error deleting grade: %v", s.handleDBError(err), err)
+	}
+
+	res := new(rmodel.DeleteDormitoryGradeResponse).From(resp)
+
+	go s.invalidateDormitoryListCache(ctx)
+	go s.invalidateGradesDistributionCache(ctx, request.DormitoryId, time.Now())
+
+	return res, nil
+}
+
+func (s *CoreService) GetGradesDistribution(
+	ctx context.Context,
+	request *rmodel.GetGradesDistributionRequest,
+) (*rmodel.GetGradesDistributionResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	if res, err := s.getGradesDistributionFromCache(ctx, request.CacheKey()); err == nil {
+		return res, nil
+	}
+
+	s.logger.Debug("grades distribution cache miss", slog.String("key", request.CacheKey()))
+
+	resp, err := s.repository.GetGradesDistribution(ctx, &dbtypes.GetGradesDistributionRequest{
+		DormitoryId: request.DormitoryId,
+		Period:      request.Period,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting grades distribution: %v", s.handleDBError(err), err)
+	}
+
+	criteria, err := s.gradeCriteriaCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(rmodel.GetGradesDistributionResponse).From(request, criteria, resp)
+
+	s.setGradesDistributionToCache(ctx, request.CacheKey(), res)
+
 	return res, nil
 }
+
+func (s *CoreService) getGradesDistributionFromCache(
+	ctx context.Context,
+	key string,
+) (*rmodel.GetGradesDistributionResponse, error) {
+	res, err := s.cacheClient.Get(
+		ctx,
+		key,
+		cache.CategoryGradesDistribution,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting grades distribution from cache: %v", ErrInternal, err)
+	}
+
+	s.logger.Debug("grades distribution cache hit")
+
+	var resp rmodel.GetGradesDistributionResponse
+
+	if err := json.Unmarshal([]byte(res), &resp); err != nil {
+		s.logger.Warn("error unmarshalling cache response", slog.String("error", err.Error()))
+
+		if err := s.cacheClient.Delete(ctx, key, cache.CategoryGradesDistribution); err != nil {
+			s.logger.Warn("error invalidating grades distribution cache", slog.String("error", err.Error()))
+		}
+
+		return nil, fmt.Errorf("%w: error unmarshalling cache response: %v", ErrInternal, err)
+	}
+
+	return &resp, nil
+}
+
+func (s *CoreService) setGradesDistributionToCache(
+	ctx context.Context,
+	key string,
+	resp *rmodel.GetGradesDistributionResponse,
+) error {
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("%w: error marshalling response: %v", ErrInternal, err)
+	}
+
+	if err := s.cacheClient.Set(
+		ctx,
+		key,
+		cache.CategoryGradesDistribution,
+		string(respBytes),
+		constants.DefaultGradesDistributionTTL,
+	); err != nil {
+		s.logger.Warn("error setting grades distribution to cache", slog.String("error", err.Error()))
+
+		return fmt.Errorf("%w: error setting cache: %v", ErrInternal, err)
+	}
+
+	return nil
+}
+
+// invalidateGradesDistributionCache сбрасывает распределения, которые могла изменить
+// оценка, поставленная в момент at: за ее месяц и за все время
+func (s *CoreService) invalidateGradesDistributionCache(
+	ctx context.Context,
+	dormitoryId string,
+	at time.Time,
+) error {
+	ctxBg, cancel := context.WithTimeout(context.Background(), constants.DefaultCtxDuration)
+
+	defer cancel()
+
+	period := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var errs []error
+	for _, key := range []string{
+		rmodel.GradesDistributionCacheKey(dormitoryId, &period),
+		rmodel.GradesDistributionCacheKey(dormitoryId, nil),
+	} {
+		if err := s.cacheClient.Delete(ctxBg, key, cache.CategoryGradesDistribution); err != nil {
+			s.logger.Warn("error invalidating grades distribution cache", slog.String("key", key), slog.String("error", err.Error()))
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%w: error invalidating grades distribution cache: %v", ErrInternal, err)
+	}
+
+	return nil
+}
+
+// validateGradeScores проверяет оценки по активным критериям реестра.
+// Критерии можно пропускать, но хотя бы одна оценка обязательна
+func (s *CoreService) validateGradeScores(
+	ctx context.Context,
+	scores map[string]int,
+) (map[string]int, error) {
+	if len(scores) == 0 {
+		return nil, fmt.Errorf("%w: at least one score is required", ErrBadRequest)
+	}
+
+	resp, err := s.repository.GetGradeCriteria(ctx, &dbtypes.GetGradeCriteriaRequest{
+		ActiveOnly: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting grade criteria: %v", s.handleDBError(err), err)
+	}
+
+	active := make(map[string]struct{}, len(resp.Criteria))
+	for _, criterion := range resp.Criteria {
+		active[criterion.Code] = struct{}{}
+	}
+
+	for code, score := range scores {
+		if _, ok := active[code]; !ok {
+			return nil, fmt.Errorf("%w: unknown grade criterion: %s", ErrBadRequest, code)
+		}
+
+		if score < 1 || score > 5 {
+			return nil, fmt.Errorf("%w: score for %s must be between 1 and 5", ErrBadRequest, code)
+		}
+	}
+
+	return scores, nil
+}
+
+// gradeCriteriaCodes возвращает коды всех критериев реестра, включая отключенные,
+// чтобы по ним оставалась видна история
+func (s *CoreService) gradeCriteriaCodes(ctx context.Context) ([]string, error) {
+	resp, err := s.repository.GetGradeCriteria(ctx, &dbtypes.GetGradeCriteriaRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting grade criteria: %v", s.handleDBError(err), err)
+	}
+
+	codes := make([]string, 0, len(resp.Criteria))
+	for _, criterion := range resp.Criteria {
+		codes = append(codes, criterion.Code)
+	}
+
+	return codes, nil
+}
+
+// gradesPeriodStart возвращает начало периода, в который попадает месяц.
+// Осенний семестр длится с сентября по январь, весенний - с февраля по август
+func gradesPeriodStart(t time.Time, granularity rmodel.GradesGranularity) time.Time {
+	switch granularity {
+	case rmodel.GradesGranularityYear:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	case rmodel.GradesGranularitySemester:
+		switch {
+		case t.Month() >= time.September:
+			return time.Date(t.Year(), time.September, 1, 0, 0, 0, 0, time.UTC)
+		case t.Month() == time.January:
+			return time.Date(t.Year()-1, time.September, 1, 0, 0, 0, 0, time.UTC)
+		default:
+			return time.Date(t.Year(), time.February, 1, 0, 0, 0, 0, time.UTC)
+		}
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// aggregateGradesHistory сводит помесячные средние в периоды заданной длины.
+// Среднее периода взвешивается количеством оценок каждого месяца,
+// а не считается как среднее средних. Критерий может быть пропущен в оценке,
+// поэтому его среднее взвешивается собственным числом оценок
+func aggregateGradesHistory(
+	dormitoryId string,
+	granularity rmodel.GradesGranularity,
+	criteria []string,
+	grades []dbtypes.AvgGrade,
+) *rmodel.GetDormitoryGradesHistoryResponse {
+	type bucket struct {
+		totalRatings    int
+		overallSum      float64
+		criteriaSums    []float64
+		criteriaRatings []int
+	}
+
+	criterionIdx := make(map[string]int, len(criteria))
+	for i, criterion := range criteria {
+		criterionIdx[criterion] = i
+	}
+
+	buckets := make(map[time.Time]*bucket)
+	for i := range grades {
+		grade := &grades[i]
+
+		period := gradesPeriodStart(grade.PeriodDate, granularity)
+
+		b, ok := buckets[period]
+		if !ok {
+			b = &bucket{
+				criteriaSums:    make([]float64, len(criteria)),
+				criteriaRatings: make([]int, len(criteria)),
+			}
+			buckets[period] = b
+		}
+
+		weight := float64(grade.TotalRatings)
+
+		b.totalRatings += grade.TotalRatings
+		b.overallSum += grade.OverallAverage * weight
+
+		for _, criterion := range grade.Criteria {
+			j, ok := criterionIdx[criterion.Code]
+			if !ok {
+				continue
+			}
+
+			b.criteriaSums[j] += criterion.Average * float64(criterion.TotalRatings)
+			b.criteriaRatings[j] += criterion.TotalRatings
+		}
+	}
+
+	periods := make([]time.Time, 0, len(buckets))
+	for period := range buckets {
+		periods = append(periods, period)
+	}
+
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Before(periods[j]) })
+
+	res := &rmodel.GetDormitoryGradesHistoryResponse{
+		DormitoryId:    dormitoryId,
+		Granularity:    granularity,
+		Periods:        periods,
+		TotalRatings:   make([]int, 0, len(periods)),
+		OverallAverage: make([]float64, 0, len(periods)),
+		Series:         make([]rmodel.GradesSeries, 0, len(criteria)),
+	}
+
+	for _, criterion := range criteria {
+		res.Series = append(res.Series, rmodel.GradesSeries{
+			Criterion: criterion,
+			Values:    make([]float64, 0, len(periods)),
+		})
+	}
+
+	for _, period := range periods {
+		b := buckets[period]
+
+		res.TotalRatings = append(res.TotalRatings, b.totalRatings)
+		res.OverallAverage = append(res.OverallAverage, weightedAverage(b.overallSum, b.totalRatings))
+
+		for j := range criteria {
+			res.Series[j].Values = append(res.Series[j].Values, weightedAverage(b.criteriaSums[j], b.criteriaRatings[j]))
+		}
+	}
+
+	return res
+}
+
+func weightedAverage(sum float64, weight int) float64 {
+	if weight == 0 {
+		return 0
+	}
+
+	return math.Round(sum/float64(weight)*100) / 100
+}
@@ -0,0 +1,239 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dormitory-life/core/internal/constants"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+// GetGradesLeaderboard строит рейтинг общежитий за период по взвешенной оценке.
+// Оценка сглаживается к общему среднему (байесовское среднее), поэтому общежитие
+// с парой оценок не обгоняет общежитие с сотнями оценок
+func (s *CoreService) GetGradesLeaderboard(
+	ctx context.Context,
+	request *rmodel.GetGradesLeaderboardRequest,
+) (*rmodel.GetGradesLeaderboardResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	weights, err := s.leaderboardWeights(ctx, request.Weights)
+	if err != nil {
+		return nil, err
+	}
+
+	minRatings := s.ratings.MinRatings
+	if minRatings <= 0 {
+		minRatings = constants.DefaultLeaderboardMinRatings
+	}
+
+	if request.MinRatings != nil {
+		minRatings = *request.MinRatings
+	}
+
+	period := time.Now().UTC()
+	if request.Period != nil {
+		period = *request.Period
+	}
+
+	var (
+		periodStart     = gradesPeriodStart(period, request.Granularity)
+		periodEnd       = gradesNextPeriodStart(periodStart, request.Granularity)
+		prevPeriodStart = gradesPeriodStart(periodStart.AddDate(0, 0, -1), request.Granularity)
+	)
+
+	current, err := s.repository.GetDormitoriesPeriodScores(ctx, &dbtypes.GetDormitoriesPeriodScoresRequest{
+		From: periodStart,
+		To:   periodEnd,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting period scores: %v", s.handleDBError(err), err)
+	}
+
+	previous, err := s.repository.GetDormitoriesPeriodScores(ctx, &dbtypes.GetDormitoriesPeriodScoresRequest{
+		From: prevPeriodStart,
+		To:   periodStart,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting previous period scores: %v", s.handleDBError(err), err)
+	}
+
+	entries, globalAverage := rankDormitories(current.Dormitories, weights, minRatings)
+	prevEntries, _ := rankDormitories(previous.Dormitories, weights, minRatings)
+
+	prevRanks := make(map[string]int, len(prevEntries))
+	for _, entry := range prevEntries {
+		prevRanks[entry.DormitoryId] = entry.Rank
+	}
+
+	for i := range entries {
+		prevRank, ok := prevRanks[entries[i].DormitoryId]
+		if !ok {
+			continue
+		}
+
+		rankChange := prevRank - entries[i].Rank
+
+		entries[i].PreviousRank = &prevRank
+		entries[i].RankChange = &rankChange
+	}
+
+	return &rmodel.GetGradesLeaderboardResponse{
+		Granularity:    request.Granularity,
+		Period:         periodStart,
+		PreviousPeriod: prevPeriodStart,
+		Weights:        weights,
+		MinRatings:     minRatings,
+		GlobalAverage:  globalAverage,
+		Entries:        entries,
+	}, nil
+}
+
+// leaderboardWeights выбирает веса критериев: из запроса, иначе из конфига,
+// иначе из реестра критериев
+func (s *CoreService) leaderboardWeights(
+	ctx context.Context,
+	requested map[string]float64,
+) (map[string]float64, error) {
+	resp, err := s.repository.GetGradeCriteria(ctx, &dbtypes.GetGradeCriteriaRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting grade criteria: %v", s.handleDBError(err), err)
+	}
+
+	weights := requested
+	if len(weights) == 0 {
+		weights = s.ratings.Weights
+	}
+
+	if len(weights) == 0 {
+		weights = make(map[string]float64, len(resp.Criteria))
+		for _, criterion := range resp.Criteria {
+			if criterion.Active {
+				weights[criterion.Code] = criterion.Weight
+			}
+		}
+	}
+
+	known := make(map[string]struct{}, len(resp.Criteria))
+	for _, criterion := range resp.Criteria {
+		known[criterion.Code] = struct{}{}
+	}
+
+	var weightSum float64
+	for code, weight := range weights {
+		if _, ok := known[code]; !ok {
+			return nil, fmt.Errorf("%w: unknown grade criterion: %s", ErrBadRequest, code)
+		}
+
+		weightSum += weight
+	}
+
+	if weightSum <= 0 {
+		return nil, fmt.Errorf("%w: at least one criterion weight must be positive", ErrBadRequest)
+	}
+
+	return weights, nil
+}
+
+// rankDormitories считает взвешенное среднее каждого общежития и сглаживает его:
+// score = (v*R + m*C) / (v + m), где v - число оценок общежития, R - его среднее,
+// m - minRatings, C - среднее по всем общежитиям, взвешенное числом оценок
+func rankDormitories(
+	dormitories []dbtypes.DormitoryPeriodScores,
+	weights map[string]float64,
+	minRatings int,
+) ([]rmodel.LeaderboardEntry, float64) {
+	entries := make([]rmodel.LeaderboardEntry, 0, len(dormitories))
+
+	var (
+		globalSum     float64
+		globalRatings int
+	)
+
+	for _, dormitory := range dormitories {
+		var weightedSum, weightSum float64
+		for _, criterion := range dormitory.Criteria {
+			weight := weights[criterion.Code]
+
+			weightedSum += criterion.Average * weight
+			weightSum += weight
+		}
+
+		// no weighted criteria were rated in this period
+		if weightSum == 0 || dormitory.TotalRatings == 0 {
+			continue
+		}
+
+		average := weightedSum / weightSum
+
+		globalSum += average * float64(dormitory.TotalRatings)
+		globalRatings += dormitory.TotalRatings
+
+		entries = append(entries, rmodel.LeaderboardEntry{
+			DormitoryId:  dormitory.DormitoryId,
+			Name:         dormitory.Name,
+			Average:      average,
+			TotalRatings: dormitory.TotalRatings,
+		})
+	}
+
+	if globalRatings == 0 {
+		return entries, 0
+	}
+
+	globalAverage := globalSum / float64(globalRatings)
+
+	for i := range entries {
+		var (
+			v = float64(entries[i].TotalRatings)
+			m = float64(minRatings)
+		)
+
+		entries[i].Score = roundScore((v*entries[i].Average + m*globalAverage) / (v + m))
+		entries[i].Average = roundScore(entries[i].Average)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+
+		if entries[i].TotalRatings != entries[j].TotalRatings {
+			return entries[i].TotalRatings > entries[j].TotalRatings
+		}
+
+		return entries[i].DormitoryId < entries[j].DormitoryId
+	})
+
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+
+	return entries, roundScore(globalAverage)
+}
+
+// gradesNextPeriodStart возвращает начало периода, следующего за periodStart
+func gradesNextPeriodStart(periodStart time.Time, granularity rmodel.GradesGranularity) time.Time {
+	switch granularity {
+	case rmodel.GradesGranularityYear:
+		return periodStart.AddDate(1, 0, 0)
+	case rmodel.GradesGranularitySemester:
+		if periodStart.Month() == time.September {
+			return time.Date(periodStart.Year()+1, time.February, 1, 0, 0, 0, 0, time.UTC)
+		}
+
+		return time.Date(periodStart.Year(), time.September, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return periodStart.AddDate(0, 1, 0)
+	}
+}
+
+func roundScore(val float64) float64 {
+	return math.Round(val*100) / 100
+}
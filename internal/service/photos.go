@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"mime/multipart"
+	"path"
 
 	"github.com/dormitory-life/core/internal/constants"
 	rmodel "github.com/dormitory-life/core/internal/server/request_models"
@@ -119,3 +121,91 @@ func (s *CoreService) DeleteDormitoryPhotos(
 		DormitoryId: request.DormitoryId,
 	}, nil
 }
+
+// entityPhotoPaths переводит пути или имена файлов в полные пути фото из каталога prefix
+// сущности subEntityId. Удалять можно только существующие фото этого каталога
+func (s *CoreService) entityPhotoPaths(
+	ctx context.Context,
+	category constants.FileCategory,
+	dormitoryId string,
+	subEntityId string,
+	prefix string,
+	photos []string,
+) ([]string, error) {
+	if len(photos) == 0 {
+		return nil, nil
+	}
+
+	filesResp, err := s.s3Client.GetEntityFiles(ctx, &storage.GetEntityFilesRequest{
+		Category:    category,
+		EntityId:    dormitoryId,
+		SubEntityId: subEntityId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting %s photos: %v", ErrInternal, category, err)
+	}
+
+	existing := make(map[string]struct{}, len(filesResp.FilesInfo))
+	for _, info := range filesResp.FilesInfo {
+		existing[info.Path] = struct{}{}
+	}
+
+	var (
+		paths = make([]string, 0, len(photos))
+		seen  = make(map[string]struct{}, len(photos))
+	)
+
+	for _, photo := range photos {
+		photoPath := prefix + path.Base(photo)
+
+		if _, ok := existing[photoPath]; !ok {
+			return nil, fmt.Errorf("%w: photo %s not found in %s", ErrBadRequest, photo, category)
+		}
+
+		if _, ok := seen[photoPath]; ok {
+			continue
+		}
+
+		seen[photoPath] = struct{}{}
+		paths = append(paths, photoPath)
+	}
+
+	return paths, nil
+}
+
+func (s *CoreService) uploadEntityPhoto(
+	ctx context.Context,
+	category constants.FileCategory,
+	dormitoryId string,
+	subEntityId string,
+	photoFileHeader *multipart.FileHeader,
+) (*storage.UploadResult, error) {
+	file, err := photoFileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", photoFileHeader.Filename, err)
+	}
+	defer file.Close()
+
+	return s.s3Client.Upload(ctx, &storage.UploadRequest{
+		Category:    category,
+		EntityId:    dormitoryId,
+		SubEntityId: subEntityId,
+		PhotoId:     uuid.New().String(),
+		FileName:    photoFileHeader.Filename,
+		Reader:      file,
+		Size:        photoFileHeader.Size,
+		MimeType:    s.s3Client.GetMimeType(photoFileHeader.Filename),
+	})
+}
+
+func (s *CoreService) deletePhotos(ctx context.Context, paths []string) {
+	for _, photoPath := range paths {
+		if err := s.s3Client.Delete(ctx, &storage.DeleteFileRequest{
+			Path: &photoPath,
+		}); err != nil {
+			s.logger.Warn("error deleting photo",
+				slog.String("error", err.Error()),
+				slog.String("path", photoPath))
+		}
+	}
+}
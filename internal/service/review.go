@@ -22,9 +22,17 @@ func (s *CoreService) GetReviews(
 		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
 	}
 
+	// скрытые отзывы видят автор и администраторы общежития
+	viewerId := s.extractViewerIdFromRequestContext(ctx)
+
 	resp, err := s.repository.GetReviews(ctx, &dbtypes.GetDormitoryReviewsRequest{
-		DormitoryId: request.DormitoryId,
-		Page:        request.Page,
+		DormitoryId:   request.DormitoryId,
+		Page:          request.Page,
+		Sort:          request.Sort,
+		MinGrade:      request.MinGrade,
+		MaxGrade:      request.MaxGrade,
+		ViewerId:      viewerId,
+		IncludeHidden: s.isDormitoryAdmin(ctx, viewerId, request.DormitoryId),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: error getting reviews: %v", s.handleDBError(err), err)
@@ -205,3 +213,142 @@ func (s *CoreService) DeleteReview(
 
 	return &rmodel.DeleteReviewResponse{}, nil
 }
+
+func (s *CoreService) UpdateReview(
+	ctx context.Context,
+	request *rmodel.UpdateReviewRequest,
+) (*rmodel.UpdateReviewResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	// only owner can edit review
+	reviewInfo, err := s.repository.GetReviewById(ctx, &dbtypes.GetReviewByIdRequest{
+		ReviewId: request.ReviewId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting review: %v", s.handleDBError(err), err)
+	}
+
+	if reviewInfo.Review.DormitoryId != request.DormitoryId {
+		return nil, fmt.Errorf("%w: review not found in dormitory", ErrNotFound)
+	}
+
+	if userId != reviewInfo.Review.OwnerId {
+		return nil, fmt.Errorf("%w: user is not owner of review", ErrForbidden)
+	}
+
+	if err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  request.DormitoryId,
+			RoleRequired: false,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	removePaths, err := s.entityPhotoPaths(
+		ctx,
+		constants.CategoryReviewPhotos,
+		request.DormitoryId,
+		request.ReviewId,
+		fmt.Sprintf(constants.PathReviewPhotos, request.DormitoryId, request.ReviewId),
+		request.RemovePhotos,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var addedPaths []string
+
+	for _, photoFileHeader := range request.PhotoFilesHeaders {
+		uploadResult, err := s.uploadEntityPhoto(ctx, constants.CategoryReviewPhotos, request.DormitoryId, request.ReviewId, photoFileHeader)
+		if err != nil {
+			s.deletePhotos(ctx, addedPaths)
+
+			return nil, fmt.Errorf("%w: upload failed: %v", ErrInternal, err)
+		}
+
+		addedPaths = append(addedPaths, uploadResult.FilePath)
+	}
+
+	updateResp, err := s.repository.UpdateReview(ctx, &dbtypes.UpdateReviewRequest{
+		ReviewId:      request.ReviewId,
+		EditorId:      userId,
+		Title:         request.Title,
+		Description:   request.Description,
+		AddedPhotos:   addedPaths,
+		RemovedPhotos: removePaths,
+	})
+	if err != nil {
+		s.deletePhotos(ctx, addedPaths)
+
+		return nil, fmt.Errorf("%w: error updating review: %v", s.handleDBError(err), err)
+	}
+
+	// правка уже сохранена, поэтому неудачное удаление фото только логируем
+	s.deletePhotos(ctx, removePaths)
+
+	res := &rmodel.UpdateReviewResponse{
+		Review: *new(rmodel.Review).From(&updateResp.Review),
+	}
+
+	reviewPhotos, err := s.s3Client.GetEntityFiles(ctx, &storage.GetEntityFilesRequest{
+		Category:    constants.CategoryReviewPhotos,
+		EntityId:    request.DormitoryId,
+		SubEntityId: request.ReviewId,
+	})
+	if err != nil {
+		s.logger.Warn("error getting dormitory review photos",
+			slog.String("error", err.Error()),
+			slog.String("dormId", request.DormitoryId),
+			slog.String("reviewId", request.ReviewId))
+	}
+	res.ReviewPhotos = rmodel.ConvertFileInfos(reviewPhotos)
+
+	return res, nil
+}
+
+func (s *CoreService) GetReviewEdits(
+	ctx context.Context,
+	request *rmodel.GetReviewEditsRequest,
+) (*rmodel.GetReviewEditsResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	reviewInfo, err := s.repository.GetReviewById(ctx, &dbtypes.GetReviewByIdRequest{
+		ReviewId: request.ReviewId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting review: %v", s.handleDBError(err), err)
+	}
+
+	if reviewInfo.Review.DormitoryId != request.DormitoryId {
+		return nil, fmt.Errorf("%w: review not found in dormitory", ErrNotFound)
+	}
+
+	resp, err := s.repository.GetReviewEdits(ctx, &dbtypes.GetReviewEditsRequest{
+		ReviewId: request.ReviewId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting review edits: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.GetReviewEditsResponse).From(request.ReviewId, resp), nil
+}
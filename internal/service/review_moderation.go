@@ -0,0 +1,126 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+func (s *CoreService) CreateReviewReport(
+	ctx context.Context,
+	request *rmodel.CreateReviewReportRequest,
+) (*rmodel.CreateReviewReportResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  request.DormitoryId,
+			RoleRequired: false,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	reviewInfo, err := s.repository.GetReviewById(ctx, &dbtypes.GetReviewByIdRequest{
+		ReviewId: request.ReviewId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting review: %v", s.handleDBError(err), err)
+	}
+
+	// скрытый отзыв пользователю не виден, жаловаться на него не на что
+	if reviewInfo.Review.DormitoryId != request.DormitoryId ||
+		reviewInfo.Review.Status == dbtypes.ReviewStatusHidden {
+		return nil, fmt.Errorf("%w: review not found in dormitory", ErrNotFound)
+	}
+
+	if reviewInfo.Review.OwnerId == userId {
+		return nil, fmt.Errorf("%w: user can not report own review", ErrBadRequest)
+	}
+
+	resp, err := s.repository.CreateReviewReport(ctx, &dbtypes.CreateReviewReportRequest{
+		DormitoryId: request.DormitoryId,
+		ReviewId:    request.ReviewId,
+		ReporterId:  userId,
+		Reason:      request.Reason,
+		Comment:     request.Comment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error creating review report: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.CreateReviewReportResponse).From(resp), nil
+}
+
+func (s *CoreService) GetModerationReviews(
+	ctx context.Context,
+	request *rmodel.GetModerationReviewsRequest,
+) (*rmodel.GetModerationReviewsResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.GetModerationReviews(ctx, &dbtypes.GetModerationReviewsRequest{
+		DormitoryId: request.DormitoryId,
+		Status:      request.Status,
+		Page:        request.Page,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting moderation reviews: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.GetModerationReviewsResponse).From(request.Page, resp), nil
+}
+
+func (s *CoreService) ModerateReview(
+	ctx context.Context,
+	request *rmodel.ModerateReviewRequest,
+) (*rmodel.ModerateReviewResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.ModerateReview(ctx, &dbtypes.ModerateReviewRequest{
+		DormitoryId: request.DormitoryId,
+		ReviewId:    request.ReviewId,
+		Status:      request.Status,
+		ModeratorId: userId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error moderating review: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.ModerateReviewResponse).From(resp), nil
+}
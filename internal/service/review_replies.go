@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	"github.com/dormitory-life/core/internal/emailer"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+func (s *CoreService) CreateReviewReply(
+	ctx context.Context,
+	request *rmodel.CreateReviewReplyRequest,
+) (*rmodel.CreateReviewReplyResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	userId, err := s.checkReviewReplyAccess(ctx, request.DormitoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewInfo, err := s.repository.GetReviewById(ctx, &dbtypes.GetReviewByIdRequest{
+		ReviewId: request.ReviewId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting review: %v", s.handleDBError(err), err)
+	}
+
+	if reviewInfo.Review.DormitoryId != request.DormitoryId {
+		return nil, fmt.Errorf("%w: review not found in dormitory", ErrNotFound)
+	}
+
+	resp, err := s.repository.CreateReviewReply(ctx, &dbtypes.CreateReviewReplyRequest{
+		ReviewId: request.ReviewId,
+		AuthorId: userId,
+		Text:     request.Text,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error creating review reply: %v", s.handleDBError(err), err)
+	}
+
+	go s.notifyReviewReply(context.WithoutCancel(ctx), &reviewInfo.Review, request.Text)
+
+	return &rmodel.CreateReviewReplyResponse{
+		ReviewReply: *new(rmodel.ReviewReply).From(&resp.Reply),
+	}, nil
+}
+
+func (s *CoreService) DeleteReviewReply(
+	ctx context.Context,
+	request *rmodel.DeleteReviewReplyRequest,
+) (*rmodel.DeleteReviewReplyResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if _, err := s.checkReviewReplyAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	reviewInfo, err := s.repository.GetReviewById(ctx, &dbtypes.GetReviewByIdRequest{
+		ReviewId: request.ReviewId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting review: %v", s.handleDBError(err), err)
+	}
+
+	if reviewInfo.Review.DormitoryId != request.DormitoryId {
+		return nil, fmt.Errorf("%w: review not found in dormitory", ErrNotFound)
+	}
+
+	if _, err := s.repository.DeleteReviewReply(ctx, &dbtypes.DeleteReviewReplyRequest{
+		ReviewId: request.ReviewId,
+		ReplyId:  request.ReplyId,
+	}); err != nil {
+		return nil, fmt.Errorf("%w: error deleting review reply: %v", s.handleDBError(err), err)
+	}
+
+	return &rmodel.DeleteReviewReplyResponse{}, nil
+}
+
+// checkReviewReplyAccess - отвечать на отзывы может только администрация этого общежития
+func (s *CoreService) checkReviewReplyAccess(ctx context.Context, dormitoryId string) (string, error) {
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	roleResp, err := s.repository.GetUsersRole(ctx, &dbtypes.GetUsersRoleRequest{
+		UserId: userId,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: error getting user role: %v", s.handleDBError(err), err)
+	}
+
+	if roleResp.Role != dbtypes.UserAdminRole {
+		return "", fmt.Errorf("%w: user role is not admin", ErrForbidden)
+	}
+
+	if err := s.checkAdminAccess(ctx, dormitoryId); err != nil {
+		return "", err
+	}
+
+	return userId, nil
+}
+
+// notifyReviewReply отправляет автору отзыва письмо об ответе. Ошибки только логируем:
+// ответ уже сохранен
+func (s *CoreService) notifyReviewReply(ctx context.Context, review *dbtypes.Review, text string) {
+	if s.emailer == nil {
+		return
+	}
+
+	emailResp, err := s.repository.GetUserEmail(ctx, &dbtypes.GetUserEmailRequest{
+		UserId: review.OwnerId,
+	})
+	if err != nil {
+		s.logger.Warn("error getting review author email",
+			slog.String("error", err.Error()),
+			slog.String("reviewId", review.ReviewId))
+
+		return
+	}
+
+	if err := s.emailer.SendReviewReplyMessage(ctx, &emailer.SendReviewReplyRequest{
+		UserEmail:   emailResp.Email,
+		ReviewTitle: review.Title,
+		ReplyText:   text,
+	}); err != nil {
+		s.logger.Warn("error sending review reply email",
+			slog.String("error", err.Error()),
+			slog.String("reviewId", review.ReviewId))
+	}
+}
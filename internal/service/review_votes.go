@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+func (s *CoreService) VoteReview(
+	ctx context.Context,
+	request *rmodel.VoteReviewRequest,
+) (*rmodel.VoteReviewResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	helpful := request.Vote == rmodel.ReviewVoteHelpful
+
+	return s.voteReview(ctx, request.DormitoryId, request.ReviewId, &helpful)
+}
+
+func (s *CoreService) DeleteReviewVote(
+	ctx context.Context,
+	request *rmodel.DeleteReviewVoteRequest,
+) (*rmodel.VoteReviewResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	return s.voteReview(ctx, request.DormitoryId, request.ReviewId, nil)
+}
+
+// voteReview - голосовать могут жители общежития, кроме автора отзыва
+func (s *CoreService) voteReview(
+	ctx context.Context,
+	dormitoryId string,
+	reviewId string,
+	helpful *bool,
+) (*rmodel.VoteReviewResponse, error) {
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	if err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  dormitoryId,
+			RoleRequired: false,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	reviewInfo, err := s.repository.GetReviewById(ctx, &dbtypes.GetReviewByIdRequest{
+		ReviewId: reviewId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting review: %v", s.handleDBError(err), err)
+	}
+
+	if reviewInfo.Review.DormitoryId != dormitoryId ||
+		reviewInfo.Review.Status == dbtypes.ReviewStatusHidden {
+		return nil, fmt.Errorf("%w: review not found in dormitory", ErrNotFound)
+	}
+
+	if reviewInfo.Review.OwnerId == userId {
+		return nil, fmt.Errorf("%w: user can not vote for own review", ErrBadRequest)
+	}
+
+	resp, err := s.repository.VoteReview(ctx, &dbtypes.VoteReviewRequest{
+		ReviewId: reviewId,
+		UserId:   userId,
+		Helpful:  helpful,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error voting for review: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.VoteReviewResponse).From(resp), nil
+}
@@ -0,0 +1,227 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+func (s *CoreService) GetDormitoryRooms(
+	ctx context.Context,
+	request *rmodel.GetDormitoryRoomsRequest,
+) (*rmodel.GetDormitoryRoomsResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	resp, err := s.repository.GetDormitoryRooms(ctx, &dbtypes.GetDormitoryRoomsRequest{
+		DormitoryId: request.DormitoryId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting dormitory rooms: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.GetDormitoryRoomsResponse).From(resp), nil
+}
+
+func (s *CoreService) GetDormitoryOccupancy(
+	ctx context.Context,
+	request *rmodel.GetDormitoryOccupancyRequest,
+) (*rmodel.GetDormitoryOccupancyResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	resp, err := s.repository.GetDormitoryOccupancy(ctx, &dbtypes.GetDormitoryOccupancyRequest{
+		DormitoryId: request.DormitoryId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting dormitory occupancy: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.GetDormitoryOccupancyResponse).From(request.DormitoryId, resp), nil
+}
+
+func (s *CoreService) CreateBuilding(
+	ctx context.Context,
+	request *rmodel.CreateBuildingRequest,
+) (*rmodel.CreateBuildingResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	resp, err := s.repository.CreateBuilding(ctx, &dbtypes.CreateBuildingRequest{
+		DormitoryId: request.DormitoryId,
+		Name:        request.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error creating building: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.CreateBuildingResponse).From(resp), nil
+}
+
+func (s *CoreService) DeleteBuilding(
+	ctx context.Context,
+	request *rmodel.DeleteBuildingRequest,
+) (*rmodel.DeleteBuildingResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.DeleteBuilding(ctx, &dbtypes.DeleteBuildingRequest{
+		DormitoryId: request.DormitoryId,
+		BuildingId:  request.BuildingId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error deleting building: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.DeleteBuildingResponse).From(resp), nil
+}
+
+func (s *CoreService) CreateFloor(
+	ctx context.Context,
+	request *rmodel.CreateFloorRequest,
+) (*rmodel.CreateFloorResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.CreateFloor(ctx, &dbtypes.CreateFloorRequest{
+		DormitoryId: request.DormitoryId,
+		BuildingId:  request.BuildingId,
+		Number:      request.Number,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error creating floor: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.CreateFloorResponse).From(resp), nil
+}
+
+func (s *CoreService) DeleteFloor(
+	ctx context.Context,
+	request *rmodel.DeleteFloorRequest,
+) (*rmodel.DeleteFloorResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.DeleteFloor(ctx, &dbtypes.DeleteFloorRequest{
+		DormitoryId: request.DormitoryId,
+		FloorId:     request.FloorId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error deleting floor: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.DeleteFloorResponse).From(resp), nil
+}
+
+func (s *CoreService) CreateRoom(
+	ctx context.Context,
+	request *rmodel.CreateRoomRequest,
+) (*rmodel.CreateRoomResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	resp, err := s.repository.CreateRoom(ctx, &dbtypes.CreateRoomRequest{
+		DormitoryId: request.DormitoryId,
+		FloorId:     request.FloorId,
+		Number:      request.Number,
+		Type:        request.Type,
+		Capacity:    request.Capacity,
+		Occupied:    request.Occupied,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error creating room: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.CreateRoomResponse).From(resp), nil
+}
+
+func (s *CoreService) UpdateRoom(
+	ctx context.Context,
+	request *rmodel.UpdateRoomRequest,
+) (*rmodel.UpdateRoomResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	resp, err := s.repository.UpdateRoom(ctx, &dbtypes.UpdateRoomRequest{
+		DormitoryId: request.DormitoryId,
+		RoomId:      request.RoomId,
+		Number:      request.Number,
+		Type:        request.Type,
+		Capacity:    request.Capacity,
+		Occupied:    request.Occupied,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error updating room: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.UpdateRoomResponse).From(resp), nil
+}
+
+func (s *CoreService) DeleteRoom(
+	ctx context.Context,
+	request *rmodel.DeleteRoomRequest,
+) (*rmodel.DeleteRoomResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	if err := s.checkAdminAccess(ctx, request.DormitoryId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repository.DeleteRoom(ctx, &dbtypes.DeleteRoomRequest{
+		DormitoryId: request.DormitoryId,
+		RoomId:      request.RoomId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error deleting room: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.DeleteRoomResponse).From(resp), nil
+}
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+)
+
+func (s *CoreService) SearchDormitory(
+	ctx context.Context,
+	request *rmodel.SearchDormitoryRequest,
+) (*rmodel.SearchDormitoryResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	// скрытые отзывы находят только автор и администраторы, как в GetReviews
+	viewerId := s.extractViewerIdFromRequestContext(ctx)
+
+	resp, err := s.repository.SearchDormitory(ctx, &dbtypes.SearchDormitoryRequest{
+		DormitoryId:   request.DormitoryId,
+		Query:         request.Query,
+		Limit:         request.Limit,
+		ViewerId:      viewerId,
+		IncludeHidden: s.isDormitoryAdmin(ctx, viewerId, request.DormitoryId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error searching dormitory: %v", s.handleDBError(err), err)
+	}
+
+	return new(rmodel.SearchDormitoryResponse).From(request.Query, resp), nil
+}
@@ -6,45 +6,86 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/dormitory-life/core/internal/announcements"
 	"github.com/dormitory-life/core/internal/auth"
 	"github.com/dormitory-life/core/internal/broker"
 	"github.com/dormitory-life/core/internal/cache"
 	"github.com/dormitory-life/core/internal/database"
 	dberrors "github.com/dormitory-life/core/internal/database/errors"
+	"github.com/dormitory-life/core/internal/emailer"
 	rmodel "github.com/dormitory-life/core/internal/server/request_models"
 	"github.com/dormitory-life/core/internal/storage"
 	"github.com/dormitory-life/core/internal/support"
 )
 
 type CoreServiceConfig struct {
-	Repository    database.Repository
-	AuthClient    *auth.AuthClient
-	Logger        slog.Logger
-	S3Client      storage.Storage
-	BrokerClient  *broker.BrokerClient
-	SupportClient support.SupportClient
-	CacheClient   cache.CacheClient
+	Repository         database.Repository
+	AuthClient         *auth.AuthClient
+	Logger             slog.Logger
+	S3Client           storage.Storage
+	BrokerClient       *broker.BrokerClient
+	SupportClient      support.SupportClient
+	CacheClient        cache.CacheClient
+	Emailer            *emailer.Emailer
+	Ratings            RatingsConfig
+	AnnouncementClient announcements.AnnouncementClient
 }
+
+type RatingsConfig struct {
+	Weights    map[string]float64
+	MinRatings int
+}
+
 type CoreService struct {
-	repository    database.Repository
-	authClient    *auth.AuthClient
-	logger        slog.Logger
-	s3Client      storage.Storage
-	brokerClient  *broker.BrokerClient
-	supportClient support.SupportClient
-	cacheClient   cache.CacheClient
+	repository         database.Repository
+	authClient         *auth.AuthClient
+	logger             slog.Logger
+	s3Client           storage.Storage
+	brokerClient       *broker.BrokerClient
+	supportClient      support.SupportClient
+	cacheClient        cache.CacheClient
+	emailer            *emailer.Emailer
+	ratings            RatingsConfig
+	announcementClient announcements.AnnouncementClient
 }
 
 type CoreServiceClient interface {
 	GetDormitories(ctx context.Context, request *rmodel.GetDormitoriesRequest) (*rmodel.GetDormitoriesResponse, error)
 	GetDormitoryById(ctx context.Context, request *rmodel.GetDormitoryByIdRequest) (*rmodel.GetDormitoryByIdResponse, error)
+	GetNearbyDormitories(ctx context.Context, request *rmodel.GetNearbyDormitoriesRequest) (*rmodel.GetNearbyDormitoriesResponse, error)
 	CreateDormitory(ctx context.Context, request *rmodel.CreateDormitoryRequest) (*rmodel.CreateDormitoryResponse, error)
 	UpdateDormitory(ctx context.Context, request *rmodel.UpdateDormitoryRequest) (*rmodel.UpdateDormitoryResponse, error)
 	DeleteDormitory(ctx context.Context, request *rmodel.DeleteDormitoryRequest) (*rmodel.DeleteDormitoryResponse, error)
 
+	GetAmenities(ctx context.Context, request *rmodel.GetAmenitiesRequest) (*rmodel.GetAmenitiesResponse, error)
+	CreateAmenity(ctx context.Context, request *rmodel.CreateAmenityRequest) (*rmodel.CreateAmenityResponse, error)
+	UpdateAmenity(ctx context.Context, request *rmodel.UpdateAmenityRequest) (*rmodel.UpdateAmenityResponse, error)
+	DeleteAmenity(ctx context.Context, request *rmodel.DeleteAmenityRequest) (*rmodel.DeleteAmenityResponse, error)
+	SetDormitoryAmenities(ctx context.Context, request *rmodel.SetDormitoryAmenitiesRequest) (*rmodel.SetDormitoryAmenitiesResponse, error)
+
+	GetDormitoryRooms(ctx context.Context, request *rmodel.GetDormitoryRoomsRequest) (*rmodel.GetDormitoryRoomsResponse, error)
+	GetDormitoryOccupancy(ctx context.Context, request *rmodel.GetDormitoryOccupancyRequest) (*rmodel.GetDormitoryOccupancyResponse, error)
+	CreateBuilding(ctx context.Context, request *rmodel.CreateBuildingRequest) (*rmodel.CreateBuildingResponse, error)
+	DeleteBuilding(ctx context.Context, request *rmodel.DeleteBuildingRequest) (*rmodel.DeleteBuildingResponse, error)
+	CreateFloor(ctx context.Context, request *rmodel.CreateFloorRequest) (*rmodel.CreateFloorResponse, error)
+	DeleteFloor(ctx context.Context, request *rmodel.DeleteFloorRequest) (*rmodel.DeleteFloorResponse, error)
+	CreateRoom(ctx context.Context, request *rmodel.CreateRoomRequest) (*rmodel.CreateRoomResponse, error)
+	UpdateRoom(ctx context.Context, request *rmodel.UpdateRoomRequest) (*rmodel.UpdateRoomResponse, error)
+	DeleteRoom(ctx context.Context, request *rmodel.DeleteRoomRequest) (*rmodel.DeleteRoomResponse, error)
+
 	GetDormitoriesAvgGrades(ctx context.Context, request *rmodel.GetDormitoriesAvgGradesRequest) (*rmodel.GetDormitoriesAvgGradesResponse, error)
 	GetDormitoryAvgGrades(ctx context.Context, request *rmodel.GetDormitoryAvgGradesRequest) (*rmodel.GetDormitoryAvgGradesResponse, error)
+	GetDormitoryGradesHistory(ctx context.Context, request *rmodel.GetDormitoryGradesHistoryRequest) (*rmodel.GetDormitoryGradesHistoryResponse, error)
+	GetGradesDistribution(ctx context.Context, request *rmodel.GetGradesDistributionRequest) (*rmodel.GetGradesDistributionResponse, error)
+	GetGradesLeaderboard(ctx context.Context, request *rmodel.GetGradesLeaderboardRequest) (*rmodel.GetGradesLeaderboardResponse, error)
 	CreateDormitoryGrade(ctx context.Context, request *rmodel.CreateDormitoryGradeRequest) (*rmodel.CreateDormitoryGradeResponse, error)
+	UpdateDormitoryGrade(ctx context.Context, request *rmodel.UpdateDormitoryGradeRequest) (*rmodel.UpdateDormitoryGradeResponse, error)
+	DeleteDormitoryGrade(ctx context.Context, request *rmodel.DeleteDormitoryGradeRequest) (*rmodel.DeleteDormitoryGradeResponse, error)
+	GetModerationGrades(ctx context.Context, request *rmodel.GetModerationGradesRequest) (*rmodel.GetModerationGradesResponse, error)
+	ModerateGrade(ctx context.Context, request *rmodel.ModerateGradeRequest) (*rmodel.ModerateGradeResponse, error)
+	GetGradeCriteria(ctx context.Context, request *rmodel.GetGradeCriteriaRequest) (*rmodel.GetGradeCriteriaResponse, error)
+	CreateGradeCriterion(ctx context.Context, request *rmodel.CreateGradeCriterionRequest) (*rmodel.CreateGradeCriterionResponse, error)
+	UpdateGradeCriterion(ctx context.Context, request *rmodel.UpdateGradeCriterionRequest) (*rmodel.UpdateGradeCriterionResponse, error)
 
 	CreateDormitoryPhotos(ctx context.Context, request *rmodel.CreateDormitoryPhotosRequest) (*rmodel.CreateDormitoryPhotosResponse, error)
 	DeleteDormitoryPhotos(ctx context.Context, request *rmodel.DeleteDormitoryPhotosRequest) (*rmodel.DeleteDormitoryPhotosResponse, error)
@@ -53,11 +94,31 @@ type CoreServiceClient interface {
 
 	GetReviews(ctx context.Context, request *rmodel.GetDormitoryReviewsRequest) (*rmodel.GetDormitoryReviewsResponse, error)
 	CreateReview(ctx context.Context, request *rmodel.CreateReviewRequest) (*rmodel.CreateReviewResponse, error)
+	UpdateReview(ctx context.Context, request *rmodel.UpdateReviewRequest) (*rmodel.UpdateReviewResponse, error)
 	DeleteReview(ctx context.Context, request *rmodel.DeleteReviewRequest) (*rmodel.DeleteReviewResponse, error)
+	GetReviewEdits(ctx context.Context, request *rmodel.GetReviewEditsRequest) (*rmodel.GetReviewEditsResponse, error)
+	CreateReviewReport(ctx context.Context, request *rmodel.CreateReviewReportRequest) (*rmodel.CreateReviewReportResponse, error)
+	GetModerationReviews(ctx context.Context, request *rmodel.GetModerationReviewsRequest) (*rmodel.GetModerationReviewsResponse, error)
+	ModerateReview(ctx context.Context, request *rmodel.ModerateReviewRequest) (*rmodel.ModerateReviewResponse, error)
+	CreateReviewReply(ctx context.Context, request *rmodel.CreateReviewReplyRequest) (*rmodel.CreateReviewReplyResponse, error)
+	DeleteReviewReply(ctx context.Context, request *rmodel.DeleteReviewReplyRequest) (*rmodel.DeleteReviewReplyResponse, error)
+	VoteReview(ctx context.Context, request *rmodel.VoteReviewRequest) (*rmodel.VoteReviewResponse, error)
+	DeleteReviewVote(ctx context.Context, request *rmodel.DeleteReviewVoteRequest) (*rmodel.VoteReviewResponse, error)
 
 	GetDormitoryEvents(ctx context.Context, request *rmodel.GetDormitoryEventsRequest) (*rmodel.GetDormitoryEventsResponse, error)
 	CreateDormitoryEvent(ctx context.Context, request *rmodel.CreateDormitoryEventRequest) (*rmodel.CreateDormitoryEventResponse, error)
+	UpdateDormitoryEvent(ctx context.Context, request *rmodel.UpdateDormitoryEventRequest) (*rmodel.UpdateDormitoryEventResponse, error)
 	DeleteDormitoryEvent(ctx context.Context, request *rmodel.DeleteDormitoryEventRequest) (*rmodel.DeleteDormitoryEventResponse, error)
+	RsvpEvent(ctx context.Context, request *rmodel.RsvpEventRequest) (*rmodel.RsvpEventResponse, error)
+	DeleteEventRsvp(ctx context.Context, request *rmodel.DeleteEventRsvpRequest) (*rmodel.RsvpEventResponse, error)
+	GetEventAttendees(ctx context.Context, request *rmodel.GetEventAttendeesRequest) (*rmodel.GetEventAttendeesResponse, error)
+	UpdateEventOccurrence(ctx context.Context, request *rmodel.UpdateEventOccurrenceRequest) (*rmodel.UpdateEventOccurrenceResponse, error)
+	CancelEventOccurrence(ctx context.Context, request *rmodel.CancelEventOccurrenceRequest) (*rmodel.UpdateEventOccurrenceResponse, error)
+	GetEventsCalendar(ctx context.Context, request *rmodel.GetEventsCalendarRequest) (*rmodel.GetEventsCalendarResponse, error)
+	GetEventsSyndication(ctx context.Context, request *rmodel.GetEventsSyndicationRequest) (*rmodel.GetEventsSyndicationResponse, error)
+	UpdateEventAnnouncement(ctx context.Context, request *rmodel.UpdateEventAnnouncementRequest) (*rmodel.UpdateEventAnnouncementResponse, error)
+
+	SearchDormitory(ctx context.Context, request *rmodel.SearchDormitoryRequest) (*rmodel.SearchDormitoryResponse, error)
 
 	GetChat(ctx context.Context, request *rmodel.GetChatMessagesRequest) (*rmodel.GetChatMessagesResponse, error)
 	CreateChatMessage(ctx context.Context, request *rmodel.CreateChatMessageRequest) (*rmodel.CreateChatMessageResponse, error)
@@ -65,13 +126,16 @@ type CoreServiceClient interface {
 
 func New(cfg CoreServiceConfig) CoreServiceClient {
 	return &CoreService{
-		repository:    cfg.Repository,
-		authClient:    cfg.AuthClient,
-		logger:        cfg.Logger,
-		s3Client:      cfg.S3Client,
-		brokerClient:  cfg.BrokerClient,
-		supportClient: cfg.SupportClient,
-		cacheClient:   cfg.CacheClient,
+		repository:         cfg.Repository,
+		authClient:         cfg.AuthClient,
+		logger:             cfg.Logger,
+		s3Client:           cfg.S3Client,
+		brokerClient:       cfg.BrokerClient,
+		supportClient:      cfg.SupportClient,
+		cacheClient:        cfg.CacheClient,
+		emailer:            cfg.Emailer,
+		ratings:            cfg.Ratings,
+		announcementClient: cfg.AnnouncementClient,
 	}
 }
 
@@ -111,6 +175,73 @@ func (s *CoreService) checkAccess(
 	return nil
 }
 
+// checkAdminAccess проверяет, что пользователь из контекста - администратор общежития
+func (s *CoreService) checkAdminAccess(
+	ctx context.Context,
+	dormitoryId string,
+) error {
+	userId, _, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	return s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  dormitoryId,
+			RoleRequired: true,
+		},
+	)
+}
+
+// checkCatalogAdminAccess - общие справочники (удобства, критерии оценки) может править
+// администратор любого общежития
+func (s *CoreService) checkCatalogAdminAccess(ctx context.Context) error {
+	_, dormitoryId, err := s.extractIdsFromRequestContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: error getting ids from context: %v", ErrInternal, err)
+	}
+
+	return s.checkAdminAccess(ctx, dormitoryId)
+}
+
+// isDormitoryAdmin - мягкая проверка прав для публичных ручек: ошибка auth сервиса
+// не ломает запрос, а считается отсутствием прав
+func (s *CoreService) isDormitoryAdmin(
+	ctx context.Context,
+	userId string,
+	dormitoryId string,
+) bool {
+	if userId == "" {
+		return false
+	}
+
+	err := s.checkAccess(
+		ctx,
+		&rmodel.CheckAccessRequest{
+			UserId:       userId,
+			DormitoryId:  dormitoryId,
+			RoleRequired: true,
+		},
+	)
+	if err != nil && !errors.Is(err, ErrForbidden) {
+		s.logger.Warn("error checking admin access",
+			slog.String("error", err.Error()),
+			slog.String("userId", userId),
+			slog.String("dormId", dormitoryId))
+	}
+
+	return err == nil
+}
+
+// extractViewerIdFromRequestContext возвращает id пользователя, если запрос авторизован
+func (s *CoreService) extractViewerIdFromRequestContext(ctx context.Context) string {
+	userId, _ := ctx.Value("userId").(string)
+
+	return userId
+}
+
 func (s *CoreService) extractIdsFromRequestContext(ctx context.Context) (string, string, error) {
 	userId := ctx.Value("userId")
 	dormitoryId := ctx.Value("dormitoryId")
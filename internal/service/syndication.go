@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dormitory-life/core/internal/constants"
+	dbtypes "github.com/dormitory-life/core/internal/database/types"
+	rmodel "github.com/dormitory-life/core/internal/server/request_models"
+	"github.com/dormitory-life/core/internal/storage"
+)
+
+func (s *CoreService) GetEventsSyndication(
+	ctx context.Context,
+	request *rmodel.GetEventsSyndicationRequest,
+) (*rmodel.GetEventsSyndicationResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("%w: request is nil", ErrBadRequest)
+	}
+
+	dormitory, err := s.repository.GetDormitoryById(ctx, &dbtypes.GetDormitoryByIdRequest{
+		DormitoryId: request.DormitoryId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting dormitory: %v", s.handleDBError(err), err)
+	}
+
+	resp, err := s.repository.GetEventsSyndication(ctx, &dbtypes.GetEventsSyndicationRequest{
+		DormitoryId: request.DormitoryId,
+		Limit:       constants.MaxSyndicationEntries,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error getting events syndication: %v", s.handleDBError(err), err)
+	}
+
+	now := time.Now()
+
+	// агрегаторы опрашивают ленту часто, неизменившуюся отдаем без обращений к хранилищу
+	validators := rmodel.NewSyndicationValidators(request, dormitory.Dormitory.Name, resp.Events, now)
+	if rmodel.ETagMatches(request.IfNoneMatch, validators.ETag) {
+		validators.NotModified = true
+
+		return validators, nil
+	}
+
+	enclosures := make(map[string]*rmodel.SyndicationEnclosure, len(resp.Events))
+
+	for _, event := range resp.Events {
+		eventPhotos, err := s.s3Client.GetEntityFiles(ctx, &storage.GetEntityFilesRequest{
+			Category:    constants.CategoryEventPhotos,
+			EntityId:    request.DormitoryId,
+			SubEntityId: event.EventId,
+			Amount:      1,
+		})
+		if err != nil {
+			s.logger.Warn("error getting dormitory event photos",
+				slog.String("error", err.Error()),
+				slog.String("dormId", request.DormitoryId),
+				slog.String("eventId", event.EventId))
+
+			continue
+		}
+
+		if eventPhotos == nil || len(eventPhotos.FilesInfo) == 0 {
+			continue
+		}
+
+		photo := eventPhotos.FilesInfo[0]
+
+		enclosures[event.EventId] = &rmodel.SyndicationEnclosure{
+			URL:      photo.URL,
+			Size:     photo.Size,
+			MimeType: s.s3Client.GetMimeType(photo.Name),
+		}
+	}
+
+	feed, err := new(rmodel.GetEventsSyndicationResponse).From(request, dormitory.Dormitory.Name, resp, enclosures, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error rendering feed: %v", ErrInternal, err)
+	}
+
+	return feed, nil
+}
@@ -2,11 +2,11 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/dormitory-life/core/internal/constants"
 	"github.com/minio/minio-go/v7"
@@ -45,7 +45,8 @@ type Storage interface {
 	// Delete удаляет файл по относительному пути в S3
 	Delete(ctx context.Context, req *DeleteFileRequest) error
 
-	// DeleteAll удаляет все файлы по пути /{category}/{entityId}/
+	// DeleteAll удаляет все файлы по пути /{category}/{entityId}/.
+	// Возвращает ошибку, если хотя бы один файл не удалось удалить; повторный вызов безопасен
 	DeleteAll(ctx context.Context, req *DeleteAllRequest) error
 
 	// GetFileUrl - вспомогательная функция по получению ссылки для пользователя на файл в S3
@@ -271,9 +272,12 @@ func (m *MinIOClient) DeleteAll(
 		Recursive: true,
 	})
 
+	var errs []error
+
 	for obj := range objectsCh {
 		if obj.Err != nil {
 			m.logger.Error("error listing objects", slog.String("error", obj.Err.Error()))
+			errs = append(errs, fmt.Errorf("failed to list objects: %w", obj.Err))
 			continue
 		}
 
@@ -285,10 +289,11 @@ func (m *MinIOClient) DeleteAll(
 				slog.String("object", obj.Key),
 				slog.String("error", err.Error()),
 			)
+			errs = append(errs, err)
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 func (m *MinIOClient) GetFileURL(filePath string) string {
@@ -296,7 +301,7 @@ func (m *MinIOClient) GetFileURL(filePath string) string {
 		context.Background(),
 		m.bucket,
 		filePath,
-		24*time.Hour,
+		constants.PresignedURLExpiry,
 		nil,
 	)
 
@@ -328,6 +333,8 @@ func getFileExtension(filename string) string {
 
 func getPathByCategory(category constants.FileCategory, entityId string, subEntityId string) (string, error) {
 	switch category {
+	case constants.CategoryDormitoryFiles:
+		return fmt.Sprintf(constants.PathDormitory, entityId), nil
 	case constants.CategoryDormitoryPhotos:
 		return fmt.Sprintf(constants.PathDormitoryPhotos, entityId), nil
 	case constants.CategoryEventPhotos:
@@ -0,0 +1,9 @@
+ALTER TABLE dormitory
+ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION CHECK (
+    latitude BETWEEN -90 AND 90
+),
+ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION CHECK (
+    longitude BETWEEN -180 AND 180
+);
+
+CREATE INDEX IF NOT EXISTS idx_dormitory_coordinates ON dormitory (latitude, longitude);
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS amenities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    code VARCHAR(64) UNIQUE NOT NULL,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS dormitory_amenities (
+    dormitory_id VARCHAR(2) NOT NULL REFERENCES dormitory (id) ON DELETE CASCADE,
+    amenity_id UUID NOT NULL REFERENCES amenities (id) ON DELETE CASCADE,
+    PRIMARY KEY (dormitory_id, amenity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dormitory_amenities_amenity_id ON dormitory_amenities (amenity_id);
+
+INSERT INTO
+    amenities (code, name)
+VALUES ('laundry', 'Прачечная'),
+    ('gym', 'Спортзал'),
+    ('study_room', 'Учебная комната'),
+    (
+        'kitchen_per_floor',
+        'Кухня на каждом этаже'
+    ),
+    ('wifi', 'Wi-Fi'),
+    ('shower_per_floor', 'Душ на каждом этаже'),
+    ('bike_parking', 'Велопарковка'),
+    ('security', 'Круглосуточная охрана')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS dormitory_buildings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    dormitory_id VARCHAR(2) NOT NULL REFERENCES dormitory (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (dormitory_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS dormitory_floors (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    building_id UUID NOT NULL REFERENCES dormitory_buildings (id) ON DELETE CASCADE,
+    number INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (building_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS dormitory_rooms (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    floor_id UUID NOT NULL REFERENCES dormitory_floors (id) ON DELETE CASCADE,
+    number VARCHAR(16) NOT NULL,
+    type VARCHAR(16) NOT NULL CHECK (type IN ('corridor', 'block')),
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    occupied INTEGER NOT NULL DEFAULT 0 CHECK (
+        occupied >= 0
+        AND occupied <= capacity
+    ),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (floor_id, number)
+);
@@ -0,0 +1,176 @@
+-- Реестр критериев оценки вместо фиксированных колонок в grades
+CREATE TABLE IF NOT EXISTS grade_criteria (
+    code VARCHAR(64) PRIMARY KEY,
+    labels JSONB NOT NULL DEFAULT '{}', -- {"ru": "...", "en": "..."}
+    weight DECIMAL(4, 2) NOT NULL DEFAULT 1 CHECK (weight > 0),
+    position INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO
+    grade_criteria (code, labels, position)
+VALUES
+    ('bathroom_cleanliness', '{"ru": "Чистота санузлов", "en": "Bathroom cleanliness"}', 1),
+    ('corridor_cleanliness', '{"ru": "Чистота коридоров", "en": "Corridor cleanliness"}', 2),
+    ('kitchen_cleanliness', '{"ru": "Чистота кухонь", "en": "Kitchen cleanliness"}', 3),
+    ('cleaning_frequency', '{"ru": "Частота уборки", "en": "Cleaning frequency"}', 4),
+    ('room_spaciousness', '{"ru": "Просторность комнат", "en": "Room spaciousness"}', 5),
+    ('corridor_spaciousness', '{"ru": "Просторность коридоров", "en": "Corridor spaciousness"}', 6),
+    ('kitchen_spaciousness', '{"ru": "Просторность кухонь", "en": "Kitchen spaciousness"}', 7),
+    ('shower_location_convenience', '{"ru": "Удобство расположения душевых", "en": "Shower location convenience"}', 8),
+    ('equipment_maintenance', '{"ru": "Исправность оборудования", "en": "Equipment maintenance"}', 9),
+    ('window_condition', '{"ru": "Состояние окон", "en": "Window condition"}', 10),
+    ('noise_isolation', '{"ru": "Шумоизоляция", "en": "Noise isolation"}', 11),
+    ('common_areas_equipment', '{"ru": "Оснащение общих зон", "en": "Common areas equipment"}', 12),
+    ('transport_accessibility', '{"ru": "Транспортная доступность", "en": "Transport accessibility"}', 13),
+    ('administration_quality', '{"ru": "Работа администрации", "en": "Administration quality"}', 14),
+    ('residents_culture_level', '{"ru": "Культура проживающих", "en": "Residents culture level"}', 15)
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS grade_scores (
+    grade_id UUID NOT NULL REFERENCES grades (id) ON DELETE CASCADE,
+    criterion_code VARCHAR(64) NOT NULL REFERENCES grade_criteria (code) ON UPDATE CASCADE,
+    score INTEGER NOT NULL CHECK (score BETWEEN 1 AND 5),
+    PRIMARY KEY (grade_id, criterion_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_grade_scores_criterion_code ON grade_scores (criterion_code);
+
+CREATE TABLE IF NOT EXISTS dormitory_average_scores (
+    dormitory_id VARCHAR(2) NOT NULL REFERENCES dormitory (id) ON DELETE CASCADE,
+    period_date DATE NOT NULL,
+    criterion_code VARCHAR(64) NOT NULL REFERENCES grade_criteria (code) ON UPDATE CASCADE,
+    avg_score DECIMAL(3, 2) NOT NULL,
+    total_ratings INTEGER NOT NULL,
+    PRIMARY KEY (dormitory_id, period_date, criterion_code)
+);
+
+-- Перенос существующих оценок и средних
+INSERT INTO
+    grade_scores (grade_id, criterion_code, score)
+SELECT g.id, c.code, c.score
+FROM grades g
+    CROSS JOIN LATERAL (
+        VALUES
+        ('bathroom_cleanliness', g.bathroom_cleanliness),
+        ('corridor_cleanliness', g.corridor_cleanliness),
+        ('kitchen_cleanliness', g.kitchen_cleanliness),
+        ('cleaning_frequency', g.cleaning_frequency),
+        ('room_spaciousness', g.room_spaciousness),
+        ('corridor_spaciousness', g.corridor_spaciousness),
+        ('kitchen_spaciousness', g.kitchen_spaciousness),
+        ('shower_location_convenience', g.shower_location_convenience),
+        ('equipment_maintenance', g.equipment_maintenance),
+        ('window_condition', g.window_condition),
+        ('noise_isolation', g.noise_isolation),
+        ('common_areas_equipment', g.common_areas_equipment),
+        ('transport_accessibility', g.transport_accessibility),
+        ('administration_quality', g.administration_quality),
+        ('residents_culture_level', g.residents_culture_level)
+    ) AS c (code, score)
+WHERE c.score IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO
+    dormitory_average_scores (
+        dormitory_id,
+        period_date,
+        criterion_code,
+        avg_score,
+        total_ratings
+    )
+SELECT dag.dormitory_id, dag.period_date, c.code, c.avg_score, dag.total_ratings
+FROM dormitory_average_grades dag
+    CROSS JOIN LATERAL (
+        VALUES
+        ('bathroom_cleanliness', dag.avg_bathroom_cleanliness),
+        ('corridor_cleanliness', dag.avg_corridor_cleanliness),
+        ('kitchen_cleanliness', dag.avg_kitchen_cleanliness),
+        ('cleaning_frequency', dag.avg_cleaning_frequency),
+        ('room_spaciousness', dag.avg_room_spaciousness),
+        ('corridor_spaciousness', dag.avg_corridor_spaciousness),
+        ('kitchen_spaciousness', dag.avg_kitchen_spaciousness),
+        ('shower_location_convenience', dag.avg_shower_location_convenience),
+        ('equipment_maintenance', dag.avg_equipment_maintenance),
+        ('window_condition', dag.avg_window_condition),
+        ('noise_isolation', dag.avg_noise_isolation),
+        ('common_areas_equipment', dag.avg_common_areas_equipment),
+        ('transport_accessibility', dag.avg_transport_accessibility),
+        ('administration_quality', dag.avg_administration_quality),
+        ('residents_culture_level', dag.avg_residents_culture_level)
+    ) AS c (code, avg_score)
+WHERE c.avg_score IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- Средние теперь пересчитывает сервис в той же транзакции, что и изменение оценки
+DROP TRIGGER IF EXISTS grades_insert_trigger ON grades;
+
+DROP TRIGGER IF EXISTS grades_update_trigger ON grades;
+
+DROP TRIGGER IF EXISTS grades_delete_trigger ON grades;
+
+DROP FUNCTION IF EXISTS update_dormitory_averages_trigger ();
+
+DROP FUNCTION IF EXISTS recompute_dormitory_period_averages (VARCHAR, DATE);
+
+-- Колонки критериев больше не заполняются и оставлены только для отката
+ALTER TABLE grades ALTER COLUMN bathroom_cleanliness DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN corridor_cleanliness DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN kitchen_cleanliness DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN cleaning_frequency DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN room_spaciousness DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN corridor_spaciousness DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN kitchen_spaciousness DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN shower_location_convenience DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN equipment_maintenance DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN window_condition DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN noise_isolation DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN common_areas_equipment DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN transport_accessibility DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN administration_quality DROP NOT NULL;
+
+ALTER TABLE grades ALTER COLUMN residents_culture_level DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_bathroom_cleanliness DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_corridor_cleanliness DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_kitchen_cleanliness DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_cleaning_frequency DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_room_spaciousness DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_corridor_spaciousness DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_kitchen_spaciousness DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_shower_location_convenience DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_equipment_maintenance DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_window_condition DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_noise_isolation DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_common_areas_equipment DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_transport_accessibility DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_administration_quality DROP NOT NULL;
+
+ALTER TABLE dormitory_average_grades ALTER COLUMN avg_residents_culture_level DROP NOT NULL;
@@ -0,0 +1,20 @@
+-- Подозрительные оценки не участвуют в средних, пока их не проверит администратор
+ALTER TABLE grades
+ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'approved' CHECK (
+    status IN ('approved', 'flagged', 'rejected')
+);
+
+ALTER TABLE grades
+ADD COLUMN IF NOT EXISTS flag_reasons TEXT [] NOT NULL DEFAULT '{}';
+
+ALTER TABLE grades
+ADD COLUMN IF NOT EXISTS reviewed_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE grades ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_grades_moderation ON grades (dormitory_id, status, created_at DESC)
+WHERE
+    status <> 'approved';
+
+-- Для подсчета активности по общежитию при поиске всплесков
+CREATE INDEX IF NOT EXISTS idx_grades_dormitory_updated_at ON grades (dormitory_id, updated_at DESC);
@@ -0,0 +1,17 @@
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE;
+
+-- История правок отзыва для модераторов
+CREATE TABLE IF NOT EXISTS review_edits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    review_id UUID NOT NULL REFERENCES reviews (id) ON DELETE CASCADE,
+    editor_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    old_title TEXT NOT NULL,
+    new_title TEXT NOT NULL,
+    old_description TEXT NOT NULL,
+    new_description TEXT NOT NULL,
+    added_photos TEXT [] NOT NULL DEFAULT '{}',
+    removed_photos TEXT [] NOT NULL DEFAULT '{}',
+    edited_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_edits_review_id ON review_edits (review_id, edited_at DESC);
@@ -0,0 +1,32 @@
+-- Скрытые администратором отзывы видят только администраторы и автор
+ALTER TABLE reviews
+ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'published' CHECK (
+    status IN ('published', 'hidden', 'pending')
+);
+
+ALTER TABLE reviews
+ADD COLUMN IF NOT EXISTS moderated_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderated_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_reviews_moderation ON reviews (dormitory_id, status, created_at DESC)
+WHERE
+    status <> 'published';
+
+-- Жалобы пользователей на отзывы
+CREATE TABLE IF NOT EXISTS review_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    review_id UUID NOT NULL REFERENCES reviews (id) ON DELETE CASCADE,
+    reporter_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    reason VARCHAR(32) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Пока жалоба не рассмотрена, повторно пожаловаться на тот же отзыв нельзя
+CREATE UNIQUE INDEX IF NOT EXISTS uq_review_reports_open ON review_reports (review_id, reporter_id)
+WHERE
+    resolved_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_review_reports_review_id ON review_reports (review_id, created_at DESC);
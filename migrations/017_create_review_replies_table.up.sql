@@ -0,0 +1,10 @@
+-- Официальные ответы администрации на отзывы
+CREATE TABLE IF NOT EXISTS review_replies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    review_id UUID NOT NULL REFERENCES reviews (id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_replies_review_id ON review_replies (review_id, created_at);
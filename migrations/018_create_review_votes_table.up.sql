@@ -0,0 +1,31 @@
+-- Оценки полезности отзывов: один голос пользователя на отзыв
+CREATE TABLE IF NOT EXISTS review_votes (
+    review_id UUID NOT NULL REFERENCES reviews (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    helpful BOOLEAN NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (review_id, user_id)
+);
+
+-- Счетчики храним в отзыве, чтобы сортировать страницу по индексу без агрегации голосов
+ALTER TABLE reviews
+ADD COLUMN IF NOT EXISTS helpful_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE reviews
+ADD COLUMN IF NOT EXISTS unhelpful_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_reviews_dormitory_created_at ON reviews (dormitory_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_dormitory_most_helpful ON reviews (
+    dormitory_id,
+    (helpful_count - unhelpful_count) DESC,
+    created_at DESC
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_dormitory_controversial ON reviews (
+    dormitory_id,
+    LEAST(helpful_count, unhelpful_count) DESC,
+    (helpful_count + unhelpful_count) DESC,
+    created_at DESC
+);
@@ -0,0 +1,19 @@
+-- Полнотекстовый поиск с русской морфологией. Заголовок весит больше описания
+ALTER TABLE reviews
+ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(title, '')), 'A') || setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(title, '')), 'A') || setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE chat_messages
+ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (to_tsvector('russian', coalesce(text, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_reviews_search_vector ON reviews USING GIN (search_vector);
+
+CREATE INDEX IF NOT EXISTS idx_feed_search_vector ON feed USING GIN (search_vector);
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_search_vector ON chat_messages USING GIN (search_vector);
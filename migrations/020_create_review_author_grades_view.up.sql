@@ -0,0 +1,45 @@
+-- Оценка автора отзыва за месяц отзыва: общий балл и крайние критерии.
+-- Отклоненные модератором оценки не показываем
+CREATE OR REPLACE VIEW review_author_grades AS
+SELECT
+    r.id AS review_id,
+    r.dormitory_id,
+    g.id AS grade_id,
+    s.overall,
+    lo.criterion_code AS lowest_code,
+    lo.score AS lowest_score,
+    hi.criterion_code AS highest_code,
+    hi.score AS highest_score
+FROM
+    reviews r
+    JOIN grades g ON g.dormitory_id = r.dormitory_id
+    AND g.user_id = r.owner_id
+    AND DATE_TRUNC('month', g.created_at) = DATE_TRUNC('month', r.created_at::timestamp)
+    AND g.status <> 'rejected'
+    CROSS JOIN LATERAL (
+        SELECT ROUND(
+                SUM(gs.score * c.weight) / SUM(c.weight), 2
+            ) AS overall
+        FROM grade_scores gs
+            JOIN grade_criteria c ON c.code = gs.criterion_code
+        WHERE
+            gs.grade_id = g.id
+    ) s
+    CROSS JOIN LATERAL (
+        SELECT gs.criterion_code, gs.score
+        FROM grade_scores gs
+            JOIN grade_criteria c ON c.code = gs.criterion_code
+        WHERE
+            gs.grade_id = g.id
+        ORDER BY gs.score ASC, c.position ASC
+        LIMIT 1
+    ) lo
+    CROSS JOIN LATERAL (
+        SELECT gs.criterion_code, gs.score
+        FROM grade_scores gs
+            JOIN grade_criteria c ON c.code = gs.criterion_code
+        WHERE
+            gs.grade_id = g.id
+        ORDER BY gs.score DESC, c.position ASC
+        LIMIT 1
+    ) hi;
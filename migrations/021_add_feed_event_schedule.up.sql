@@ -0,0 +1,40 @@
+-- События ленты получают время проведения, место и категорию.
+-- Записи без starts_at остаются обычными постами
+ALTER TABLE feed ADD COLUMN IF NOT EXISTS starts_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE feed ADD COLUMN IF NOT EXISTS ends_at TIMESTAMP WITH TIME ZONE;
+
+-- Место проведения: комната общежития или общая зона, описанная текстом
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS room_id UUID REFERENCES dormitory_rooms (id) ON DELETE SET NULL;
+
+ALTER TABLE feed ADD COLUMN IF NOT EXISTS location TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS category VARCHAR(32) NOT NULL DEFAULT 'other' CHECK (
+    category IN (
+        'meeting',
+        'cleaning',
+        'sport',
+        'culture',
+        'education',
+        'party',
+        'other'
+    )
+);
+
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS capacity INTEGER CHECK (capacity > 0);
+
+ALTER TABLE feed
+ADD CONSTRAINT feed_schedule_check CHECK (
+    ends_at IS NULL
+    OR (
+        starts_at IS NOT NULL
+        AND ends_at >= starts_at
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_dormitory_starts_at ON feed (dormitory_id, starts_at)
+WHERE
+    starts_at IS NOT NULL;
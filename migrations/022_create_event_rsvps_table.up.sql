@@ -0,0 +1,22 @@
+-- Ответы жителей на приглашение к событию. waitlisted выставляет сервер,
+-- когда мест на событии не осталось
+CREATE TABLE IF NOT EXISTS event_rsvps (
+    event_id UUID NOT NULL REFERENCES feed (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL CHECK (
+        status IN (
+            'going',
+            'maybe',
+            'not_going',
+            'waitlisted'
+        )
+    ),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, user_id)
+);
+
+-- Очередь листа ожидания: кто раньше в нее попал, тот раньше и проходит
+CREATE INDEX IF NOT EXISTS idx_event_rsvps_status ON event_rsvps (event_id, status, updated_at);
+
+CREATE INDEX IF NOT EXISTS idx_event_rsvps_user_id ON event_rsvps (user_id);
@@ -0,0 +1,45 @@
+-- Правило повторения события в духе RRULE (RFC 5545): частота, шаг, окончание
+-- по дате или числу повторений и исключенные даты
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS recurrence_freq VARCHAR(16) CHECK (
+    recurrence_freq IN ('daily', 'weekly', 'monthly')
+);
+
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS recurrence_interval INTEGER NOT NULL DEFAULT 1 CHECK (recurrence_interval > 0);
+
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS recurrence_until TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS recurrence_count INTEGER CHECK (recurrence_count > 0);
+
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS recurrence_exdates TIMESTAMP WITH TIME ZONE[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE feed
+ADD CONSTRAINT feed_recurrence_check CHECK (
+    recurrence_freq IS NULL
+    OR (
+        starts_at IS NOT NULL
+        AND (
+            recurrence_until IS NULL
+            OR recurrence_count IS NULL
+        )
+    )
+);
+
+-- Изменения и отмены отдельных повторений. occurrence_start - исходное время
+-- начала повторения по правилу, по нему повторение и находится
+CREATE TABLE IF NOT EXISTS event_occurrences (
+    event_id UUID NOT NULL REFERENCES feed (id) ON DELETE CASCADE,
+    occurrence_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    cancelled BOOLEAN NOT NULL DEFAULT FALSE,
+    title TEXT,
+    description TEXT,
+    starts_at TIMESTAMP WITH TIME ZONE,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    location TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, occurrence_start)
+);
@@ -0,0 +1,16 @@
+-- Закрепление, срок показа и срочность постов ленты. pinned_at - время закрепления,
+-- NULL - пост не закреплен. После expires_at пост пропадает из ленты по умолчанию
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS pinned_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE feed
+ADD COLUMN IF NOT EXISTS urgent BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_feed_dormitory_pinned ON feed (
+    dormitory_id,
+    pinned_at DESC NULLS LAST,
+    created_at DESC
+);
@@ -0,0 +1,12 @@
+-- Время последнего изменения поста, нужно лентам RSS и Atom
+ALTER TABLE feed ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE feed
+SET
+    updated_at = COALESCE(created_at, CURRENT_TIMESTAMP)
+WHERE
+    updated_at IS NULL;
+
+ALTER TABLE feed ALTER COLUMN updated_at SET DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE feed ALTER COLUMN updated_at SET NOT NULL;